@@ -39,7 +39,7 @@ func ServeRestAPI() {
 	commonRepos := dependency.InitCommonRepos(adapters, drivers, appConfigs)
 	appRepos := dependency.InitRepos(drivers.Db, drivers.Redis)
 	appUsecase := dependency.InitUsecase(appConfigs, appRepos, commonRepos)
-	appHandlers := dependency.InitHandlers(ctx, appConfigs, appUsecase)
+	appHandlers := dependency.InitHandlers(ctx, appConfigs, drivers.Redis, appUsecase)
 
 	appRouter := router.Router{
 		ApplicationHandler: appHandlers,
@@ -29,6 +29,9 @@ const (
 	REDIS_ADDR_SECRET_MANAGER        = "APEXA_REDIS_ADDR"
 	REDIS_PASSWORD_SECRET_MANAGER    = "APEXA_REDIS_PASSWORD"
 	REDIS_DB_SECRET_MANAGER          = "APEXA_REDIS_DB"
+
+	// MIN_ENCRYPTION_KEY_LENGTH is the shortest passphrase accepted to encrypt MFA secrets
+	MIN_ENCRYPTION_KEY_LENGTH = 32
 )
 
 type Config struct {
@@ -48,18 +51,44 @@ type Config struct {
 	Gotenberg  GotenbergConfig
 	Media      MediaConfig
 	Token      TokenConfig
+	Mailer     MailerConfig
+	OIDC       OIDCConfig
 }
 
 type TokenConfig struct {
 	TokenExpiration string `json:"TokenExpiration"`
+	SigningSecret   string `json:"TOKEN_SigningSecret"`
+	EncryptionKey   string `json:"TOKEN_EncryptionKey"`
+}
+
+type MailerConfig struct {
+	Provider string `json:"MAILER_Provider"`
+}
+
+// OIDCConfig lists the OpenID Connect providers users can sign in with, keyed by provider name.
+// In the secret manager it is an object under OIDC_Providers, e.g.
+// {"OIDC_Providers": {"hospital": {"OIDC_Issuer": "...", "OIDC_ClientID": "...", ...}}}
+type OIDCConfig struct {
+	Providers map[string]OIDCProviderConfig `json:"OIDC_Providers"`
+}
+
+type OIDCProviderConfig struct {
+	Issuer       string   `json:"OIDC_Issuer"`
+	ClientID     string   `json:"OIDC_ClientID"`
+	ClientSecret string   `json:"OIDC_ClientSecret"`
+	RedirectURL  string   `json:"OIDC_RedirectURL"` // Frontend page receiving the authorization code
+	Scopes       []string `json:"OIDC_Scopes"`      // Defaults to openid, email and profile
 }
 
 type HttpConfig struct {
-	Address      string        `json:"HTTP_Address"`
-	ReadTimeout  time.Duration `json:"HTTP_ReadTimeout"`
-	WriteTimeout time.Duration `json:"HTTP_WriteTimeout"`
-	ApiPrefix    string        `json:"HTTP_ApiPrefix"`
-	BaseURL      string        `json:"HTTP_BaseURL"`
+	Address        string        `json:"HTTP_Address"`
+	ReadTimeout    time.Duration `json:"HTTP_ReadTimeout"`
+	WriteTimeout   time.Duration `json:"HTTP_WriteTimeout"`
+	ApiPrefix      string        `json:"HTTP_ApiPrefix"`
+	BaseURL        string        `json:"HTTP_BaseURL"`
+	FrontendURL    string        `json:"HTTP_FrontendURL"` // Web app hosting the pages emailed links open
+	ProxyHeader    string        `json:"HTTP_ProxyHeader"`
+	TrustedProxies []string      `json:"HTTP_TrustedProxies"`
 }
 
 type DatabaseConfig struct {
@@ -204,9 +233,21 @@ func GetConfig() (*Config, error) {
 	if env != LOCAL_ENV {
 		config.loadFromSecretManager()
 	}
+	config.checkToken()
 	return &config, nil
 }
 
+// checkToken refuses to start without the secrets tokens and links depend on, every
+// request using them would fail otherwise
+func (c *Config) checkToken() {
+	if c.Token.SigningSecret == "" {
+		app_log.Fatal("Failed to load configuration: missing token signing secret")
+	}
+	if len(c.Token.EncryptionKey) < MIN_ENCRYPTION_KEY_LENGTH {
+		app_log.Fatalf("Failed to load configuration: token encryption key must be at least %d characters", MIN_ENCRYPTION_KEY_LENGTH)
+	}
+}
+
 func (c *Config) loadFromSecretManager() {
 	app_log.Info("load config from secret manager...")
 	var provider, region, accessKey, secretKey, secretName string
@@ -263,9 +304,13 @@ func (c *Config) getKeyFromSecretManager(secret *SecretManagerConfig) {
 		app_log.Fatal("Secret value is binary, which is not supported by this example.")
 	}
 
-	secretByte := []byte(*result.SecretString)
+	c.parseSecret([]byte(*result.SecretString))
+}
+
+// parseSecret fills the config from the JSON secret, every section reads its prefixed keys
+func (c *Config) parseSecret(secretByte []byte) {
 	//parsing Http config
-	err = json.Unmarshal(secretByte, &c.Http)
+	err := json.Unmarshal(secretByte, &c.Http)
 	if err != nil {
 		app_log.Fatalf("Error parsing secret Http: %v", err)
 	}
@@ -335,4 +380,22 @@ func (c *Config) getKeyFromSecretManager(secret *SecretManagerConfig) {
 	if err != nil {
 		app_log.Fatalf("Error parsing secret Secret: %v", err)
 	}
+
+	//parsing Token config
+	err = json.Unmarshal(secretByte, &c.Token)
+	if err != nil {
+		app_log.Fatalf("Error parsing secret Token: %v", err)
+	}
+
+	//parsing Mailer config
+	err = json.Unmarshal(secretByte, &c.Mailer)
+	if err != nil {
+		app_log.Fatalf("Error parsing secret Mailer: %v", err)
+	}
+
+	//parsing OIDC config
+	err = json.Unmarshal(secretByte, &c.OIDC)
+	if err != nil {
+		app_log.Fatalf("Error parsing secret OIDC: %v", err)
+	}
 }
@@ -0,0 +1,44 @@
+package config
+
+import "testing"
+
+func TestConfig_parseSecret(t *testing.T) {
+	secret := `{
+		"HTTP_BaseURL": "https://api.example-hospital.org",
+		"TOKEN_SigningSecret": "signing-secret",
+		"TOKEN_EncryptionKey": "encryption-key",
+		"MAILER_Provider": "SES",
+		"OIDC_Providers": {
+			"hospital": {
+				"OIDC_Issuer": "https://sso.example-hospital.org",
+				"OIDC_ClientID": "hospital-cms",
+				"OIDC_ClientSecret": "client-secret",
+				"OIDC_RedirectURL": "https://example-hospital.org/auth/callback",
+				"OIDC_Scopes": ["openid", "email"]
+			}
+		}
+	}`
+
+	var c Config
+	c.parseSecret([]byte(secret))
+
+	if c.Http.BaseURL != "https://api.example-hospital.org" {
+		t.Errorf("Http.BaseURL = %q", c.Http.BaseURL)
+	}
+	if c.Token.SigningSecret != "signing-secret" || c.Token.EncryptionKey != "encryption-key" {
+		t.Errorf("Token = %+v, want the signing secret and encryption key", c.Token)
+	}
+	if c.Mailer.Provider != "SES" {
+		t.Errorf("Mailer.Provider = %q, want SES", c.Mailer.Provider)
+	}
+
+	provider, ok := c.OIDC.Providers["hospital"]
+	if !ok {
+		t.Fatalf("OIDC.Providers = %+v, want the hospital provider", c.OIDC.Providers)
+	}
+	if provider.Issuer != "https://sso.example-hospital.org" || provider.ClientID != "hospital-cms" ||
+		provider.ClientSecret != "client-secret" || provider.RedirectURL != "https://example-hospital.org/auth/callback" ||
+		len(provider.Scopes) != 2 {
+		t.Errorf("OIDC provider = %+v", provider)
+	}
+}
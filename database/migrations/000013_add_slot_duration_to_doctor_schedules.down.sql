@@ -0,0 +1,3 @@
+-- Remove slot_duration column from doctor_schedules table
+ALTER TABLE doctor_schedules
+DROP COLUMN slot_duration;
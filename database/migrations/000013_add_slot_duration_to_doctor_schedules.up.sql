@@ -0,0 +1,3 @@
+-- Add slot_duration (in minutes) to doctor_schedules table
+ALTER TABLE doctor_schedules
+ADD COLUMN slot_duration INT NOT NULL DEFAULT 30 AFTER end_time;
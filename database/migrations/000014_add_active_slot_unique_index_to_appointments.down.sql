@@ -0,0 +1,5 @@
+-- Remove active slot unique index and generated column from appointments table
+DROP INDEX idx_appointments_active_slot ON appointments;
+
+ALTER TABLE appointments
+DROP COLUMN active_slot;
@@ -0,0 +1,7 @@
+-- Add active_slot generated column, it is only set for scheduled appointments so
+-- cancelled and completed appointments do not hold the slot (NULLs are ignored by unique indexes)
+ALTER TABLE appointments
+ADD COLUMN active_slot TINYINT GENERATED ALWAYS AS (IF(status = 'scheduled', 1, NULL)) STORED;
+
+-- Prevent double booking of the same doctor slot
+CREATE UNIQUE INDEX idx_appointments_active_slot ON appointments(doctor_id, appointment_date, appointment_time, active_slot);
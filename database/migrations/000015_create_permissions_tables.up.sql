@@ -0,0 +1,54 @@
+-- Create permissions table
+CREATE TABLE IF NOT EXISTS permissions (
+    id CHAR(36) NOT NULL COMMENT 'UUID v4',
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_permissions_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Create role_permissions table (junction table for many-to-many relationship)
+CREATE TABLE IF NOT EXISTS role_permissions (
+    id CHAR(36) NOT NULL COMMENT 'UUID v4',
+    role_id CHAR(36) NOT NULL,
+    permission_id CHAR(36) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_role_permissions_role_id_permission_id (role_id, permission_id),
+    KEY idx_role_permissions_permission_id (permission_id),
+    CONSTRAINT fk_role_permissions_role_id FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_role_permissions_permission_id FOREIGN KEY (permission_id) REFERENCES permissions (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Insert default permissions
+INSERT INTO permissions (id, name, description) VALUES
+    (UUID(), 'article:write', 'Create, update and delete articles'),
+    (UUID(), 'doctor:write', 'Create, update and delete doctors'),
+    (UUID(), 'doctor:schedule:manage', 'Manage doctor schedules and reschedules'),
+    (UUID(), 'appointment:read:any', 'View appointments of any patient or doctor'),
+    (UUID(), 'role:manage', 'Manage roles, permissions and user role assignments')
+ON DUPLICATE KEY UPDATE
+    description = VALUES(description);
+
+-- Admins get every permission
+INSERT INTO role_permissions (id, role_id, permission_id)
+SELECT UUID(), r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name = 'admin';
+
+-- Staff permissions
+INSERT INTO role_permissions (id, role_id, permission_id)
+SELECT UUID(), r.id, p.id
+FROM roles r
+INNER JOIN permissions p ON (
+    (r.name = 'receptionist' AND p.name IN ('appointment:read:any', 'doctor:schedule:manage'))
+    OR (r.name = 'doctor' AND p.name IN ('appointment:read:any'))
+    OR (r.name = 'nurse' AND p.name IN ('appointment:read:any'))
+);
+
+-- Existing tokens carry role names as abilities, sign them out so abilities are resolved from permissions on next login
+UPDATE user_tokens SET deleted_at = NOW() WHERE deleted_at IS NULL;
@@ -0,0 +1,6 @@
+-- Remove token type, rotation family and usage tracking from user_tokens table
+ALTER TABLE user_tokens
+DROP KEY idx_user_tokens_family_id,
+DROP COLUMN used_at,
+DROP COLUMN family_id,
+DROP COLUMN type;
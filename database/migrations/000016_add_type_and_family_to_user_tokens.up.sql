@@ -0,0 +1,6 @@
+-- Add token type, rotation family and usage tracking to user_tokens table
+ALTER TABLE user_tokens
+ADD COLUMN type VARCHAR(20) NOT NULL DEFAULT 'access' AFTER token,
+ADD COLUMN family_id CHAR(36) NULL DEFAULT NULL AFTER type,
+ADD COLUMN used_at TIMESTAMP NULL DEFAULT NULL AFTER expired_at,
+ADD KEY idx_user_tokens_family_id (family_id);
@@ -0,0 +1,4 @@
+-- Digests cannot be turned back into plaintext tokens, so revoke them instead
+UPDATE user_tokens
+SET deleted_at = NOW()
+WHERE deleted_at IS NULL;
@@ -0,0 +1,6 @@
+-- Store user tokens as SHA-256 digests instead of plaintext.
+-- Existing tokens keep working: clients still present the raw value, which
+-- hashes to the digest written here.
+UPDATE user_tokens
+SET token = SHA2(token, 256)
+WHERE CHAR_LENGTH(token) <> 64;
@@ -0,0 +1,17 @@
+-- Create email_verification_tokens table
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id CHAR(36) NOT NULL COMMENT 'UUID v4',
+    user_id CHAR(36) NOT NULL,
+    token VARCHAR(255) NOT NULL COMMENT 'SHA-256 digest',
+    expired_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY idx_email_verification_tokens_user_id (user_id),
+    CONSTRAINT fk_email_verification_tokens_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Users activated before verification existed are considered verified
+UPDATE users
+SET email_verified_at = created_at
+WHERE status = 'active' AND email_verified_at IS NULL;
@@ -0,0 +1,9 @@
+-- Remove user administration and audit permissions
+DELETE FROM permissions WHERE name IN ('user:manage', 'audit:read');
+
+-- Drop auth_audit_logs table
+DROP TABLE IF EXISTS auth_audit_logs;
+
+-- Remove locked_until column from users table
+ALTER TABLE users
+DROP COLUMN locked_until;
@@ -0,0 +1,32 @@
+-- Record temporary lockouts on the user row
+ALTER TABLE users
+ADD COLUMN locked_until TIMESTAMP NULL DEFAULT NULL AFTER email_verified_at;
+
+-- Create auth_audit_logs table
+CREATE TABLE IF NOT EXISTS auth_audit_logs (
+    id CHAR(36) NOT NULL COMMENT 'UUID v4',
+    event VARCHAR(50) NOT NULL,
+    user_id CHAR(36) NULL DEFAULT NULL COMMENT 'User the event is about',
+    actor_id CHAR(36) NULL DEFAULT NULL COMMENT 'User who triggered the event, NULL for the system',
+    email VARCHAR(255) NULL DEFAULT NULL,
+    ip_address VARCHAR(45) NULL DEFAULT NULL,
+    metadata JSON NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY idx_auth_audit_logs_event (event),
+    KEY idx_auth_audit_logs_user_id (user_id),
+    KEY idx_auth_audit_logs_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Insert user administration and audit permissions
+INSERT INTO permissions (id, name, description) VALUES
+    (UUID(), 'user:manage', 'Manage user accounts, including unlocking them'),
+    (UUID(), 'audit:read', 'Review authentication audit logs')
+ON DUPLICATE KEY UPDATE
+    description = VALUES(description);
+
+INSERT IGNORE INTO role_permissions (id, role_id, permission_id)
+SELECT UUID(), r.id, p.id
+FROM roles r
+INNER JOIN permissions p ON p.name IN ('user:manage', 'audit:read')
+WHERE r.name = 'admin';
@@ -0,0 +1,29 @@
+-- Roles can require their users to sign in with a second factor
+ALTER TABLE roles
+ADD COLUMN mfa_required TINYINT(1) NOT NULL DEFAULT 0 AFTER description;
+
+UPDATE roles SET mfa_required = 1 WHERE name IN ('admin', 'doctor', 'receptionist');
+
+-- Create user_mfa table
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id CHAR(36) NOT NULL,
+    secret VARCHAR(255) NOT NULL COMMENT 'TOTP secret, encrypted',
+    last_used_step BIGINT NULL DEFAULT NULL COMMENT 'Time step of the last accepted code, refuses replays',
+    enabled_at TIMESTAMP NULL DEFAULT NULL COMMENT 'NULL until the first code is confirmed',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id),
+    CONSTRAINT fk_user_mfa_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Create mfa_recovery_codes table
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id CHAR(36) NOT NULL COMMENT 'UUID v4',
+    user_id CHAR(36) NOT NULL,
+    code CHAR(64) NOT NULL COMMENT 'SHA-256 digest of the code',
+    used_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY idx_mfa_recovery_codes_user_code (user_id, code),
+    CONSTRAINT fk_mfa_recovery_codes_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
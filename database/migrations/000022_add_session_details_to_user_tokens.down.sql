@@ -0,0 +1,5 @@
+-- Remove device and last activity tracking from user_tokens table
+ALTER TABLE user_tokens
+DROP COLUMN last_used_at,
+DROP COLUMN ip_address,
+DROP COLUMN user_agent;
@@ -0,0 +1,5 @@
+-- Record the device and last activity of each token so users can review their sessions
+ALTER TABLE user_tokens
+ADD COLUMN user_agent VARCHAR(255) NULL DEFAULT NULL AFTER ability,
+ADD COLUMN ip_address VARCHAR(45) NULL DEFAULT NULL AFTER user_agent,
+ADD COLUMN last_used_at TIMESTAMP NULL DEFAULT NULL AFTER used_at;
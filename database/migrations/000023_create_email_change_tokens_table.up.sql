@@ -0,0 +1,13 @@
+-- Create email_change_tokens table
+CREATE TABLE IF NOT EXISTS email_change_tokens (
+    id CHAR(36) NOT NULL COMMENT 'UUID v4',
+    user_id CHAR(36) NOT NULL,
+    new_email VARCHAR(255) NOT NULL COMMENT 'Address the link was sent to',
+    token VARCHAR(255) NOT NULL COMMENT 'SHA-256 digest',
+    expired_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY idx_email_change_tokens_user_id (user_id),
+    CONSTRAINT fk_email_change_tokens_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
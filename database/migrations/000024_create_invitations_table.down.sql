@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS invitation_roles;
+DROP TABLE IF EXISTS invitations;
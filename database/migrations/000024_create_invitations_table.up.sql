@@ -0,0 +1,28 @@
+-- Create invitations table
+CREATE TABLE IF NOT EXISTS invitations (
+    id CHAR(36) NOT NULL COMMENT 'UUID v4',
+    email VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    token VARCHAR(255) NOT NULL COMMENT 'SHA-256 digest',
+    invited_by CHAR(36) NULL DEFAULT NULL,
+    user_id CHAR(36) NULL DEFAULT NULL COMMENT 'Account created when the invitation was accepted',
+    expired_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP NULL DEFAULT NULL,
+    revoked_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY idx_invitations_email (email),
+    KEY idx_invitations_created_at (created_at),
+    CONSTRAINT fk_invitations_invited_by FOREIGN KEY (invited_by) REFERENCES users (id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT fk_invitations_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Create invitation_roles table (roles granted when the invitation is accepted)
+CREATE TABLE IF NOT EXISTS invitation_roles (
+    invitation_id CHAR(36) NOT NULL,
+    role_id CHAR(36) NOT NULL,
+    PRIMARY KEY (invitation_id, role_id),
+    KEY idx_invitation_roles_role_id (role_id),
+    CONSTRAINT fk_invitation_roles_invitation_id FOREIGN KEY (invitation_id) REFERENCES invitations (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_invitation_roles_role_id FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,14 @@
+-- Create user_identities table (accounts of external OpenID Connect providers linked to users)
+CREATE TABLE IF NOT EXISTS user_identities (
+    id CHAR(36) NOT NULL COMMENT 'UUID v4',
+    user_id CHAR(36) NOT NULL,
+    provider VARCHAR(50) NOT NULL COMMENT 'Provider name from the OIDC configuration',
+    subject VARCHAR(255) NOT NULL COMMENT 'sub claim, stable per provider',
+    email VARCHAR(255) NULL DEFAULT NULL COMMENT 'Email reported by the provider at the last login',
+    last_login_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_user_identities_provider_subject (provider, subject),
+    KEY idx_user_identities_user_id (user_id),
+    CONSTRAINT fk_user_identities_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,8 @@
+-- Remove patient record permission
+DELETE FROM permissions WHERE name = 'patient:read:any';
+
+ALTER TABLE appointments
+    DROP FOREIGN KEY fk_appointments_patient_id,
+    DROP COLUMN patient_id;
+
+DROP TABLE IF EXISTS patients;
@@ -0,0 +1,44 @@
+-- Create patients table (medical demographics of the people appointments are booked for)
+CREATE TABLE IF NOT EXISTS patients (
+    id CHAR(36) NOT NULL COMMENT 'UUID v4',
+    user_id CHAR(36) NULL DEFAULT NULL COMMENT 'Account the profile belongs to',
+    name VARCHAR(255) NOT NULL,
+    date_of_birth DATE NOT NULL,
+    gender ENUM('male', 'female') NOT NULL,
+    national_id VARCHAR(50) NULL DEFAULT NULL,
+    phone VARCHAR(20) NULL DEFAULT NULL,
+    address TEXT NULL,
+    blood_type VARCHAR(3) NULL DEFAULT NULL COMMENT 'ABO group and Rh factor, e.g. AB+',
+    allergies JSON NULL COMMENT 'List of known allergies',
+    emergency_contact_name VARCHAR(255) NULL DEFAULT NULL,
+    emergency_contact_phone VARCHAR(20) NULL DEFAULT NULL,
+    emergency_contact_relationship VARCHAR(50) NULL DEFAULT NULL,
+    guardian_name VARCHAR(255) NULL DEFAULT NULL COMMENT 'Required for minors',
+    guardian_phone VARCHAR(20) NULL DEFAULT NULL,
+    guardian_relationship VARCHAR(50) NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_patients_user_id (user_id),
+    UNIQUE KEY uk_patients_national_id (national_id),
+    KEY idx_patients_name (name),
+    CONSTRAINT fk_patients_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Appointments reference the patient profile, rows booked before profiles existed keep NULL
+ALTER TABLE appointments
+    ADD COLUMN patient_id CHAR(36) NULL DEFAULT NULL AFTER user_id,
+    ADD KEY idx_appointments_patient_id (patient_id),
+    ADD CONSTRAINT fk_appointments_patient_id FOREIGN KEY (patient_id) REFERENCES patients (id) ON DELETE SET NULL;
+
+-- Insert patient record permission
+INSERT INTO permissions (id, name, description) VALUES
+    (UUID(), 'patient:read:any', 'View the patient profile of any user')
+ON DUPLICATE KEY UPDATE
+    description = VALUES(description);
+
+INSERT IGNORE INTO role_permissions (id, role_id, permission_id)
+SELECT UUID(), r.id, p.id
+FROM roles r
+INNER JOIN permissions p ON p.name = 'patient:read:any'
+WHERE r.name IN ('admin', 'doctor', 'nurse', 'receptionist');
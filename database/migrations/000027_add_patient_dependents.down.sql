@@ -0,0 +1,4 @@
+ALTER TABLE patients
+    DROP FOREIGN KEY fk_patients_managed_by,
+    DROP KEY idx_patients_managed_by,
+    DROP COLUMN managed_by;
@@ -0,0 +1,5 @@
+-- Dependents are patient profiles without their own login, managed by a family member's account
+ALTER TABLE patients
+    ADD COLUMN managed_by CHAR(36) NULL DEFAULT NULL COMMENT 'Account managing a dependent profile' AFTER user_id,
+    ADD KEY idx_patients_managed_by (managed_by),
+    ADD CONSTRAINT fk_patients_managed_by FOREIGN KEY (managed_by) REFERENCES users (id) ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,12 @@
+-- Remove doctor portal permission
+DELETE FROM permissions WHERE name = 'doctor:portal';
+
+UPDATE appointments SET status = 'cancelled' WHERE status = 'no_show';
+
+ALTER TABLE appointments
+    MODIFY COLUMN status ENUM('scheduled', 'completed', 'cancelled') NOT NULL DEFAULT 'scheduled';
+
+ALTER TABLE doctors
+    DROP FOREIGN KEY fk_doctors_user_id,
+    DROP KEY uk_doctors_user_id,
+    DROP COLUMN user_id;
@@ -0,0 +1,21 @@
+-- Link doctor records to the account of the doctor
+ALTER TABLE doctors
+    ADD COLUMN user_id CHAR(36) NULL DEFAULT NULL COMMENT 'Account of the doctor' AFTER id,
+    ADD UNIQUE KEY uk_doctors_user_id (user_id),
+    ADD CONSTRAINT fk_doctors_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- Doctors mark patients who did not show up
+ALTER TABLE appointments
+    MODIFY COLUMN status ENUM('scheduled', 'completed', 'cancelled', 'no_show') NOT NULL DEFAULT 'scheduled';
+
+-- Insert doctor portal permission
+INSERT INTO permissions (id, name, description) VALUES
+    (UUID(), 'doctor:portal', 'Manage own agenda, appointments and schedules as a linked doctor')
+ON DUPLICATE KEY UPDATE
+    description = VALUES(description);
+
+INSERT IGNORE INTO role_permissions (id, role_id, permission_id)
+SELECT UUID(), r.id, p.id
+FROM roles r
+INNER JOIN permissions p ON p.name = 'doctor:portal'
+WHERE r.name = 'doctor';
@@ -0,0 +1,10 @@
+-- Remove service management permission
+DELETE FROM permissions WHERE name = 'service:write';
+
+ALTER TABLE services
+    DROP KEY uk_services_slug,
+    DROP KEY idx_services_sort_order,
+    DROP COLUMN is_active,
+    DROP COLUMN sort_order,
+    DROP COLUMN icon,
+    DROP COLUMN slug;
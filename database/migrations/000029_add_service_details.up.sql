@@ -0,0 +1,25 @@
+-- Services get a public slug, an icon, a display order and can be hidden without deleting them
+ALTER TABLE services
+    ADD COLUMN slug VARCHAR(255) NULL DEFAULT NULL AFTER name,
+    ADD COLUMN icon VARCHAR(255) NULL DEFAULT NULL COMMENT 'Icon name or URL shown by the frontend' AFTER slug,
+    ADD COLUMN sort_order INT NOT NULL DEFAULT 0 AFTER description,
+    ADD COLUMN is_active TINYINT(1) NOT NULL DEFAULT 1 AFTER sort_order;
+
+UPDATE services SET slug = LOWER(REPLACE(REPLACE(name, ' & ', '-and-'), ' ', '-'));
+
+ALTER TABLE services
+    MODIFY COLUMN slug VARCHAR(255) NOT NULL,
+    ADD UNIQUE KEY uk_services_slug (slug),
+    ADD KEY idx_services_sort_order (is_active, sort_order);
+
+-- Insert service management permission
+INSERT INTO permissions (id, name, description) VALUES
+    (UUID(), 'service:write', 'Create, update and delete services')
+ON DUPLICATE KEY UPDATE
+    description = VALUES(description);
+
+INSERT IGNORE INTO role_permissions (id, role_id, permission_id)
+SELECT UUID(), r.id, p.id
+FROM roles r
+INNER JOIN permissions p ON p.name = 'service:write'
+WHERE r.name = 'admin';
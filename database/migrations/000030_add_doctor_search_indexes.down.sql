@@ -0,0 +1,3 @@
+DROP INDEX idx_doctor_schedules_day_doctor ON doctor_schedules;
+DROP INDEX idx_doctors_name ON doctors;
+DROP INDEX idx_doctors_specialization ON doctors;
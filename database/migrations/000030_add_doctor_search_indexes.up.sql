@@ -0,0 +1,4 @@
+-- Indexes backing the doctor list filters and sort orders
+CREATE INDEX idx_doctors_specialization ON doctors(specialization);
+CREATE INDEX idx_doctors_name ON doctors(name);
+CREATE INDEX idx_doctor_schedules_day_doctor ON doctor_schedules(day, doctor_id);
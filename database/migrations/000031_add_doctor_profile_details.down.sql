@@ -0,0 +1,8 @@
+ALTER TABLE doctors
+    DROP KEY uk_doctors_slug,
+    DROP COLUMN consultation_fee,
+    DROP COLUMN certifications,
+    DROP COLUMN education,
+    DROP COLUMN languages,
+    DROP COLUMN photo_path,
+    DROP COLUMN slug;
@@ -0,0 +1,19 @@
+-- Doctors get a public slug, a profile photo and the details shown on their profile page
+ALTER TABLE doctors
+    ADD COLUMN slug VARCHAR(255) NULL DEFAULT NULL AFTER name,
+    ADD COLUMN photo_path VARCHAR(512) NULL DEFAULT NULL COMMENT 'Object path of the profile photo in the storage provider' AFTER slug,
+    ADD COLUMN languages JSON NULL AFTER experience,
+    ADD COLUMN education JSON NULL AFTER languages,
+    ADD COLUMN certifications JSON NULL AFTER education,
+    ADD COLUMN consultation_fee BIGINT NULL DEFAULT NULL COMMENT 'Fee in whole currency units, NULL when not published' AFTER certifications;
+
+UPDATE doctors SET slug = LOWER(REPLACE(REPLACE(REPLACE(name, ',', ''), '.', ''), ' ', '-'));
+
+-- Doctors sharing a name are told apart by the start of their ID
+UPDATE doctors d
+INNER JOIN (SELECT slug FROM doctors GROUP BY slug HAVING COUNT(*) > 1) duplicate ON duplicate.slug = d.slug
+SET d.slug = CONCAT(d.slug, '-', LEFT(d.id, 8));
+
+ALTER TABLE doctors
+    MODIFY COLUMN slug VARCHAR(255) NOT NULL,
+    ADD UNIQUE KEY uk_doctors_slug (slug);
@@ -0,0 +1,7 @@
+-- Profiles never completed cannot satisfy the required columns, their appointments keep NULL
+DELETE FROM patients
+WHERE date_of_birth IS NULL OR gender IS NULL;
+
+ALTER TABLE patients
+    MODIFY date_of_birth DATE NOT NULL,
+    MODIFY gender ENUM('male', 'female') NOT NULL;
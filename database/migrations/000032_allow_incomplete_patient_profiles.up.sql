@@ -0,0 +1,19 @@
+-- Profiles created from the user account on a first booking do not know the birth date or
+-- gender yet, the user completes them through the patient profile
+ALTER TABLE patients
+    MODIFY date_of_birth DATE NULL DEFAULT NULL,
+    MODIFY gender ENUM('male', 'female') NULL DEFAULT NULL;
+
+-- Give every user who booked before profiles existed a profile from their account
+INSERT INTO patients (id, user_id, name, phone)
+SELECT UUID(), u.id, u.name, u.phone
+FROM users u
+WHERE u.deleted_at IS NULL
+AND NOT EXISTS (SELECT 1 FROM patients p WHERE p.user_id = u.id)
+AND EXISTS (SELECT 1 FROM appointments a WHERE a.user_id = u.id);
+
+-- Their earlier appointments were booked for themselves
+UPDATE appointments a
+INNER JOIN patients p ON p.user_id = a.user_id
+SET a.patient_id = p.id
+WHERE a.patient_id IS NULL;
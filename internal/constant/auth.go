@@ -8,12 +8,51 @@ const (
 	AUTH_BASIC_PREFIX      = "Basic "
 	AUTH_TOKEN_TYPE_ACCESS = "access"
 	AUTH_TOKEN_TYPE_REFRESH = "refresh"
+	AUTH_TOKEN_TYPE_MFA = "mfa" // Pending login waiting for the second factor
 )
 
 // Token Durations
 const (
-	ACCESS_TOKEN_DURATION  = time.Hour * 24     // 1 day
+	ACCESS_TOKEN_DURATION  = time.Minute * 15    // 15 minutes, used when Token.TokenExpiration is not set
 	REFRESH_TOKEN_DURATION = time.Hour * 24 * 30 // 30 days
+
+	EMAIL_VERIFICATION_TOKEN_DURATION = time.Hour * 24   // 1 day
+	PASSWORD_RESET_TOKEN_DURATION     = time.Hour        // 1 hour
+	EMAIL_CHANGE_TOKEN_DURATION       = time.Hour * 24   // 1 day
+	INVITATION_TOKEN_DURATION         = time.Hour * 72   // 3 days
+	MFA_TOKEN_DURATION                = time.Minute * 5  // 5 minutes to enter the code
+	OIDC_STATE_DURATION               = time.Minute * 10 // 10 minutes to sign in at the provider
+
+	PASSWORD_RESET_SEND_TIMEOUT = time.Second * 30 // Time given to mail a reset link after the request was answered
+)
+
+// Two-Factor Authentication
+const (
+	MFA_TOTP_ISSUER         = "Apexa"
+	MFA_TOTP_SKEW           = 1 // Codes of the previous and next step are accepted for clock drift
+	MFA_RECOVERY_CODE_COUNT = 10
+	MFA_MAX_FAILURES        = 5 // Wrong codes within LOGIN_FAILURE_WINDOW before the account is locked
+)
+
+// Sessions
+const (
+	SESSION_TOUCH_INTERVAL   = time.Minute // Minimum time between two writes of a token's last activity
+	SESSION_USER_AGENT_LIMIT = 255         // Longer user agents are truncated to fit user_tokens.user_agent
+)
+
+// User Statuses
+const (
+	USER_STATUS_ACTIVE    = "active"
+	USER_STATUS_INACTIVE  = "inactive"
+	USER_STATUS_SUSPENDED = "suspended"
+)
+
+// Invitation Statuses
+const (
+	INVITATION_STATUS_PENDING  = "pending"
+	INVITATION_STATUS_ACCEPTED = "accepted"
+	INVITATION_STATUS_REVOKED  = "revoked"
+	INVITATION_STATUS_EXPIRED  = "expired"
 )
 
 // User Roles
@@ -23,3 +62,49 @@ const (
 	ROLE_PATIENT   = "patient"
 	ROLE_STAFF     = "staff"
 )
+
+// Permissions, granted to roles and used as token abilities
+const (
+	PERMISSION_ARTICLE_WRITE          = "article:write"
+	PERMISSION_DOCTOR_WRITE           = "doctor:write"
+	PERMISSION_DOCTOR_SCHEDULE_MANAGE = "doctor:schedule:manage"
+	PERMISSION_APPOINTMENT_READ_ANY   = "appointment:read:any"
+	PERMISSION_ROLE_MANAGE            = "role:manage"
+	PERMISSION_USER_MANAGE            = "user:manage"
+	PERMISSION_AUDIT_READ             = "audit:read"
+	PERMISSION_PATIENT_READ_ANY       = "patient:read:any"
+	PERMISSION_DOCTOR_PORTAL          = "doctor:portal"
+	PERMISSION_SERVICE_WRITE          = "service:write"
+)
+
+// Login Throttling
+const (
+	LOGIN_FAILURE_WINDOW       = time.Minute * 15 // Failed attempts are counted over this window
+	LOGIN_MAX_EMAIL_FAILURES   = 5                // Failures per email before the account is locked
+	LOGIN_MAX_IP_FAILURES      = 20               // Failures per IP before the address is blocked
+	LOGIN_DELAY_AFTER_FAILURES = 3                // Failures per email before logins are delayed
+	LOGIN_DELAY_BASE           = time.Second      // Doubled for every failure past LOGIN_DELAY_AFTER_FAILURES
+	LOGIN_DELAY_MAX            = time.Minute      // Upper bound of the progressive delay
+	LOGIN_LOCKOUT_DURATION     = time.Minute * 15 // How long a locked account stays locked
+)
+
+// Auth Audit Events
+const (
+	AUDIT_EVENT_ACCOUNT_LOCKED      = "account_locked"
+	AUDIT_EVENT_ACCOUNT_UNLOCKED    = "account_unlocked"
+	AUDIT_EVENT_IP_BLOCKED          = "ip_blocked"
+	AUDIT_EVENT_MFA_ENABLED         = "mfa_enabled"
+	AUDIT_EVENT_MFA_DISABLED        = "mfa_disabled"
+	AUDIT_EVENT_SESSION_REVOKED     = "session_revoked"
+	AUDIT_EVENT_SESSIONS_REVOKED    = "sessions_revoked"
+	AUDIT_EVENT_USER_SUSPENDED      = "user_suspended"
+	AUDIT_EVENT_USER_REACTIVATED    = "user_reactivated"
+	AUDIT_EVENT_USER_DELETED        = "user_deleted"
+	AUDIT_EVENT_USER_RESTORED       = "user_restored"
+	AUDIT_EVENT_PASSWORD_CHANGED    = "password_changed"
+	AUDIT_EVENT_EMAIL_CHANGED       = "email_changed"
+	AUDIT_EVENT_INVITATION_SENT     = "invitation_sent"
+	AUDIT_EVENT_INVITATION_REVOKED  = "invitation_revoked"
+	AUDIT_EVENT_INVITATION_ACCEPTED = "invitation_accepted"
+	AUDIT_EVENT_IDENTITY_LINKED     = "identity_linked"
+)
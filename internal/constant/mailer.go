@@ -0,0 +1,7 @@
+package constant
+
+const (
+	MAILER_SMTP    = "SMTP"
+	MAILER_SES     = "SES"
+	MAILER_DEFAULT = MAILER_SMTP
+)
@@ -10,24 +10,33 @@ import (
 	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
 	"github.com/gomajido/hospital-cms-golang/internal/module/auth/handler"
 	doctorHandler "github.com/gomajido/hospital-cms-golang/internal/module/doctor/handler"
+	patientHandler "github.com/gomajido/hospital-cms-golang/internal/module/patient/handler"
+	serviceHandler "github.com/gomajido/hospital-cms-golang/internal/module/service/handler"
+	"github.com/gomajido/hospital-cms-golang/pkg/db/redis"
 )
 
 type ApplicationHandler struct {
 	AuthHandler        domain.AuthHandler
 	AuthMiddleware     *middleware.AuthMiddleware
+	RateLimiter        *middleware.RateLimiter
 	ArticleHandler     *articleHandler.ArticleHandler
 	DoctorHandler      *doctorHandler.DoctorHandler
 	AppointmentHandler *appointmentHandler.AppointmentHandler
+	PatientHandler     *patientHandler.PatientHandler
+	ServiceHandler     *serviceHandler.ServiceHandler
 }
 
-func InitHandlers(ctx context.Context, cfg *config.Config, service *AppUsecase) *ApplicationHandler {
+func InitHandlers(ctx context.Context, cfg *config.Config, redis *redis.Redis, service *AppUsecase) *ApplicationHandler {
 	authMiddleware := middleware.NewAuthMiddleware(service.AuthUsecase)
 
 	return &ApplicationHandler{
 		AuthHandler:        handler.NewAuthHandler(service.AuthUsecase),
 		AuthMiddleware:     authMiddleware,
+		RateLimiter:        middleware.NewRateLimiter(redis),
 		ArticleHandler:     articleHandler.NewArticleHandler(service.ArticleUsecase),
 		DoctorHandler:      doctorHandler.NewDoctorHandler(service.DoctorUsecase),
 		AppointmentHandler: appointmentHandler.NewAppointmentHandler(service.AppointmentUsecase),
+		PatientHandler:     patientHandler.NewPatientHandler(service.PatientUsecase),
+		ServiceHandler:     serviceHandler.NewServiceHandler(service.ServiceUsecase),
 	}
 }
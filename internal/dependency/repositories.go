@@ -4,6 +4,7 @@ import (
 	"database/sql"
 
 	"github.com/gomajido/hospital-cms-golang/config"
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
 	appointmentDomain "github.com/gomajido/hospital-cms-golang/internal/module/appointment/domain"
 	appointmentRepo "github.com/gomajido/hospital-cms-golang/internal/module/appointment/repository"
 	articleDomain "github.com/gomajido/hospital-cms-golang/internal/module/article/domain"
@@ -12,28 +13,75 @@ import (
 	"github.com/gomajido/hospital-cms-golang/internal/module/auth/repository"
 	doctorDomain "github.com/gomajido/hospital-cms-golang/internal/module/doctor/domain"
 	doctorRepo "github.com/gomajido/hospital-cms-golang/internal/module/doctor/repository"
+	patientDomain "github.com/gomajido/hospital-cms-golang/internal/module/patient/domain"
+	patientRepo "github.com/gomajido/hospital-cms-golang/internal/module/patient/repository"
+	serviceDomain "github.com/gomajido/hospital-cms-golang/internal/module/service/domain"
+	serviceRepo "github.com/gomajido/hospital-cms-golang/internal/module/service/repository"
+	"github.com/gomajido/hospital-cms-golang/pkg/app_log"
 	"github.com/gomajido/hospital-cms-golang/pkg/db/redis"
+	"github.com/gomajido/hospital-cms-golang/pkg/mailer"
+	mailerSes "github.com/gomajido/hospital-cms-golang/pkg/mailer/ses"
+	mailerSmtp "github.com/gomajido/hospital-cms-golang/pkg/mailer/smtp"
+	"github.com/gomajido/hospital-cms-golang/pkg/oidc"
+	"github.com/gomajido/hospital-cms-golang/pkg/storage"
+	s3Storage "github.com/gomajido/hospital-cms-golang/pkg/storage/s3"
 )
 
 type CommonRepositories struct {
+	Mailer        mailer.IMailerProviderRepository
+	OIDCProviders map[string]oidc.IOIDCProviderRepository
+	Storage       storage.IStorageProviderRepository
 }
 
 type AppRepositories struct {
 	AuthRepo        domain.AuthRepository
+	LoginAttempts   domain.LoginAttemptRepository
+	OIDCStates      domain.OIDCStateRepository
 	ArticleRepo     articleDomain.ArticleRepository
 	DoctorRepo      doctorDomain.DoctorRepository
 	AppointmentRepo appointmentDomain.AppointmentRepository
+	PatientRepo     patientDomain.PatientRepository
+	ServiceRepo     serviceDomain.ServiceRepository
 }
 
 func InitCommonRepos(Adapters *Adapters, Drivers *Drivers, config *config.Config) *CommonRepositories {
-	return &CommonRepositories{}
+	return &CommonRepositories{
+		Mailer:        initMailer(Drivers, config),
+		OIDCProviders: initOIDCProviders(config),
+		Storage:       s3Storage.NewAwsS3(&config.S3, Drivers.S3),
+	}
+}
+
+func initOIDCProviders(cfg *config.Config) map[string]oidc.IOIDCProviderRepository {
+	providers := make(map[string]oidc.IOIDCProviderRepository, len(cfg.OIDC.Providers))
+	for name, providerCfg := range cfg.OIDC.Providers {
+		providerCfg := providerCfg
+		providers[name] = oidc.NewProvider(&providerCfg, nil)
+	}
+	return providers
+}
+
+func initMailer(drivers *Drivers, cfg *config.Config) mailer.IMailerProviderRepository {
+	switch cfg.Mailer.Provider {
+	case constant.MAILER_SES:
+		return mailerSes.NewSESMailer(&cfg.SES, drivers.SES)
+	case constant.MAILER_SMTP, constant.EMPTY_STRING:
+		return mailerSmtp.NewSMTPMailer(&cfg.SMTP, drivers.SMTPAuth)
+	default:
+		app_log.Fatalf("Unsupported mailer provider: %s", cfg.Mailer.Provider)
+		return nil
+	}
 }
 
 func InitRepos(db *sql.DB, redis *redis.Redis) *AppRepositories {
 	return &AppRepositories{
-		AuthRepo:        repository.NewAuthRepository(db),
+		AuthRepo:        repository.NewAuthCacheRepository(repository.NewAuthRepository(db), redis),
+		LoginAttempts:   repository.NewLoginAttemptRepository(redis),
+		OIDCStates:      repository.NewOIDCStateRepository(redis),
 		ArticleRepo:     articleRepo.NewArticleRepository(db),
 		DoctorRepo:      doctorRepo.NewDoctorRepository(db),
 		AppointmentRepo: appointmentRepo.NewAppointmentRepository(db),
+		PatientRepo:     patientRepo.NewPatientRepository(db),
+		ServiceRepo:     serviceRepo.NewServiceRepository(db),
 	}
 }
@@ -10,6 +10,10 @@ import (
 	"github.com/gomajido/hospital-cms-golang/internal/module/auth/usecase"
 	doctorDomain "github.com/gomajido/hospital-cms-golang/internal/module/doctor/domain"
 	doctorUsecase "github.com/gomajido/hospital-cms-golang/internal/module/doctor/usecase"
+	patientDomain "github.com/gomajido/hospital-cms-golang/internal/module/patient/domain"
+	patientUsecase "github.com/gomajido/hospital-cms-golang/internal/module/patient/usecase"
+	serviceDomain "github.com/gomajido/hospital-cms-golang/internal/module/service/domain"
+	serviceUsecase "github.com/gomajido/hospital-cms-golang/internal/module/service/usecase"
 )
 
 type AppUsecase struct {
@@ -17,13 +21,20 @@ type AppUsecase struct {
 	ArticleUsecase     articleDomain.ArticleUsecase
 	DoctorUsecase      doctorDomain.DoctorUsecase
 	AppointmentUsecase appointmentDomain.AppointmentUsecase
+	PatientUsecase     patientDomain.PatientUsecase
+	ServiceUsecase     serviceDomain.ServiceUsecase
 }
 
 func InitUsecase(config *config.Config, repo *AppRepositories, common *CommonRepositories) *AppUsecase {
+	doctorUC := doctorUsecase.NewDoctorUsecase(repo.DoctorRepo, common.Storage)
+	patientUC := patientUsecase.NewPatientUsecase(repo.PatientRepo)
+
 	return &AppUsecase{
-		AuthUsecase:        usecase.NewAuthUsecase(repo.AuthRepo, config),
+		AuthUsecase:        usecase.NewAuthUsecase(repo.AuthRepo, repo.LoginAttempts, repo.OIDCStates, common.Mailer, common.OIDCProviders, config),
 		ArticleUsecase:     articleUsecase.NewArticleUsecase(repo.ArticleRepo),
-		DoctorUsecase:      doctorUsecase.NewDoctorUsecase(repo.DoctorRepo),
-		AppointmentUsecase: appointmentUsecase.NewAppointmentUsecase(repo.AppointmentRepo),
+		DoctorUsecase:      doctorUC,
+		AppointmentUsecase: appointmentUsecase.NewAppointmentUsecase(repo.AppointmentRepo, doctorUC, patientUC),
+		PatientUsecase:     patientUC,
+		ServiceUsecase:     serviceUsecase.NewServiceUsecase(repo.ServiceRepo),
 	}
 }
@@ -0,0 +1,75 @@
+package identity
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// localsKey is the fiber locals key holding the identity of the authenticated request
+const localsKey = "identity"
+
+// ErrMissingIdentity is returned when a handler runs without the auth middleware populating the identity
+var ErrMissingIdentity = errors.New("missing request identity")
+
+// Identity represents the authenticated user of the current request
+type Identity struct {
+	UserID    uuid.UUID `json:"user_id"`
+	TokenID   uuid.UUID `json:"token_id"`
+	Roles     []string  `json:"roles"`
+	Abilities []string  `json:"abilities"`
+}
+
+// Set stores the identity in the request locals
+func Set(c *fiber.Ctx, identity *Identity) {
+	c.Locals(localsKey, identity)
+}
+
+// FromContext returns the identity stored in the request locals
+func FromContext(c *fiber.Ctx) (*Identity, error) {
+	identity, ok := c.Locals(localsKey).(*Identity)
+	if !ok || identity == nil {
+		return nil, ErrMissingIdentity
+	}
+	return identity, nil
+}
+
+// HasRole checks if the identity has the given role
+func (i *Identity) HasRole(role string) bool {
+	return contains(i.Roles, role)
+}
+
+// HasAbility checks if the identity has the given ability
+func (i *Identity) HasAbility(ability string) bool {
+	return contains(i.Abilities, ability)
+}
+
+// HasAnyAbility checks if the identity has at least one of the given abilities
+func (i *Identity) HasAnyAbility(abilities ...string) bool {
+	for _, ability := range abilities {
+		if i.HasAbility(ability) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAllAbilities checks if the identity has every one of the given abilities
+func (i *Identity) HasAllAbilities(abilities ...string) bool {
+	for _, ability := range abilities {
+		if !i.HasAbility(ability) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package identity
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestFromContext(t *testing.T) {
+	want := &Identity{
+		UserID:    uuid.New(),
+		TokenID:   uuid.New(),
+		Roles:     []string{"member"},
+		Abilities: []string{"member"},
+	}
+
+	tests := []struct {
+		name    string
+		set     bool
+		wantErr error
+	}{
+		{name: "Identity set by middleware", set: true},
+		{name: "Missing identity", set: false, wantErr: ErrMissingIdentity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				if tt.set {
+					Set(c, want)
+				}
+
+				got, err := FromContext(c)
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("FromContext() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if tt.wantErr == nil && got != want {
+					t.Errorf("FromContext() = %v, want %v", got, want)
+				}
+				return nil
+			})
+
+			if _, err := app.Test(httptest.NewRequest("GET", "/", nil)); err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestIdentity_Abilities(t *testing.T) {
+	id := &Identity{Roles: []string{"admin"}, Abilities: []string{"article:write", "doctor:read"}}
+
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{name: "HasRole", got: id.HasRole("admin"), want: true},
+		{name: "HasRole missing", got: id.HasRole("doctor"), want: false},
+		{name: "HasAbility", got: id.HasAbility("article:write"), want: true},
+		{name: "HasAbility missing", got: id.HasAbility("article:delete"), want: false},
+		{name: "HasAnyAbility", got: id.HasAnyAbility("article:delete", "doctor:read"), want: true},
+		{name: "HasAnyAbility none", got: id.HasAnyAbility("article:delete"), want: false},
+		{name: "HasAllAbilities", got: id.HasAllAbilities("article:write", "doctor:read"), want: true},
+		{name: "HasAllAbilities partial", got: id.HasAllAbilities("article:write", "article:delete"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Package secretbox encrypts small secrets, such as TOTP seeds, before they are
+// stored. It uses AES-256-GCM with a key derived from a configured passphrase.
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+)
+
+var (
+	// ErrMissingKey is returned when no encryption key is configured
+	ErrMissingKey = errors.New("missing encryption key")
+	// ErrInvalidCiphertext is returned when a value was not sealed with the key
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+)
+
+// Seal encrypts plaintext and returns it base64 encoded with its nonce
+func Seal(plaintext, key string) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a value produced by Seal
+func Open(ciphertext, key string) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+
+	nonce, sealed := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+
+	return string(plaintext), nil
+}
+
+func newAEAD(key string) (cipher.AEAD, error) {
+	if key == "" {
+		return nil, ErrMissingKey
+	}
+
+	derived := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(derived[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,36 @@
+package secretbox
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSealOpen(t *testing.T) {
+	sealed, err := Seal("JBSWY3DPEHPK3PXP", "key")
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+
+	if got, err := Open(sealed, "key"); err != nil || got != "JBSWY3DPEHPK3PXP" {
+		t.Errorf("Open() = %q, %v, want the sealed secret", got, err)
+	}
+
+	tests := []struct {
+		name       string
+		ciphertext string
+		key        string
+		wantErr    error
+	}{
+		{name: "Wrong key", ciphertext: sealed, key: "other", wantErr: ErrInvalidCiphertext},
+		{name: "Not base64", ciphertext: "%%%", key: "key", wantErr: ErrInvalidCiphertext},
+		{name: "Tampered", ciphertext: sealed[:len(sealed)-4] + "AAAA", key: "key", wantErr: ErrInvalidCiphertext},
+		{name: "Missing key", ciphertext: sealed, key: "", wantErr: ErrMissingKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Open(tt.ciphertext, tt.key); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Open() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
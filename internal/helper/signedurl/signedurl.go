@@ -0,0 +1,79 @@
+package signedurl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	PARAM_EXPIRES   = "expires"
+	PARAM_SIGNATURE = "signature"
+)
+
+var (
+	ErrMissingSecret    = errors.New("signing secret is not configured")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrExpired          = errors.New("link expired")
+)
+
+// Sign appends an expiry and an HMAC-SHA256 signature of the query to baseURL
+func Sign(baseURL string, params url.Values, expiresAt time.Time, secret string) (string, error) {
+	if secret == "" {
+		return "", ErrMissingSecret
+	}
+
+	signed := url.Values{}
+	for key, values := range params {
+		signed[key] = append([]string(nil), values...)
+	}
+	signed.Set(PARAM_EXPIRES, strconv.FormatInt(expiresAt.Unix(), 10))
+	signed.Set(PARAM_SIGNATURE, signature(signed, secret))
+
+	return baseURL + "?" + signed.Encode(), nil
+}
+
+// Verify checks the signature and expiry of the query of a signed URL
+func Verify(params url.Values, secret string, now time.Time) error {
+	if secret == "" {
+		return ErrMissingSecret
+	}
+
+	expected, err := hex.DecodeString(params.Get(PARAM_SIGNATURE))
+	if err != nil || len(expected) == 0 {
+		return ErrInvalidSignature
+	}
+
+	actual, _ := hex.DecodeString(signature(params, secret))
+	if !hmac.Equal(expected, actual) {
+		return ErrInvalidSignature
+	}
+
+	expires, err := strconv.ParseInt(params.Get(PARAM_EXPIRES), 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if now.After(time.Unix(expires, 0)) {
+		return ErrExpired
+	}
+
+	return nil
+}
+
+// signature signs every parameter except the signature itself, in sorted order
+func signature(params url.Values, secret string) string {
+	unsigned := url.Values{}
+	for key, values := range params {
+		if key != PARAM_SIGNATURE {
+			unsigned[key] = values
+		}
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned.Encode()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
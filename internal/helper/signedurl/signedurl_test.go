@@ -0,0 +1,50 @@
+package signedurl
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	link, err := Sign("https://example.com/verify", url.Values{"id": {"42"}, "token": {"abc"}}, now.Add(time.Hour), "secret")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("url.Parse() error = %v", err)
+	}
+
+	tampered := func(key, value string) url.Values {
+		params := parsed.Query()
+		params.Set(key, value)
+		return params
+	}
+
+	tests := []struct {
+		name    string
+		params  url.Values
+		secret  string
+		now     time.Time
+		wantErr error
+	}{
+		{name: "Valid link", params: parsed.Query(), secret: "secret", now: now},
+		{name: "Expired link", params: parsed.Query(), secret: "secret", now: now.Add(2 * time.Hour), wantErr: ErrExpired},
+		{name: "Tampered parameter", params: tampered("id", "43"), secret: "secret", now: now, wantErr: ErrInvalidSignature},
+		{name: "Extended expiry", params: tampered(PARAM_EXPIRES, "9999999999"), secret: "secret", now: now, wantErr: ErrInvalidSignature},
+		{name: "Missing signature", params: tampered(PARAM_SIGNATURE, ""), secret: "secret", now: now, wantErr: ErrInvalidSignature},
+		{name: "Other secret", params: parsed.Query(), secret: "other", now: now, wantErr: ErrInvalidSignature},
+		{name: "Missing secret", params: parsed.Query(), secret: "", now: now, wantErr: ErrMissingSecret},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Verify(tt.params, tt.secret, tt.now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
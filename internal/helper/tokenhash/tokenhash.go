@@ -0,0 +1,20 @@
+package tokenhash
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+)
+
+// Sum returns the hex encoded SHA-256 digest of a raw token, which is the only
+// form a token is ever stored in.
+func Sum(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// Equal reports whether a raw token matches a stored digest. The comparison
+// runs in constant time so the digest cannot be guessed byte by byte.
+func Equal(raw, hashed string) bool {
+	return subtle.ConstantTimeCompare([]byte(Sum(raw)), []byte(hashed)) == 1
+}
@@ -0,0 +1,33 @@
+package tokenhash
+
+import "testing"
+
+func TestSum(t *testing.T) {
+	// echo -n "secret" | sha256sum
+	want := "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b"
+	if got := Sum("secret"); got != want {
+		t.Errorf("Sum() = %v, want %v", got, want)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	hashed := Sum("secret")
+
+	tests := []struct {
+		name string
+		raw  string
+		want bool
+	}{
+		{name: "Matching token", raw: "secret", want: true},
+		{name: "Different token", raw: "secreT", want: false},
+		{name: "Stored digest presented as token", raw: hashed, want: false},
+		{name: "Empty token", raw: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Equal(tt.raw, hashed); got != tt.want {
+				t.Errorf("Equal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// RFC 6238 appendix B, SHA-1 with the ASCII key "12345678901234567890", truncated to 6 digits
+func TestCode(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+	}
+	for _, tt := range tests {
+		got, err := Code(secret, Step(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("Code() unexpected error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("Code(%d) = %v, want %v", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+	now := time.Now()
+	previous, _ := Code(secret, Step(now)-1)
+	stale, _ := Code(secret, Step(now)-3)
+
+	tests := []struct {
+		name     string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "Previous step within the skew", code: previous, wantStep: Step(now) - 1, wantOK: true},
+		{name: "Code outside the skew", code: stale},
+		{name: "Wrong length", code: "12345"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := Validate(secret, tt.code, now, 1)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("Validate() = %d, %v, want %d, %v", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri, err := url.Parse(URI("Apexa", "doctor@example.com", "JBSWY3DPEHPK3PXP"))
+	if err != nil {
+		t.Fatalf("URI() is not a valid URL: %v", err)
+	}
+
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/Apexa:doctor@example.com" {
+		t.Errorf("URI() = %v, want otpauth://totp/Apexa:doctor@example.com", uri)
+	}
+	if query := uri.Query(); query.Get("secret") != "JBSWY3DPEHPK3PXP" || query.Get("issuer") != "Apexa" {
+		t.Errorf("URI() query = %v", query)
+	}
+}
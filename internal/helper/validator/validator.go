@@ -10,6 +10,11 @@ import (
 
 var Validate = validator.New()
 
+func init() {
+	// Lets modules check a date of birth with Validate.Var(date, RULE_OLDER_THAN)
+	Validate.RegisterValidation(RULE_OLDER_THAN, OlderThan)
+}
+
 const (
 	RULE_OLDER_THAN  = "older_than"
 	RULE_REQUIRED_IF = "required_if"
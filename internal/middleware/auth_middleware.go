@@ -5,9 +5,9 @@ import (
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/identity"
 	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
 	"github.com/gomajido/hospital-cms-golang/internal/response"
-	"github.com/google/uuid"
 )
 
 type AuthMiddleware struct {
@@ -23,79 +23,110 @@ func NewAuthMiddleware(usecase domain.AuthUsecase) *AuthMiddleware {
 // Protected validates the token and allows access if valid
 func (m *AuthMiddleware) Protected() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// Get token from header
-		header := c.Get("Authorization")
-		if header == "" {
-			err := errors.New("missing authorization header")
-			return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+		// Already authenticated by Identify
+		if _, err := identity.FromContext(c); err == nil {
+			return c.Next()
 		}
 
-		// Check bearer scheme
-		parts := strings.Split(header, " ")
-		if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
-			err := errors.New("invalid authorization format")
-			return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+		id, status, err := m.authenticate(c)
+		if err != nil {
+			if status == fiber.StatusInternalServerError {
+				return c.Status(status).JSON(response.ErrInternalServer.WithError(err))
+			}
+			return c.Status(status).JSON(response.ErrUnprocessableEntity.WithError(err))
 		}
 
-		// Split ID and token
-		credentials := strings.Split(parts[1], "|")
-		if len(credentials) != 2 {
-			err := errors.New("invalid token format")
-			return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
-		}
+		// Set request identity in context
+		identity.Set(c, id)
 
-		tokenIDStr := credentials[0]
-		token := credentials[1]
+		return c.Next()
+	}
+}
 
-		if tokenIDStr == "" || token == "" {
-			err := errors.New("missing token ID or token")
-			return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+// Identify sets the request identity when the request carries a valid token and lets every
+// request through, so middleware running before Protected (such as the global rate limit) can
+// tell users apart. Missing or invalid tokens are left for Protected to refuse.
+func (m *AuthMiddleware) Identify() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Get("Authorization") == "" {
+			return c.Next()
 		}
 
-		// Parse token ID to UUID
-		tokenID, err := uuid.Parse(tokenIDStr)
-		if err != nil {
-			return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(errors.New("invalid token ID format")))
+		if id, _, err := m.authenticate(c); err == nil {
+			identity.Set(c, id)
 		}
 
-		// Validate token
-		err = m.usecase.ValidateUserToken(c.Context(), tokenIDStr, token)
-		if err != nil {
-			return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
-		}
+		return c.Next()
+	}
+}
 
-		// Get token from database
-		userToken, err := m.usecase.GetUserTokenByID(c.Context(), tokenID)
-		if err != nil {
-			return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
-		}
+// authenticate resolves the identity of the bearer token, the status tells how to answer a failure
+func (m *AuthMiddleware) authenticate(c *fiber.Ctx) (*identity.Identity, int, error) {
+	// Get token from header
+	header := c.Get("Authorization")
+	if header == "" {
+		return nil, fiber.StatusUnprocessableEntity, errors.New("missing authorization header")
+	}
 
-		// Set user token in context
-		c.Locals("user_token", userToken)
+	// Check bearer scheme
+	parts := strings.Split(header, " ")
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return nil, fiber.StatusUnprocessableEntity, errors.New("invalid authorization format")
+	}
 
-		return c.Next()
+	// Split ID and token
+	credentials := strings.Split(parts[1], "|")
+	if len(credentials) != 2 {
+		return nil, fiber.StatusUnprocessableEntity, errors.New("invalid token format")
 	}
+
+	tokenIDStr := credentials[0]
+	token := credentials[1]
+
+	if tokenIDStr == "" || token == "" {
+		return nil, fiber.StatusUnprocessableEntity, errors.New("missing token ID or token")
+	}
+
+	// Validate token
+	userToken, err := m.usecase.ValidateUserToken(c.Context(), tokenIDStr, token)
+	if err != nil {
+		return nil, fiber.StatusUnprocessableEntity, err
+	}
+
+	// Record the session activity
+	m.usecase.TouchUserToken(c.Context(), userToken, domain.ClientInfo{
+		IPAddress: c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+	})
+
+	// Get user roles
+	roles, err := m.usecase.GetUserRoles(c.Context(), userToken.UserID)
+	if err != nil {
+		return nil, fiber.StatusInternalServerError, err
+	}
+
+	roleNames := make([]string, 0, len(roles))
+	for _, role := range roles {
+		roleNames = append(roleNames, role.Name)
+	}
+
+	return &identity.Identity{
+		UserID:    userToken.UserID,
+		TokenID:   userToken.ID,
+		Roles:     roleNames,
+		Abilities: userToken.Ability,
+	}, 0, nil
 }
 
 // HasAbility checks if the user has the required ability
 func (m *AuthMiddleware) HasAbility(ability string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		userToken, ok := c.Locals("user_token").(*domain.UserToken)
-		if !ok {
-			err := errors.New("missing user token")
+		id, err := identity.FromContext(c)
+		if err != nil {
 			return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
 		}
 
-		// Check if user has the required ability
-		hasAbility := false
-		for _, a := range userToken.Ability {
-			if a == ability {
-				hasAbility = true
-				break
-			}
-		}
-
-		if !hasAbility {
+		if !id.HasAbility(ability) {
 			err := errors.New("insufficient permissions")
 			return c.Status(fiber.StatusForbidden).JSON(response.ErrForbidden.WithError(err))
 		}
@@ -107,27 +138,12 @@ func (m *AuthMiddleware) HasAbility(ability string) fiber.Handler {
 // HasAnyAbility checks if the user has any of the required abilities
 func (m *AuthMiddleware) HasAnyAbility(abilities ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		userToken, ok := c.Locals("user_token").(*domain.UserToken)
-		if !ok {
-			err := errors.New("missing user token")
+		id, err := identity.FromContext(c)
+		if err != nil {
 			return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
 		}
 
-		// Check if user has any of the required abilities
-		hasAbility := false
-		for _, required := range abilities {
-			for _, userAbility := range userToken.Ability {
-				if required == userAbility {
-					hasAbility = true
-					break
-				}
-			}
-			if hasAbility {
-				break
-			}
-		}
-
-		if !hasAbility {
+		if !id.HasAnyAbility(abilities...) {
 			err := errors.New("insufficient permissions")
 			return c.Status(fiber.StatusForbidden).JSON(response.ErrForbidden.WithError(err))
 		}
@@ -139,25 +155,14 @@ func (m *AuthMiddleware) HasAnyAbility(abilities ...string) fiber.Handler {
 // HasAllAbilities checks if the user has all of the required abilities
 func (m *AuthMiddleware) HasAllAbilities(abilities ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		userToken, ok := c.Locals("user_token").(*domain.UserToken)
-		if !ok {
-			err := errors.New("missing user token")
+		id, err := identity.FromContext(c)
+		if err != nil {
 			return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
 		}
 
-		// Check if user has all required abilities
-		for _, required := range abilities {
-			hasAbility := false
-			for _, userAbility := range userToken.Ability {
-				if required == userAbility {
-					hasAbility = true
-					break
-				}
-			}
-			if !hasAbility {
-				err := errors.New("insufficient permissions")
-				return c.Status(fiber.StatusForbidden).JSON(response.ErrForbidden.WithError(err))
-			}
+		if !id.HasAllAbilities(abilities...) {
+			err := errors.New("insufficient permissions")
+			return c.Status(fiber.StatusForbidden).JSON(response.ErrForbidden.WithError(err))
 		}
 
 		return c.Next()
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/identity"
+	"github.com/gomajido/hospital-cms-golang/internal/response"
+	"github.com/gomajido/hospital-cms-golang/pkg/app_log"
+	"github.com/gomajido/hospital-cms-golang/pkg/db/redis"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	rateLimitKey = "rate_limit:%s:%s"
+
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+)
+
+// slidingWindowScript keeps one sorted set entry per request scored by its time in
+// milliseconds. Entries older than the window are dropped before counting, and a
+// refused request reports how long until the oldest entry leaves the window.
+var slidingWindowScript = goredis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+local count = redis.call('ZCARD', key)
+if count < limit then
+	redis.call('ZADD', key, now, ARGV[4])
+	redis.call('PEXPIRE', key, window)
+	return {1, limit - count - 1, 0}
+end
+
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+return {0, 0, tonumber(oldest[2]) + window - now}
+`)
+
+// RateLimitPolicy describes how many requests a client may make within a sliding window.
+// Requests are counted per authenticated user when the route is protected, per IP otherwise.
+type RateLimitPolicy struct {
+	Name   string // Routes sharing a name share their counters
+	Limit  int
+	Window time.Duration
+}
+
+type RateLimiter struct {
+	redis *redis.Redis
+	now   func() time.Time
+}
+
+func NewRateLimiter(redis *redis.Redis) *RateLimiter {
+	return &RateLimiter{
+		redis: redis,
+		now:   time.Now,
+	}
+}
+
+// Limit refuses requests over the policy with 429 and a Retry-After header.
+// Requests are let through when Redis is unavailable.
+func (m *RateLimiter) Limit(policy RateLimitPolicy) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := fmt.Sprintf(rateLimitKey, policy.Name, rateLimitSubject(c))
+
+		allowed, remaining, retryAfter, err := m.take(c.Context(), key, policy)
+		if err != nil {
+			app_log.Errorf("Failed to apply %s rate limit: %v", policy.Name, err)
+			return c.Next()
+		}
+
+		c.Set(HeaderRateLimitLimit, strconv.Itoa(policy.Limit))
+		c.Set(HeaderRateLimitRemaining, strconv.FormatInt(remaining, 10))
+
+		if !allowed {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			err := errors.New("too many requests")
+			return c.Status(fiber.StatusTooManyRequests).JSON(response.StatusTooManyRequests.WithError(err))
+		}
+
+		return c.Next()
+	}
+}
+
+// take records a request against the key if the policy allows it
+func (m *RateLimiter) take(ctx context.Context, key string, policy RateLimitPolicy) (bool, int64, time.Duration, error) {
+	now := m.now().UnixMilli()
+
+	result, err := slidingWindowScript.Run(ctx, m.redis.Client, []string{key},
+		now, policy.Window.Milliseconds(), policy.Limit, strconv.FormatInt(now, 10)+"-"+uuid.NewString(),
+	).Int64Slice()
+	if err != nil {
+		return false, 0, 0, err
+	}
+	if len(result) != 3 {
+		return false, 0, 0, fmt.Errorf("unexpected rate limit result %v", result)
+	}
+
+	// Round up so clients never retry before the window has moved
+	retryAfter := time.Duration(result[2]) * time.Millisecond
+	if result[0] == 0 && retryAfter < time.Second {
+		retryAfter = time.Second
+	}
+
+	return result[0] == 1, result[1], retryAfter, nil
+}
+
+// rateLimitSubject identifies the client a request is counted against
+func rateLimitSubject(c *fiber.Ctx) string {
+	if id, err := identity.FromContext(c); err == nil {
+		return "user:" + id.UserID.String()
+	}
+	return "ip:" + c.IP()
+}
@@ -0,0 +1,202 @@
+package middleware
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/gomajido/hospital-cms-golang/internal/helper/identity"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain/mocks"
+	"github.com/gomajido/hospital-cms-golang/pkg/db/redis"
+)
+
+func newTestRateLimiter(t *testing.T, now *time.Time) (*miniredis.Miniredis, *RateLimiter) {
+	server := miniredis.RunT(t)
+	limiter := NewRateLimiter(&redis.Redis{Client: goredis.NewClient(&goredis.Options{Addr: server.Addr()})})
+	limiter.now = func() time.Time { return *now }
+
+	return server, limiter
+}
+
+func TestRateLimiter_Limit(t *testing.T) {
+	now := time.Now()
+	_, limiter := newTestRateLimiter(t, &now)
+
+	app := fiber.New()
+	app.Get("/", limiter.Limit(RateLimitPolicy{Name: "test", Limit: 2, Window: time.Minute}), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	steps := []struct {
+		advance       time.Duration
+		wantStatus    int
+		wantRemaining string
+		wantRetry     string
+	}{
+		{wantStatus: fiber.StatusOK, wantRemaining: "1"},
+		{advance: 20 * time.Second, wantStatus: fiber.StatusOK, wantRemaining: "0"},
+		{advance: 10 * time.Second, wantStatus: fiber.StatusTooManyRequests, wantRemaining: "0", wantRetry: "30"},
+		// The first request has left the window, the second one is still in it
+		{advance: 31 * time.Second, wantStatus: fiber.StatusOK, wantRemaining: "0"},
+		{advance: 19 * time.Second, wantStatus: fiber.StatusOK, wantRemaining: "0"},
+	}
+	for i, step := range steps {
+		now = now.Add(step.advance)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+		if err != nil {
+			t.Fatalf("request %d: app.Test() error = %v", i, err)
+		}
+		if resp.StatusCode != step.wantStatus {
+			t.Errorf("request %d: status = %d, want %d", i, resp.StatusCode, step.wantStatus)
+		}
+		if got := resp.Header.Get(HeaderRateLimitRemaining); got != step.wantRemaining {
+			t.Errorf("request %d: %s = %q, want %q", i, HeaderRateLimitRemaining, got, step.wantRemaining)
+		}
+		if got := resp.Header.Get(fiber.HeaderRetryAfter); got != step.wantRetry {
+			t.Errorf("request %d: Retry-After = %q, want %q", i, got, step.wantRetry)
+		}
+	}
+}
+
+func TestRateLimiter_Subjects(t *testing.T) {
+	now := time.Now()
+	server, limiter := newTestRateLimiter(t, &now)
+	userID := uuid.New()
+
+	app := fiber.New()
+	policy := limiter.Limit(RateLimitPolicy{Name: "test", Limit: 1, Window: time.Minute})
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }
+	app.Get("/public", policy, ok)
+	app.Get("/private", func(c *fiber.Ctx) error {
+		identity.Set(c, &identity.Identity{UserID: userID})
+		return c.Next()
+	}, policy, ok)
+
+	for _, path := range []string{"/public", "/private"} {
+		resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+		if err != nil {
+			t.Fatalf("app.Test(%s) error = %v", path, err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("GET %s status = %d, want %d", path, resp.StatusCode, fiber.StatusOK)
+		}
+	}
+
+	// Anonymous requests are counted per IP, authenticated ones per user
+	for _, key := range []string{"rate_limit:test:ip:0.0.0.0", "rate_limit:test:user:" + userID.String()} {
+		if !server.Exists(key) {
+			t.Errorf("key %s not found, have %v", key, server.Keys())
+		}
+	}
+}
+
+func TestRateLimiter_FailsOpen(t *testing.T) {
+	now := time.Now()
+	server, limiter := newTestRateLimiter(t, &now)
+	server.Close()
+
+	app := fiber.New()
+	app.Get("/", limiter.Limit(RateLimitPolicy{Name: "test", Limit: 1, Window: time.Minute}), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	for i := 0; i < 2; i++ {
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+		if err != nil {
+			t.Fatalf("app.Test() error = %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("status = %d without Redis, want %d", resp.StatusCode, fiber.StatusOK)
+		}
+	}
+}
+
+func TestRateLimiter_IdentifiedBeforeProtected(t *testing.T) {
+	now := time.Now()
+	server, limiter := newTestRateLimiter(t, &now)
+
+	ctrl := gomock.NewController(t)
+	usecase := mocks.NewMockAuthUsecase(ctrl)
+	auth := NewAuthMiddleware(usecase)
+
+	tokens := map[string]*domain.UserToken{}
+	for _, secret := range []string{"first", "second"} {
+		userToken := &domain.UserToken{ID: uuid.New(), UserID: uuid.New()}
+		tokens[userToken.ID.String()+"|"+secret] = userToken
+		usecase.EXPECT().ValidateUserToken(gomock.Any(), userToken.ID.String(), secret).Return(userToken, nil)
+		usecase.EXPECT().TouchUserToken(gomock.Any(), userToken, gomock.Any())
+		usecase.EXPECT().GetUserRoles(gomock.Any(), userToken.UserID).Return(nil, nil)
+	}
+
+	// The global policy runs before the routes are protected, as in the API router
+	app := fiber.New()
+	api := app.Group("/api", auth.Identify(), limiter.Limit(RateLimitPolicy{Name: "api", Limit: 1, Window: time.Minute}))
+	api.Get("/private", auth.Protected(), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	// Users behind the same address do not share their limit
+	for credentials, userToken := range tokens {
+		req := httptest.NewRequest("GET", "/api/private", nil)
+		req.Header.Set("Authorization", "Bearer "+credentials)
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("app.Test() error = %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("GET /api/private status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+		}
+		if key := "rate_limit:api:user:" + userToken.UserID.String(); !server.Exists(key) {
+			t.Errorf("key %s not found, have %v", key, server.Keys())
+		}
+	}
+}
+
+func TestRateLimiter_LimitedBeforeIdentify(t *testing.T) {
+	now := time.Now()
+	server, limiter := newTestRateLimiter(t, &now)
+
+	ctrl := gomock.NewController(t)
+	usecase := mocks.NewMockAuthUsecase(ctrl)
+	auth := NewAuthMiddleware(usecase)
+
+	// Only the first made up token reaches the token lookup
+	usecase.EXPECT().ValidateUserToken(gomock.Any(), gomock.Any(), "guess").Return(nil, errors.New("invalid token")).Times(1)
+
+	app := fiber.New()
+	api := app.Group("/api",
+		limiter.Limit(RateLimitPolicy{Name: "api_ip", Limit: 1, Window: time.Minute}),
+		auth.Identify(),
+	)
+	api.Get("/public", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	want := []int{fiber.StatusOK, fiber.StatusTooManyRequests}
+	for i, status := range want {
+		req := httptest.NewRequest("GET", "/api/public", nil)
+		req.Header.Set("Authorization", "Bearer "+uuid.NewString()+"|guess")
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("app.Test() error = %v", err)
+		}
+		if resp.StatusCode != status {
+			t.Errorf("request %d status = %d, want %d", i+1, resp.StatusCode, status)
+		}
+	}
+
+	if key := "rate_limit:api_ip:ip:0.0.0.0"; !server.Exists(key) {
+		t.Errorf("key %s not found, have %v", key, server.Keys())
+	}
+}
@@ -6,9 +6,18 @@ const (
 	AppointmentStatusScheduled = "scheduled"
 	AppointmentStatusCompleted = "completed"
 	AppointmentStatusCancelled = "cancelled"
+	AppointmentStatusNoShow    = "no_show"
 	MaxRescheduleCount        = 3
 )
 
+const (
+	DateFormat = "2006-01-02"
+	TimeFormat = "15:04"
+
+	// MaxAgendaRangeDays limits how many days a doctor can list in a single agenda query
+	MaxAgendaRangeDays = 31
+)
+
 // Common errors for appointment module
 var (
 	ErrTimeSlotNotAvailable   = errors.New("time slot is not available")
@@ -16,4 +25,9 @@ var (
 	ErrAppointmentNotFound    = errors.New("appointment not found")
 	ErrInvalidAppointmentDate = errors.New("invalid appointment date")
 	ErrInvalidAppointmentTime = errors.New("invalid appointment time")
+	ErrPatientProfileRequired = errors.New("a patient profile is required to book an appointment")
+	ErrPatientNotAuthorized   = errors.New("patient is not linked to your account")
+	ErrNotAppointmentManager  = errors.New("only the patient or their guardian account can change this appointment")
+	ErrAppointmentNotStarted  = errors.New("appointment has not started yet")
+	ErrInvalidStatusChange    = errors.New("only scheduled appointments can be marked completed or no-show")
 )
@@ -14,6 +14,14 @@ type User struct {
 	Email string    `json:"email"`
 }
 
+// Patient represents minimal patient information needed for appointments
+type Patient struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	DateOfBirth *time.Time `json:"date_of_birth"`
+	Gender      string    `json:"gender"`
+}
+
 // Doctor represents minimal doctor information needed for appointments
 type Doctor struct {
 	ID             uuid.UUID `json:"id"`
@@ -35,6 +43,7 @@ type DoctorSchedule struct {
 type Appointment struct {
 	ID              uuid.UUID       `json:"id"`
 	UserID          uuid.UUID       `json:"user_id"`
+	PatientID       *uuid.UUID      `json:"patient_id,omitempty"` // Empty for appointments booked before patient profiles
 	DoctorID        uuid.UUID       `json:"doctor_id"`
 	ScheduleID      uuid.UUID       `json:"doctor_schedule_id"`
 	AppointmentDate time.Time       `json:"appointment_date"`
@@ -46,6 +55,7 @@ type Appointment struct {
 	CreatedAt       time.Time       `json:"created_at"`
 	UpdatedAt       time.Time       `json:"updated_at"`
 	User            *User           `json:"user,omitempty"`
+	Patient         *Patient        `json:"patient,omitempty"`
 	Doctor          *Doctor         `json:"doctor,omitempty"`
 	Schedule        *DoctorSchedule `json:"schedule,omitempty"`
 }
@@ -56,6 +66,7 @@ type AppointmentRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*Appointment, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID, page, limit int) ([]Appointment, int64, error)
 	GetByDoctorID(ctx context.Context, doctorID uuid.UUID, page, limit int) ([]Appointment, int64, error)
+	GetDoctorAgenda(ctx context.Context, doctorID uuid.UUID, from, to time.Time) ([]Appointment, error)
 	Update(ctx context.Context, appointment *Appointment) error
 	Cancel(ctx context.Context, id uuid.UUID, req *CancelAppointmentRequest) (*Appointment, error)
 	Reschedule(ctx context.Context, id uuid.UUID, date time.Time, timeSlot string) error
@@ -66,9 +77,14 @@ type AppointmentRepository interface {
 type AppointmentUsecase interface {
 	Create(ctx context.Context, req CreateAppointmentRequest) (*Appointment, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*Appointment, error)
+	Authorize(ctx context.Context, appointment *Appointment, userID uuid.UUID) error
 	GetByUserID(ctx context.Context, userID uuid.UUID, page, limit int) ([]Appointment, int64, error)
 	GetByDoctorID(ctx context.Context, doctorID uuid.UUID, page, limit int) ([]Appointment, int64, error)
 	Cancel(ctx context.Context, id uuid.UUID, req CancelAppointmentRequest) (*Appointment, error)
 	Reschedule(ctx context.Context, id uuid.UUID, req RescheduleAppointmentRequest) (*Appointment, error)
 	CheckAvailability(ctx context.Context, req CheckAvailabilityRequest) (bool, error)
+
+	// Doctor portal operations, the doctor is the one linked to the given user
+	GetDoctorAgenda(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]Appointment, error)
+	UpdateStatusByDoctor(ctx context.Context, id uuid.UUID, req UpdateAppointmentStatusRequest) (*Appointment, error)
 }
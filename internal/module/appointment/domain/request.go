@@ -6,30 +6,33 @@ import (
 
 // CreateAppointmentRequest represents the request to create a new appointment
 type CreateAppointmentRequest struct {
-	UserID          uuid.UUID `json:"user_id" validate:"required"`
-	DoctorID        uuid.UUID `json:"doctor_id" validate:"required"`
-	ScheduleID      uuid.UUID `json:"doctor_schedule_id" validate:"required"`
-	AppointmentDate string    `json:"appointment_date" validate:"required"`
-	AppointmentTime string    `json:"appointment_time" validate:"required"`
-	Reason          string    `json:"reason" validate:"required"`
-	Notes           string    `json:"notes"`
+	UserID          uuid.UUID  `json:"user_id" validate:"required"`
+	PatientID       *uuid.UUID `json:"patient_id"` // Own profile or a dependent, defaults to the own profile
+	DoctorID        uuid.UUID  `json:"doctor_id" validate:"required"`
+	ScheduleID      uuid.UUID  `json:"doctor_schedule_id" validate:"required"`
+	AppointmentDate string     `json:"appointment_date" validate:"required"`
+	AppointmentTime string     `json:"appointment_time" validate:"required"`
+	Reason          string     `json:"reason" validate:"required"`
+	Notes           string     `json:"notes"`
 }
 
 // CancelAppointmentRequest represents the request to cancel an appointment
 type CancelAppointmentRequest struct {
-	UserID uuid.UUID `json:"user_id" validate:"required"`
-	Reason string    `json:"reason" validate:"required"`
-	Notes  string    `json:"notes"`
+	UserID    uuid.UUID  `json:"user_id" validate:"required"`
+	PatientID *uuid.UUID `json:"patient_id"` // Optional, must be the patient of the appointment
+	Reason    string     `json:"reason" validate:"required"`
+	Notes     string     `json:"notes"`
 }
 
 // RescheduleAppointmentRequest represents the request to reschedule an appointment
 type RescheduleAppointmentRequest struct {
-	UserID          uuid.UUID `json:"user_id" validate:"required"`
-	ScheduleID      uuid.UUID `json:"doctor_schedule_id" validate:"required"`
-	AppointmentDate string    `json:"appointment_date" validate:"required"`
-	AppointmentTime string    `json:"appointment_time" validate:"required"`
-	Reason          string    `json:"reason" validate:"required"`
-	Notes           string    `json:"notes"`
+	UserID          uuid.UUID  `json:"user_id" validate:"required"`
+	PatientID       *uuid.UUID `json:"patient_id"` // Optional, must be the patient of the appointment
+	ScheduleID      uuid.UUID  `json:"doctor_schedule_id" validate:"required"`
+	AppointmentDate string     `json:"appointment_date" validate:"required"`
+	AppointmentTime string     `json:"appointment_time" validate:"required"`
+	Reason          string     `json:"reason" validate:"required"`
+	Notes           string     `json:"notes"`
 }
 
 // CheckAvailabilityRequest represents the request to check doctor's availability
@@ -39,3 +42,17 @@ type CheckAvailabilityRequest struct {
 	AppointmentDate string    `json:"appointment_date" validate:"required"`
 	AppointmentTime string    `json:"appointment_time" validate:"required"`
 }
+
+// DoctorAgendaRequest represents the request of a doctor to list their appointments,
+// both dates default to today
+type DoctorAgendaRequest struct {
+	From string `query:"from"`
+	To   string `query:"to"`
+}
+
+// UpdateAppointmentStatusRequest represents the request of a doctor to close an appointment
+type UpdateAppointmentStatusRequest struct {
+	UserID uuid.UUID `json:"-"` // Set by the handler from the authenticated doctor
+	Status string    `json:"status"`
+	Notes  string    `json:"notes"`
+}
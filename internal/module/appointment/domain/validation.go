@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	appointmentConstant "github.com/gomajido/hospital-cms-golang/internal/module/appointment/constant"
 	"github.com/gomajido/hospital-cms-golang/internal/response"
 )
 
@@ -18,6 +19,8 @@ const (
 	STATUS_FIELD           = "status"
 	PAGE_FIELD             = "page"
 	LIMIT_FIELD            = "limit"
+	FROM_FIELD             = "from"
+	TO_FIELD               = "to"
 )
 
 // Validate validates CreateAppointmentRequest
@@ -186,3 +189,73 @@ func (r *CheckAvailabilityRequest) Validate() []response.ErrorInfo {
 
 	return errorInfo
 }
+
+// Validate validates DoctorAgendaRequest
+func (r *DoctorAgendaRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	_, fromErr := time.Parse(appointmentConstant.DateFormat, r.From)
+	if r.From != constant.EMPTY_STRING && fromErr != nil {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        FROM_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_FORMAT, FROM_FIELD, "YYYY-MM-DD"),
+		})
+	}
+
+	_, toErr := time.Parse(appointmentConstant.DateFormat, r.To)
+	if r.To != constant.EMPTY_STRING && toErr != nil {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        TO_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_FORMAT, TO_FIELD, "YYYY-MM-DD"),
+		})
+	}
+
+	if len(errorInfo) > 0 {
+		return errorInfo
+	}
+
+	from, to := r.Dates()
+	if to.Before(from) {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        TO_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MIN_VALUE, TO_FIELD, from.Format(appointmentConstant.DateFormat)),
+		})
+	} else if to.Sub(from) >= appointmentConstant.MaxAgendaRangeDays*24*time.Hour {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        TO_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MAX_VALUE, TO_FIELD, from.AddDate(0, 0, appointmentConstant.MaxAgendaRangeDays-1).Format(appointmentConstant.DateFormat)),
+		})
+	}
+
+	return errorInfo
+}
+
+// Dates returns the requested range, a missing from is today and a missing to is the from date.
+// It must be called after Validate.
+func (r *DoctorAgendaRequest) Dates() (time.Time, time.Time) {
+	from, err := time.Parse(appointmentConstant.DateFormat, r.From)
+	if err != nil {
+		from, _ = time.Parse(appointmentConstant.DateFormat, time.Now().Format(appointmentConstant.DateFormat))
+	}
+
+	to, err := time.Parse(appointmentConstant.DateFormat, r.To)
+	if err != nil {
+		to = from
+	}
+
+	return from, to
+}
+
+// Validate validates UpdateAppointmentStatusRequest
+func (r *UpdateAppointmentStatusRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if r.Status != appointmentConstant.AppointmentStatusCompleted && r.Status != appointmentConstant.AppointmentStatusNoShow {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        STATUS_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_VALUE, STATUS_FIELD, appointmentConstant.AppointmentStatusCompleted+", "+appointmentConstant.AppointmentStatusNoShow),
+		})
+	}
+
+	return errorInfo
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/appointment/constant"
+)
+
+func TestDoctorAgendaRequest(t *testing.T) {
+	today := time.Now().Format(constant.DateFormat)
+
+	tests := []struct {
+		name     string
+		req      DoctorAgendaRequest
+		wantErr  bool
+		wantFrom string
+		wantTo   string
+	}{
+		{
+			name:     "Defaults to today",
+			wantFrom: today,
+			wantTo:   today,
+		},
+		{
+			name:     "Single day",
+			req:      DoctorAgendaRequest{From: "2030-01-07"},
+			wantFrom: "2030-01-07",
+			wantTo:   "2030-01-07",
+		},
+		{
+			name:     "Range",
+			req:      DoctorAgendaRequest{From: "2030-01-07", To: "2030-01-13"},
+			wantFrom: "2030-01-07",
+			wantTo:   "2030-01-13",
+		},
+		{
+			name:    "To before from",
+			req:     DoctorAgendaRequest{From: "2030-01-07", To: "2030-01-06"},
+			wantErr: true,
+		},
+		{
+			name:    "Range too long",
+			req:     DoctorAgendaRequest{From: "2030-01-01", To: "2030-02-01"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid date",
+			req:     DoctorAgendaRequest{From: "07-01-2030"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if (len(errs) > 0) != tt.wantErr {
+				t.Fatalf("Validate() = %+v, want error %v", errs, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			from, to := tt.req.Dates()
+			if from.Format(constant.DateFormat) != tt.wantFrom || to.Format(constant.DateFormat) != tt.wantTo {
+				t.Errorf("Dates() = %s - %s, want %s - %s", from.Format(constant.DateFormat), to.Format(constant.DateFormat), tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestUpdateAppointmentStatusRequest_Validate(t *testing.T) {
+	for status, valid := range map[string]bool{
+		constant.AppointmentStatusCompleted: true,
+		constant.AppointmentStatusNoShow:    true,
+		constant.AppointmentStatusCancelled: false,
+		"":                                  false,
+	} {
+		req := UpdateAppointmentStatusRequest{Status: status}
+		if errs := req.Validate(); (len(errs) == 0) != valid {
+			t.Errorf("Validate() status %q = %+v, want valid %v", status, errs, valid)
+		}
+	}
+}
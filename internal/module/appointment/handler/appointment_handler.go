@@ -1,8 +1,14 @@
 package handler
 
 import (
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
+	authConstant "github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/identity"
+	"github.com/gomajido/hospital-cms-golang/internal/module/appointment/constant"
 	"github.com/gomajido/hospital-cms-golang/internal/module/appointment/domain"
+	doctorConstant "github.com/gomajido/hospital-cms-golang/internal/module/doctor/constant"
 	"github.com/gomajido/hospital-cms-golang/internal/response"
 	"github.com/google/uuid"
 )
@@ -24,8 +30,11 @@ func (h *AppointmentHandler) Create(c *fiber.Ctx) error {
 	}
 
 	// Get user ID from authenticated context
-	userID := c.Locals("user_id").(uuid.UUID)
-	req.UserID = userID
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+	req.UserID = userIdentity.UserID
 
 	if errors := req.Validate(); len(errors) > 0 {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
@@ -33,6 +42,15 @@ func (h *AppointmentHandler) Create(c *fiber.Ctx) error {
 
 	appointment, err := h.appointmentUsecase.Create(c.Context(), req)
 	if err != nil {
+		if errors.Is(err, constant.ErrTimeSlotNotAvailable) {
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		if errors.Is(err, constant.ErrPatientProfileRequired) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+		}
+		if errors.Is(err, constant.ErrPatientNotAuthorized) {
+			return c.Status(fiber.StatusForbidden).JSON(response.ErrForbidden.WithError(err))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
 	}
 
@@ -51,8 +69,11 @@ func (h *AppointmentHandler) Cancel(c *fiber.Ctx) error {
 	}
 
 	// Get user ID from authenticated context
-	userID := c.Locals("user_id").(uuid.UUID)
-	req.UserID = userID
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+	req.UserID = userIdentity.UserID
 
 	if errors := req.Validate(); len(errors) > 0 {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
@@ -60,6 +81,12 @@ func (h *AppointmentHandler) Cancel(c *fiber.Ctx) error {
 
 	appointment, err := h.appointmentUsecase.Cancel(c.Context(), id, req)
 	if err != nil {
+		if errors.Is(err, constant.ErrAppointmentNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		if errors.Is(err, constant.ErrNotAppointmentManager) {
+			return c.Status(fiber.StatusForbidden).JSON(response.ErrForbidden.WithError(err))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
 	}
 
@@ -78,8 +105,11 @@ func (h *AppointmentHandler) Reschedule(c *fiber.Ctx) error {
 	}
 
 	// Get user ID from authenticated context
-	userID := c.Locals("user_id").(uuid.UUID)
-	req.UserID = userID
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+	req.UserID = userIdentity.UserID
 
 	if errors := req.Validate(); len(errors) > 0 {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
@@ -87,6 +117,15 @@ func (h *AppointmentHandler) Reschedule(c *fiber.Ctx) error {
 
 	appointment, err := h.appointmentUsecase.Reschedule(c.Context(), id, req)
 	if err != nil {
+		if errors.Is(err, constant.ErrAppointmentNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		if errors.Is(err, constant.ErrNotAppointmentManager) {
+			return c.Status(fiber.StatusForbidden).JSON(response.ErrForbidden.WithError(err))
+		}
+		if errors.Is(err, constant.ErrTimeSlotNotAvailable) {
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
 	}
 
@@ -119,19 +158,38 @@ func (h *AppointmentHandler) GetByID(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
 	}
 
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
 	appointment, err := h.appointmentUsecase.GetByID(c.Context(), id)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
 	}
 
+	// Only the booker, the patient or their guardian account, and staff allowed to read any
+	// appointment can see it
+	if !userIdentity.HasAbility(authConstant.PERMISSION_APPOINTMENT_READ_ANY) {
+		if err := h.appointmentUsecase.Authorize(c.Context(), appointment, userIdentity.UserID); err != nil {
+			if errors.Is(err, constant.ErrNotAppointmentManager) {
+				return c.Status(fiber.StatusForbidden).JSON(response.ErrForbidden.WithError(errors.New("insufficient permissions")))
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+		}
+	}
+
 	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(appointment))
 }
 
 func (h *AppointmentHandler) GetByUserID(c *fiber.Ctx) error {
 	// Get user ID from authenticated context
-	userID := c.Locals("user_id").(uuid.UUID)
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
 
-	appointments, totalCount, err := h.appointmentUsecase.GetByUserID(c.Context(), userID, 1, 10) // Adding default pagination
+	appointments, totalCount, err := h.appointmentUsecase.GetByUserID(c.Context(), userIdentity.UserID, 1, 10) // Adding default pagination
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
 	}
@@ -158,3 +216,71 @@ func (h *AppointmentHandler) GetByDoctorID(c *fiber.Ctx) error {
 		"total_count":  totalCount,
 	}))
 }
+
+func (h *AppointmentHandler) GetDoctorAgenda(c *fiber.Ctx) error {
+	var req domain.DoctorAgendaRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	from, to := req.Dates()
+	appointments, err := h.appointmentUsecase.GetDoctorAgenda(c.Context(), userIdentity.UserID, from, to)
+	if err != nil {
+		if errors.Is(err, doctorConstant.ErrDoctorAccountNotLinked) {
+			return c.Status(fiber.StatusForbidden).JSON(response.ErrForbidden.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(appointments))
+}
+
+func (h *AppointmentHandler) UpdateStatusByDoctor(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
+	}
+
+	var req domain.UpdateAppointmentStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+	req.UserID = userIdentity.UserID
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	appointment, err := h.appointmentUsecase.UpdateStatusByDoctor(c.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, doctorConstant.ErrDoctorAccountNotLinked) {
+			return c.Status(fiber.StatusForbidden).JSON(response.ErrForbidden.WithError(err))
+		}
+		if errors.Is(err, constant.ErrAppointmentNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		if errors.Is(err, constant.ErrInvalidStatusChange) {
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		if errors.Is(err, constant.ErrAppointmentNotStarted) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(appointment))
+}
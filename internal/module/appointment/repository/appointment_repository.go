@@ -3,14 +3,20 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/google/uuid"
 
 	"github.com/gomajido/hospital-cms-golang/internal/module/appointment/constant"
 	"github.com/gomajido/hospital-cms-golang/internal/module/appointment/domain"
+	doctorConstant "github.com/gomajido/hospital-cms-golang/internal/module/doctor/constant"
 )
 
+// mysqlErrDuplicateEntry is returned by MySQL when a unique index is violated
+const mysqlErrDuplicateEntry = 1062
+
 type AppointmentRepository struct {
 	db *sql.DB
 }
@@ -21,63 +27,82 @@ func NewAppointmentRepository(db *sql.DB) domain.AppointmentRepository {
 	}
 }
 
-// Create creates a new appointment
+// Create creates a new appointment, the slot is locked and checked in the same transaction
+// so concurrent bookings of the same slot cannot both succeed
 func (r *AppointmentRepository) Create(ctx context.Context, appointment *domain.Appointment) error {
 	query := `INSERT INTO appointments (
-		id, user_id, doctor_id, doctor_schedule_id, appointment_date,
+		id, user_id, patient_id, doctor_id, doctor_schedule_id, appointment_date,
 		appointment_time, status, reason, notes, reschedule_count,
 		created_at, updated_at
-	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	now := time.Now()
 	appointment.CreatedAt = now
 	appointment.UpdatedAt = now
 
-	_, err := r.db.ExecContext(ctx, query,
-		appointment.ID, appointment.UserID, appointment.DoctorID,
-		appointment.ScheduleID, appointment.AppointmentDate,
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := r.lockSlot(ctx, tx, appointment); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query,
+		appointment.ID, appointment.UserID, nullUUID(appointment.PatientID),
+		appointment.DoctorID, appointment.ScheduleID, appointment.AppointmentDate,
 		appointment.AppointmentTime, appointment.Status,
 		appointment.Reason, appointment.Notes,
 		appointment.RescheduleCount, appointment.CreatedAt,
 		appointment.UpdatedAt,
 	)
+	if err != nil {
+		return mapSlotError(err)
+	}
 
-	return err
+	return tx.Commit()
 }
 
 // GetByID gets an appointment by ID with related data
 func (r *AppointmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Appointment, error) {
 	query := `
 		SELECT 
-			a.id, a.user_id, a.doctor_id, a.doctor_schedule_id,
+			a.id, a.user_id, a.patient_id, a.doctor_id, a.doctor_schedule_id,
 			a.appointment_date, a.appointment_time, a.status,
 			a.reason, a.notes, a.reschedule_count,
 			a.created_at, a.updated_at,
 			u.name as user_name, u.email as user_email,
+			p.name as patient_name, p.date_of_birth as patient_date_of_birth,
+			p.gender as patient_gender,
 			d.name as doctor_name, d.specialization as doctor_specialization,
 			d.service_id as doctor_service_id,
 			ds.day as schedule_day, ds.start_time as schedule_start_time,
 			ds.end_time as schedule_end_time
 		FROM appointments a
 		LEFT JOIN users u ON a.user_id = u.id
+		LEFT JOIN patients p ON a.patient_id = p.id
 		LEFT JOIN doctors d ON a.doctor_id = d.id
 		LEFT JOIN doctor_schedules ds ON a.doctor_schedule_id = ds.id
 		WHERE a.id = ?`
 
 	appointment := &domain.Appointment{}
 	var userName, userEmail string
+	var patient patientColumns
 	var doctorName, doctorSpecialization string
 	var doctorServiceID uuid.UUID
 	var scheduleDay, scheduleStartTime, scheduleEndTime string
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&appointment.ID, &appointment.UserID, &appointment.DoctorID,
+		&appointment.ID, &appointment.UserID, &patient.id, &appointment.DoctorID,
 		&appointment.ScheduleID, &appointment.AppointmentDate,
 		&appointment.AppointmentTime, &appointment.Status,
 		&appointment.Reason, &appointment.Notes,
 		&appointment.RescheduleCount, &appointment.CreatedAt,
 		&appointment.UpdatedAt,
 		&userName, &userEmail,
+		&patient.name, &patient.dateOfBirth, &patient.gender,
 		&doctorName, &doctorSpecialization, &doctorServiceID,
 		&scheduleDay, &scheduleStartTime, &scheduleEndTime,
 	)
@@ -96,6 +121,8 @@ func (r *AppointmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*dom
 		Email: userEmail,
 	}
 
+	patient.apply(appointment)
+
 	appointment.Doctor = &domain.Doctor{
 		ID:             appointment.DoctorID,
 		Name:           doctorName,
@@ -114,15 +141,16 @@ func (r *AppointmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*dom
 	return appointment, nil
 }
 
-// GetByUserID gets appointments for a user with pagination
+// GetByUserID gets appointments for a user and their dependents with pagination
 func (r *AppointmentRepository) GetByUserID(ctx context.Context, userID uuid.UUID, page, limit int) ([]domain.Appointment, int64, error) {
 	var appointments []domain.Appointment
 	var total int64
 	offset := (page - 1) * limit
 
-	// Get total count
-	countQuery := "SELECT COUNT(*) FROM appointments WHERE user_id = ?"
-	err := r.db.QueryRowContext(ctx, countQuery, userID).Scan(&total)
+	// Get total count, including appointments of the patients the user manages
+	countQuery := `SELECT COUNT(*) FROM appointments
+		WHERE user_id = ? OR patient_id IN (SELECT id FROM patients WHERE user_id = ? OR managed_by = ?)`
+	err := r.db.QueryRowContext(ctx, countQuery, userID, userID, userID).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -130,24 +158,27 @@ func (r *AppointmentRepository) GetByUserID(ctx context.Context, userID uuid.UUI
 	// Get appointments with related data
 	query := `
 		SELECT 
-			a.id, a.user_id, a.doctor_id, a.doctor_schedule_id,
+			a.id, a.user_id, a.patient_id, a.doctor_id, a.doctor_schedule_id,
 			a.appointment_date, a.appointment_time, a.status,
 			a.reason, a.notes, a.reschedule_count,
 			a.created_at, a.updated_at,
 			u.name as user_name, u.email as user_email,
+			p.name as patient_name, p.date_of_birth as patient_date_of_birth,
+			p.gender as patient_gender,
 			d.name as doctor_name, d.specialization as doctor_specialization,
 			d.service_id as doctor_service_id,
 			ds.day as schedule_day, ds.start_time as schedule_start_time,
 			ds.end_time as schedule_end_time
 		FROM appointments a
 		LEFT JOIN users u ON a.user_id = u.id
+		LEFT JOIN patients p ON a.patient_id = p.id
 		LEFT JOIN doctors d ON a.doctor_id = d.id
 		LEFT JOIN doctor_schedules ds ON a.doctor_schedule_id = ds.id
-		WHERE a.user_id = ?
+		WHERE a.user_id = ? OR a.patient_id IN (SELECT id FROM patients WHERE user_id = ? OR managed_by = ?)
 		ORDER BY a.appointment_date DESC, a.appointment_time DESC
 		LIMIT ? OFFSET ?`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, userID, userID, userID, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -156,18 +187,20 @@ func (r *AppointmentRepository) GetByUserID(ctx context.Context, userID uuid.UUI
 	for rows.Next() {
 		var appointment domain.Appointment
 		var userName, userEmail string
+		var patient patientColumns
 		var doctorName, doctorSpecialization string
 		var doctorServiceID uuid.UUID
 		var scheduleDay, scheduleStartTime, scheduleEndTime string
 
 		err := rows.Scan(
-			&appointment.ID, &appointment.UserID, &appointment.DoctorID,
+			&appointment.ID, &appointment.UserID, &patient.id, &appointment.DoctorID,
 			&appointment.ScheduleID, &appointment.AppointmentDate,
 			&appointment.AppointmentTime, &appointment.Status,
 			&appointment.Reason, &appointment.Notes,
 			&appointment.RescheduleCount, &appointment.CreatedAt,
 			&appointment.UpdatedAt,
 			&userName, &userEmail,
+			&patient.name, &patient.dateOfBirth, &patient.gender,
 			&doctorName, &doctorSpecialization, &doctorServiceID,
 			&scheduleDay, &scheduleStartTime, &scheduleEndTime,
 		)
@@ -182,6 +215,8 @@ func (r *AppointmentRepository) GetByUserID(ctx context.Context, userID uuid.UUI
 			Email: userEmail,
 		}
 
+		patient.apply(&appointment)
+
 		appointment.Doctor = &domain.Doctor{
 			ID:             appointment.DoctorID,
 			Name:           doctorName,
@@ -219,17 +254,20 @@ func (r *AppointmentRepository) GetByDoctorID(ctx context.Context, doctorID uuid
 	// Get appointments with related data
 	query := `
 		SELECT 
-			a.id, a.user_id, a.doctor_id, a.doctor_schedule_id,
+			a.id, a.user_id, a.patient_id, a.doctor_id, a.doctor_schedule_id,
 			a.appointment_date, a.appointment_time, a.status,
 			a.reason, a.notes, a.reschedule_count,
 			a.created_at, a.updated_at,
 			u.name as user_name, u.email as user_email,
+			p.name as patient_name, p.date_of_birth as patient_date_of_birth,
+			p.gender as patient_gender,
 			d.name as doctor_name, d.specialization as doctor_specialization,
 			d.service_id as doctor_service_id,
 			ds.day as schedule_day, ds.start_time as schedule_start_time,
 			ds.end_time as schedule_end_time
 		FROM appointments a
 		LEFT JOIN users u ON a.user_id = u.id
+		LEFT JOIN patients p ON a.patient_id = p.id
 		LEFT JOIN doctors d ON a.doctor_id = d.id
 		LEFT JOIN doctor_schedules ds ON a.doctor_schedule_id = ds.id
 		WHERE a.doctor_id = ?
@@ -245,18 +283,20 @@ func (r *AppointmentRepository) GetByDoctorID(ctx context.Context, doctorID uuid
 	for rows.Next() {
 		var appointment domain.Appointment
 		var userName, userEmail string
+		var patient patientColumns
 		var doctorName, doctorSpecialization string
 		var doctorServiceID uuid.UUID
 		var scheduleDay, scheduleStartTime, scheduleEndTime string
 
 		err := rows.Scan(
-			&appointment.ID, &appointment.UserID, &appointment.DoctorID,
+			&appointment.ID, &appointment.UserID, &patient.id, &appointment.DoctorID,
 			&appointment.ScheduleID, &appointment.AppointmentDate,
 			&appointment.AppointmentTime, &appointment.Status,
 			&appointment.Reason, &appointment.Notes,
 			&appointment.RescheduleCount, &appointment.CreatedAt,
 			&appointment.UpdatedAt,
 			&userName, &userEmail,
+			&patient.name, &patient.dateOfBirth, &patient.gender,
 			&doctorName, &doctorSpecialization, &doctorServiceID,
 			&scheduleDay, &scheduleStartTime, &scheduleEndTime,
 		)
@@ -271,6 +311,8 @@ func (r *AppointmentRepository) GetByDoctorID(ctx context.Context, doctorID uuid
 			Email: userEmail,
 		}
 
+		patient.apply(&appointment)
+
 		appointment.Doctor = &domain.Doctor{
 			ID:             appointment.DoctorID,
 			Name:           doctorName,
@@ -292,23 +334,126 @@ func (r *AppointmentRepository) GetByDoctorID(ctx context.Context, doctorID uuid
 	return appointments, total, nil
 }
 
-// Update updates an appointment
+// GetDoctorAgenda gets all appointments of a doctor between two dates in chronological order
+func (r *AppointmentRepository) GetDoctorAgenda(ctx context.Context, doctorID uuid.UUID, from, to time.Time) ([]domain.Appointment, error) {
+	appointments := []domain.Appointment{}
+
+	query := `
+		SELECT 
+			a.id, a.user_id, a.patient_id, a.doctor_id, a.doctor_schedule_id,
+			a.appointment_date, a.appointment_time, a.status,
+			a.reason, a.notes, a.reschedule_count,
+			a.created_at, a.updated_at,
+			u.name as user_name, u.email as user_email,
+			p.name as patient_name, p.date_of_birth as patient_date_of_birth,
+			p.gender as patient_gender,
+			d.name as doctor_name, d.specialization as doctor_specialization,
+			d.service_id as doctor_service_id,
+			ds.day as schedule_day, ds.start_time as schedule_start_time,
+			ds.end_time as schedule_end_time
+		FROM appointments a
+		LEFT JOIN users u ON a.user_id = u.id
+		LEFT JOIN patients p ON a.patient_id = p.id
+		LEFT JOIN doctors d ON a.doctor_id = d.id
+		LEFT JOIN doctor_schedules ds ON a.doctor_schedule_id = ds.id
+		WHERE a.doctor_id = ? AND a.appointment_date BETWEEN ? AND ?
+		ORDER BY a.appointment_date ASC, a.appointment_time ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, doctorID,
+		from.Format(constant.DateFormat), to.Format(constant.DateFormat))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var appointment domain.Appointment
+		var userName, userEmail string
+		var patient patientColumns
+		var doctorName, doctorSpecialization string
+		var doctorServiceID uuid.UUID
+		var scheduleDay, scheduleStartTime, scheduleEndTime string
+
+		err := rows.Scan(
+			&appointment.ID, &appointment.UserID, &patient.id, &appointment.DoctorID,
+			&appointment.ScheduleID, &appointment.AppointmentDate,
+			&appointment.AppointmentTime, &appointment.Status,
+			&appointment.Reason, &appointment.Notes,
+			&appointment.RescheduleCount, &appointment.CreatedAt,
+			&appointment.UpdatedAt,
+			&userName, &userEmail,
+			&patient.name, &patient.dateOfBirth, &patient.gender,
+			&doctorName, &doctorSpecialization, &doctorServiceID,
+			&scheduleDay, &scheduleStartTime, &scheduleEndTime,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// Set related data
+		appointment.User = &domain.User{
+			ID:    appointment.UserID,
+			Name:  userName,
+			Email: userEmail,
+		}
+
+		patient.apply(&appointment)
+
+		appointment.Doctor = &domain.Doctor{
+			ID:             appointment.DoctorID,
+			Name:           doctorName,
+			Specialization: doctorSpecialization,
+			ServiceID:      doctorServiceID,
+		}
+
+		appointment.Schedule = &domain.DoctorSchedule{
+			ID:        appointment.ScheduleID,
+			DoctorID:  appointment.DoctorID,
+			Day:       scheduleDay,
+			StartTime: scheduleStartTime,
+			EndTime:   scheduleEndTime,
+		}
+
+		appointments = append(appointments, appointment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return appointments, nil
+}
+
+// Update updates an appointment, a scheduled appointment keeps its slot locked while the row is written
 func (r *AppointmentRepository) Update(ctx context.Context, appointment *domain.Appointment) error {
 	query := `UPDATE appointments SET
-		appointment_date = ?, appointment_time = ?, status = ?,
-		reason = ?, notes = ?, reschedule_count = ?, updated_at = ?
+		doctor_schedule_id = ?, appointment_date = ?, appointment_time = ?,
+		status = ?, reason = ?, notes = ?, reschedule_count = ?, updated_at = ?
 		WHERE id = ?`
 
 	appointment.UpdatedAt = time.Now()
 
-	result, err := r.db.ExecContext(ctx, query,
-		appointment.AppointmentDate, appointment.AppointmentTime,
-		appointment.Status, appointment.Reason, appointment.Notes,
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if appointment.Status == constant.AppointmentStatusScheduled {
+		if err := r.lockSlot(ctx, tx, appointment); err != nil {
+			return err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, query,
+		appointment.ScheduleID, appointment.AppointmentDate,
+		appointment.AppointmentTime, appointment.Status,
+		appointment.Reason, appointment.Notes,
 		appointment.RescheduleCount, appointment.UpdatedAt,
 		appointment.ID,
 	)
 	if err != nil {
-		return err
+		return mapSlotError(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -320,7 +465,7 @@ func (r *AppointmentRepository) Update(ctx context.Context, appointment *domain.
 		return constant.ErrAppointmentNotFound
 	}
 
-	return nil
+	return tx.Commit()
 }
 
 // Cancel cancels an appointment
@@ -431,3 +576,93 @@ func (r *AppointmentRepository) CheckAvailability(ctx context.Context, req *doma
 
 	return count == 0, nil
 }
+
+// lockSlot locks the doctor schedule row for the rest of the transaction and makes sure no other
+// scheduled appointment of the doctor on the same date overlaps the slot. Slots are compared by
+// their span, as each appointment holds the slot duration of its schedule, so a slot starting
+// inside a booked one is taken even when the start times differ.
+func (r *AppointmentRepository) lockSlot(ctx context.Context, tx *sql.Tx, appointment *domain.Appointment) error {
+	var slotDuration int
+	err := tx.QueryRowContext(ctx,
+		"SELECT slot_duration FROM doctor_schedules WHERE id = ? AND doctor_id = ? FOR UPDATE",
+		appointment.ScheduleID, appointment.DoctorID,
+	).Scan(&slotDuration)
+	if err == sql.ErrNoRows {
+		return constant.ErrTimeSlotNotAvailable
+	}
+	if err != nil {
+		return err
+	}
+	if slotDuration <= 0 {
+		slotDuration = doctorConstant.DefaultSlotDuration
+	}
+
+	var count int
+	query := `SELECT COUNT(*) FROM appointments a
+		INNER JOIN doctor_schedules ds ON ds.id = a.doctor_schedule_id
+		WHERE a.doctor_id = ?
+		AND a.appointment_date = ?
+		AND a.status = ?
+		AND a.id != ?
+		AND TIME_TO_SEC(a.appointment_time) < TIME_TO_SEC(?) + ? * 60
+		AND TIME_TO_SEC(?) < TIME_TO_SEC(a.appointment_time) + IF(ds.slot_duration > 0, ds.slot_duration, ?) * 60`
+
+	err = tx.QueryRowContext(ctx, query,
+		appointment.DoctorID,
+		appointment.AppointmentDate,
+		constant.AppointmentStatusScheduled,
+		appointment.ID,
+		appointment.AppointmentTime, slotDuration,
+		appointment.AppointmentTime, doctorConstant.DefaultSlotDuration,
+	).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return constant.ErrTimeSlotNotAvailable
+	}
+
+	return nil
+}
+
+// patientColumns holds the nullable patient columns of an appointment row
+type patientColumns struct {
+	id          uuid.NullUUID
+	name        sql.NullString
+	dateOfBirth sql.NullTime
+	gender      sql.NullString
+}
+
+// apply sets the patient of an appointment when the row references one
+func (p patientColumns) apply(appointment *domain.Appointment) {
+	if !p.id.Valid {
+		return
+	}
+
+	appointment.PatientID = &p.id.UUID
+	appointment.Patient = &domain.Patient{
+		ID:     p.id.UUID,
+		Name:   p.name.String,
+		Gender: p.gender.String,
+	}
+	if p.dateOfBirth.Valid {
+		appointment.Patient.DateOfBirth = &p.dateOfBirth.Time
+	}
+}
+
+func nullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+// mapSlotError converts a unique slot index violation into ErrTimeSlotNotAvailable
+func mapSlotError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+		return constant.ErrTimeSlotNotAvailable
+	}
+	return err
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/appointment/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/appointment/domain"
+)
+
+// testMySQLDSNEnv points the repository tests to a migrated local MySQL database,
+// e.g. root:secret@tcp(127.0.0.1:3306)/hospital_cms_test?parseTime=true
+const testMySQLDSNEnv = "APEXA_TEST_MYSQL_DSN"
+
+type appointmentFixture struct {
+	userID     uuid.UUID
+	doctorID   uuid.UUID
+	scheduleID uuid.UUID
+}
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv(testMySQLDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set, skipping MySQL repository test", testMySQLDSNEnv)
+	}
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if err := db.Ping(); err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func seedAppointmentFixture(t *testing.T, db *sql.DB) appointmentFixture {
+	t.Helper()
+
+	ctx := context.Background()
+	fixture := appointmentFixture{
+		userID:     uuid.New(),
+		doctorID:   uuid.New(),
+		scheduleID: uuid.New(),
+	}
+	serviceID := uuid.New()
+
+	statements := []struct {
+		query string
+		args  []interface{}
+	}{
+		{
+			query: "INSERT INTO users (id, email, password, name, status) VALUES (?, ?, 'secret', 'Concurrency Test', 'active')",
+			args:  []interface{}{fixture.userID, fixture.userID.String() + "@example.com"},
+		},
+		{
+			query: "INSERT INTO services (id, name, slug) VALUES (?, 'Concurrency Test', ?)",
+			args:  []interface{}{serviceID, serviceID.String()},
+		},
+		{
+			query: "INSERT INTO doctors (id, name, slug, service_id, specialization, degree, experience) VALUES (?, 'Concurrency Test', ?, ?, 'General', 'MD', '1 year')",
+			args:  []interface{}{fixture.doctorID, fixture.doctorID.String(), serviceID},
+		},
+		{
+			query: "INSERT INTO doctor_schedules (id, doctor_id, day, start_time, end_time) VALUES (?, ?, 'Monday', '09:00:00', '12:00:00')",
+			args:  []interface{}{fixture.scheduleID, fixture.doctorID},
+		},
+	}
+	for _, stmt := range statements {
+		if _, err := db.ExecContext(ctx, stmt.query, stmt.args...); err != nil {
+			t.Fatalf("failed to seed fixture: %v", err)
+		}
+	}
+
+	t.Cleanup(func() {
+		// Doctors, schedules and appointments are removed through ON DELETE CASCADE
+		db.ExecContext(ctx, "DELETE FROM services WHERE id = ?", serviceID)
+		db.ExecContext(ctx, "DELETE FROM users WHERE id = ?", fixture.userID)
+	})
+
+	return fixture
+}
+
+func newTestAppointment(fixture appointmentFixture, date time.Time, slot string) *domain.Appointment {
+	return &domain.Appointment{
+		ID:              uuid.New(),
+		UserID:          fixture.userID,
+		DoctorID:        fixture.doctorID,
+		ScheduleID:      fixture.scheduleID,
+		AppointmentDate: date,
+		AppointmentTime: slot,
+		Status:          constant.AppointmentStatusScheduled,
+		Reason:          "Concurrency test",
+	}
+}
+
+func TestAppointmentRepository_CreateConcurrent(t *testing.T) {
+	db := openTestDB(t)
+	fixture := seedAppointmentFixture(t, db)
+	repo := NewAppointmentRepository(db)
+
+	const attempts = 10
+	date := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make(chan error, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			errs <- repo.Create(context.Background(), newTestAppointment(fixture, date, "09:00"))
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	var booked, rejected int
+	for err := range errs {
+		switch {
+		case err == nil:
+			booked++
+		case errors.Is(err, constant.ErrTimeSlotNotAvailable):
+			rejected++
+		default:
+			t.Errorf("Create() unexpected error = %v", err)
+		}
+	}
+
+	if booked != 1 {
+		t.Errorf("Create() booked %d appointments for the same slot, want 1", booked)
+	}
+	if rejected != attempts-1 {
+		t.Errorf("Create() rejected %d appointments, want %d", rejected, attempts-1)
+	}
+}
+
+func TestAppointmentRepository_RebookCancelledSlot(t *testing.T) {
+	db := openTestDB(t)
+	fixture := seedAppointmentFixture(t, db)
+	repo := NewAppointmentRepository(db)
+	ctx := context.Background()
+	date := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+
+	first := newTestAppointment(fixture, date, "09:30")
+	if err := repo.Create(ctx, first); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if err := repo.Create(ctx, newTestAppointment(fixture, date, "09:30")); !errors.Is(err, constant.ErrTimeSlotNotAvailable) {
+		t.Fatalf("Create() on a booked slot error = %v, want %v", err, constant.ErrTimeSlotNotAvailable)
+	}
+
+	first.Status = constant.AppointmentStatusCancelled
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if err := repo.Create(ctx, newTestAppointment(fixture, date, "09:30")); err != nil {
+		t.Errorf("Create() on a released slot error = %v", err)
+	}
+}
+
+func TestAppointmentRepository_CreateOverlapping(t *testing.T) {
+	db := openTestDB(t)
+	fixture := seedAppointmentFixture(t, db)
+	repo := NewAppointmentRepository(db)
+	date := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+
+	// The fixture schedule holds 30 minute slots, a booking at 09:15 falls inside the 09:00 one
+	slots := []string{"09:00", "09:15"}
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make(chan error, len(slots))
+	for _, slot := range slots {
+		wg.Add(1)
+		go func(slot string) {
+			defer wg.Done()
+			<-start
+			errs <- repo.Create(context.Background(), newTestAppointment(fixture, date, slot))
+		}(slot)
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	var booked int
+	for err := range errs {
+		switch {
+		case err == nil:
+			booked++
+		case !errors.Is(err, constant.ErrTimeSlotNotAvailable):
+			t.Errorf("Create() unexpected error = %v", err)
+		}
+	}
+	if booked != 1 {
+		t.Errorf("Create() booked %d overlapping appointments, want 1", booked)
+	}
+
+	if err := repo.Create(context.Background(), newTestAppointment(fixture, date, "10:00")); err != nil {
+		t.Errorf("Create() on a slot after the booked ones error = %v", err)
+	}
+}
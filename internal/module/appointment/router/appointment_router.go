@@ -1,36 +1,58 @@
 package router
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
 	"github.com/gomajido/hospital-cms-golang/internal/middleware"
 	"github.com/gomajido/hospital-cms-golang/internal/module/appointment/handler"
 )
 
-func RegisterAppointmentRoutes(router fiber.Router, appointmentHandler *handler.AppointmentHandler, authMiddleware *middleware.AuthMiddleware) {
+// bookingRateLimit throttles each user's appointment bookings and changes
+var bookingRateLimit = middleware.RateLimitPolicy{Name: "appointments:booking", Limit: 20, Window: time.Hour}
+
+// availabilityRateLimit throttles each user's availability checks
+var availabilityRateLimit = middleware.RateLimitPolicy{Name: "appointments:availability", Limit: 60, Window: time.Minute}
+
+func RegisterAppointmentRoutes(router fiber.Router, appointmentHandler *handler.AppointmentHandler, authMiddleware *middleware.AuthMiddleware, rateLimiter *middleware.RateLimiter) {
 	appointmentRouter := router.Group("/appointments")
 
 	// Protected routes (require authentication)
 	appointmentRouter.Use(authMiddleware.Protected())
+	limitBooking := rateLimiter.Limit(bookingRateLimit)
 	{
 		// Create appointment
-		appointmentRouter.Post("/", appointmentHandler.Create)
+		appointmentRouter.Post("/", limitBooking, appointmentHandler.Create)
 
 		// Get appointments for current user
 		appointmentRouter.Get("/me", appointmentHandler.GetByUserID)
 
 		// Get appointments for a doctor
-		appointmentRouter.Get("/doctor/:doctor_id", appointmentHandler.GetByDoctorID)
+		appointmentRouter.Get("/doctor/:doctor_id", authMiddleware.HasAbility(constant.PERMISSION_APPOINTMENT_READ_ANY), appointmentHandler.GetByDoctorID)
 
 		// Get specific appointment
 		appointmentRouter.Get("/:id", appointmentHandler.GetByID)
 
 		// Cancel appointment
-		appointmentRouter.Post("/:id/cancel", appointmentHandler.Cancel)
+		appointmentRouter.Post("/:id/cancel", limitBooking, appointmentHandler.Cancel)
 
 		// Reschedule appointment
-		appointmentRouter.Post("/:id/reschedule", appointmentHandler.Reschedule)
+		appointmentRouter.Post("/:id/reschedule", limitBooking, appointmentHandler.Reschedule)
 
 		// Check availability
-		appointmentRouter.Post("/check-availability", appointmentHandler.CheckAvailability)
+		appointmentRouter.Post("/check-availability", rateLimiter.Limit(availabilityRateLimit), appointmentHandler.CheckAvailability)
+	}
+
+	// Doctor portal, agenda of the doctor linked to the current user
+	doctorPortal := router.Group("/doctor/me/appointments")
+	protected := authMiddleware.Protected()
+	asDoctor := authMiddleware.HasAbility(constant.PERMISSION_DOCTOR_PORTAL)
+	{
+		// Appointments for today or the requested date range
+		doctorPortal.Get("", protected, asDoctor, appointmentHandler.GetDoctorAgenda)
+
+		// Mark an appointment completed or no-show
+		doctorPortal.Put("/:id/status", protected, asDoctor, appointmentHandler.UpdateStatusByDoctor)
 	}
 }
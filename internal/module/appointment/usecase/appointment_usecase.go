@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,16 +10,23 @@ import (
 
 	"github.com/gomajido/hospital-cms-golang/internal/module/appointment/constant"
 	"github.com/gomajido/hospital-cms-golang/internal/module/appointment/domain"
+	doctorDomain "github.com/gomajido/hospital-cms-golang/internal/module/doctor/domain"
+	patientConstant "github.com/gomajido/hospital-cms-golang/internal/module/patient/constant"
+	patientDomain "github.com/gomajido/hospital-cms-golang/internal/module/patient/domain"
 )
 
 type appointmentUsecase struct {
 	appointmentRepo domain.AppointmentRepository
+	doctorUsecase   doctorDomain.DoctorUsecase
+	patientUsecase  patientDomain.PatientUsecase
 }
 
 // NewAppointmentUsecase creates a new instance of appointmentUsecase
-func NewAppointmentUsecase(ar domain.AppointmentRepository) domain.AppointmentUsecase {
+func NewAppointmentUsecase(ar domain.AppointmentRepository, du doctorDomain.DoctorUsecase, pu patientDomain.PatientUsecase) domain.AppointmentUsecase {
 	return &appointmentUsecase{
 		appointmentRepo: ar,
+		doctorUsecase:   du,
+		patientUsecase:  pu,
 	}
 }
 
@@ -29,13 +37,14 @@ func (u *appointmentUsecase) Create(ctx context.Context, req domain.CreateAppoin
 		return nil, fmt.Errorf("invalid appointment date format: %v", err)
 	}
 
-	// Check if doctor schedule is available
-	available, err := u.appointmentRepo.CheckAvailability(ctx, &domain.CheckAvailabilityRequest{
-		DoctorID:        req.DoctorID,
-		ScheduleID:      req.ScheduleID,
-		AppointmentDate: req.AppointmentDate, // Use the parsed appointmentDate,
-		AppointmentTime: req.AppointmentTime,
-	})
+	// Appointments are booked for the patient profile of the user or one of their dependents
+	patient, err := u.bookingPatient(ctx, req.UserID, req.PatientID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the requested time is a free slot of the doctor schedule
+	available, err := u.doctorUsecase.IsSlotAvailable(ctx, req.DoctorID, req.ScheduleID, appointmentDate, req.AppointmentTime)
 	if err != nil {
 		return nil, err
 	}
@@ -47,6 +56,7 @@ func (u *appointmentUsecase) Create(ctx context.Context, req domain.CreateAppoin
 	appointment := &domain.Appointment{
 		ID:              uuid.New(),
 		UserID:          req.UserID,
+		PatientID:       &patient.ID,
 		DoctorID:        req.DoctorID,
 		ScheduleID:      req.ScheduleID,
 		AppointmentDate: appointmentDate,
@@ -63,6 +73,13 @@ func (u *appointmentUsecase) Create(ctx context.Context, req domain.CreateAppoin
 		return nil, err
 	}
 
+	appointment.Patient = &domain.Patient{
+		ID:          patient.ID,
+		Name:        patient.Name,
+		DateOfBirth: patient.DateOfBirth,
+		Gender:      patient.Gender,
+	}
+
 	return appointment, nil
 }
 
@@ -70,6 +87,11 @@ func (u *appointmentUsecase) GetByID(ctx context.Context, id uuid.UUID) (*domain
 	return u.appointmentRepo.GetByID(ctx, id)
 }
 
+// Authorize checks that a user may see an appointment, with the same rules as changing it
+func (u *appointmentUsecase) Authorize(ctx context.Context, appointment *domain.Appointment, userID uuid.UUID) error {
+	return u.authorize(ctx, appointment, userID, nil)
+}
+
 func (u *appointmentUsecase) GetByUserID(ctx context.Context, userID uuid.UUID, page, limit int) ([]domain.Appointment, int64, error) {
 	if page < 1 {
 		page = 1
@@ -99,9 +121,9 @@ func (u *appointmentUsecase) Cancel(ctx context.Context, id uuid.UUID, req domai
 		return nil, err
 	}
 
-	// Check if user owns the appointment
-	if appointment.UserID != req.UserID {
-		return nil, fmt.Errorf("unauthorized: only the appointment owner can cancel")
+	// Check if user manages the appointment
+	if err := u.authorize(ctx, appointment, req.UserID, req.PatientID); err != nil {
+		return nil, err
 	}
 
 	// Check if appointment can be cancelled
@@ -128,9 +150,9 @@ func (u *appointmentUsecase) Reschedule(ctx context.Context, id uuid.UUID, req d
 		return nil, err
 	}
 
-	// Check if user owns the appointment
-	if appointment.UserID != req.UserID {
-		return nil, fmt.Errorf("unauthorized: only the appointment owner can reschedule")
+	// Check if user manages the appointment
+	if err := u.authorize(ctx, appointment, req.UserID, req.PatientID); err != nil {
+		return nil, err
 	}
 
 	// Check if appointment can be rescheduled
@@ -149,13 +171,8 @@ func (u *appointmentUsecase) Reschedule(ctx context.Context, id uuid.UUID, req d
 		return nil, fmt.Errorf("invalid appointment date format: %v", err)
 	}
 
-	// Check if new schedule is available
-	available, err := u.appointmentRepo.CheckAvailability(ctx, &domain.CheckAvailabilityRequest{
-		DoctorID:        appointment.DoctorID,
-		ScheduleID:      req.ScheduleID,
-		AppointmentDate: req.AppointmentDate,
-		AppointmentTime: req.AppointmentTime,
-	})
+	// Check if the new time is a free slot of the doctor schedule
+	available, err := u.doctorUsecase.IsSlotAvailable(ctx, appointment.DoctorID, req.ScheduleID, appointmentDate, req.AppointmentTime)
 	if err != nil {
 		return nil, err
 	}
@@ -178,17 +195,116 @@ func (u *appointmentUsecase) Reschedule(ctx context.Context, id uuid.UUID, req d
 	return appointment, nil
 }
 
+// GetDoctorAgenda lists the appointments of the doctor linked to a user between two dates
+func (u *appointmentUsecase) GetDoctorAgenda(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]domain.Appointment, error) {
+	doctor, err := u.doctorUsecase.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.appointmentRepo.GetDoctorAgenda(ctx, doctor.ID, from, to)
+}
+
+// UpdateStatusByDoctor marks an appointment of the doctor linked to a user as completed or no-show
+// once its start time has passed
+func (u *appointmentUsecase) UpdateStatusByDoctor(ctx context.Context, id uuid.UUID, req domain.UpdateAppointmentStatusRequest) (*domain.Appointment, error) {
+	doctor, err := u.doctorUsecase.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	appointment, err := u.appointmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Appointments of other doctors are reported as not found
+	if appointment.DoctorID != doctor.ID {
+		return nil, constant.ErrAppointmentNotFound
+	}
+
+	if appointment.Status != constant.AppointmentStatusScheduled {
+		return nil, constant.ErrInvalidStatusChange
+	}
+
+	startsAt, err := appointmentStart(appointment)
+	if err != nil {
+		return nil, err
+	}
+	if startsAt.After(time.Now()) {
+		return nil, constant.ErrAppointmentNotStarted
+	}
+
+	appointment.Status = req.Status
+	if req.Notes != "" {
+		appointment.Notes = req.Notes
+	}
+	appointment.UpdatedAt = time.Now()
+
+	if err := u.appointmentRepo.Update(ctx, appointment); err != nil {
+		return nil, err
+	}
+
+	return appointment, nil
+}
+
+// appointmentStart returns the local date and time an appointment starts at
+func appointmentStart(appointment *domain.Appointment) (time.Time, error) {
+	slot := appointment.AppointmentTime
+	if len(slot) > len(constant.TimeFormat) {
+		slot = slot[:len(constant.TimeFormat)]
+	}
+
+	return time.ParseInLocation(constant.DateFormat+" "+constant.TimeFormat,
+		appointment.AppointmentDate.Format(constant.DateFormat)+" "+slot, time.Local)
+}
+
+// bookingPatient resolves the patient an appointment is booked for. Without a patient ID the
+// user's own profile is used, created from their account on a first booking, otherwise the
+// patient must be the user or one of their dependents.
+func (u *appointmentUsecase) bookingPatient(ctx context.Context, userID uuid.UUID, patientID *uuid.UUID) (*patientDomain.Patient, error) {
+	if patientID == nil {
+		patient, err := u.patientUsecase.GetOrCreateProfile(ctx, userID)
+		if errors.Is(err, patientConstant.ErrPatientNotFound) {
+			return nil, constant.ErrPatientProfileRequired
+		}
+		return patient, err
+	}
+
+	patient, err := u.patientUsecase.GetAuthorized(ctx, userID, *patientID)
+	if errors.Is(err, patientConstant.ErrPatientNotFound) {
+		return nil, constant.ErrPatientNotAuthorized
+	}
+	return patient, err
+}
+
+// authorize checks that a user may change an appointment: they booked it, or the patient is
+// their own profile or one of their dependents. A given patient ID must match the appointment.
+func (u *appointmentUsecase) authorize(ctx context.Context, appointment *domain.Appointment, userID uuid.UUID, patientID *uuid.UUID) error {
+	if patientID != nil && (appointment.PatientID == nil || *appointment.PatientID != *patientID) {
+		return constant.ErrAppointmentNotFound
+	}
+
+	if appointment.UserID == userID {
+		return nil
+	}
+	if appointment.PatientID == nil {
+		return constant.ErrNotAppointmentManager
+	}
+
+	_, err := u.patientUsecase.GetAuthorized(ctx, userID, *appointment.PatientID)
+	if errors.Is(err, patientConstant.ErrPatientNotFound) {
+		return constant.ErrNotAppointmentManager
+	}
+	return err
+}
+
 func (u *appointmentUsecase) CheckAvailability(ctx context.Context, req domain.CheckAvailabilityRequest) (bool, error) {
 	// Parse appointment date
-	_, err := time.Parse("2006-01-02", req.AppointmentDate)
+	appointmentDate, err := time.Parse("2006-01-02", req.AppointmentDate)
 	if err != nil {
 		return false, fmt.Errorf("invalid appointment date format: %v", err)
 	}
 
-	return u.appointmentRepo.CheckAvailability(ctx, &domain.CheckAvailabilityRequest{
-		DoctorID:        req.DoctorID,
-		ScheduleID:      req.ScheduleID,
-		AppointmentDate: req.AppointmentDate,
-		AppointmentTime: req.AppointmentTime,
-	})
+	return u.doctorUsecase.IsSlotAvailable(ctx, req.DoctorID, req.ScheduleID, appointmentDate, req.AppointmentTime)
 }
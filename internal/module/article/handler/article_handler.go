@@ -9,8 +9,8 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
+	"github.com/gomajido/hospital-cms-golang/internal/helper/identity"
 	"github.com/gomajido/hospital-cms-golang/internal/module/article/domain"
-	authdomain "github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
 	"github.com/gomajido/hospital-cms-golang/internal/response"
 	"github.com/gomajido/hospital-cms-golang/pkg/app_log"
 )
@@ -148,12 +148,12 @@ func (h *ArticleHandler) Create(c *fiber.Ctx) error {
 	}
 
 	// Get user from context
-	userToken, ok := c.Locals("user_token").(*authdomain.UserToken)
-	if !ok {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(fmt.Errorf("missing user token")))
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
 	}
 
-	req.AuthorID = userToken.UserID
+	req.AuthorID = userIdentity.UserID
 
 	// Validate request
 	if errors := req.Validate(); len(errors) > 0 {
@@ -199,9 +199,9 @@ func (h *ArticleHandler) Update(c *fiber.Ctx) error {
 	}
 
 	// Get user from context
-	userToken, ok := c.Locals("user_token").(*authdomain.UserToken)
-	if !ok {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(fmt.Errorf("missing user token")))
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
 	}
 
 	// Check if user is author or has permission
@@ -213,7 +213,7 @@ func (h *ArticleHandler) Update(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
 	}
 
-	updatedArticle, err := h.articleUsecase.Update(c.Context(), article.ID, userToken.UserID, req)
+	updatedArticle, err := h.articleUsecase.Update(c.Context(), article.ID, userIdentity.UserID, req)
 	if err != nil {
 		if err.Error() == "article not found" {
 			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
@@ -244,9 +244,9 @@ func (h *ArticleHandler) Delete(c *fiber.Ctx) error {
 	}
 
 	// Get user from context
-	userToken, ok := c.Locals("user_token").(*authdomain.UserToken)
-	if !ok {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(fmt.Errorf("missing user token")))
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
 	}
 
 	// Check if user is author or has permission
@@ -258,7 +258,7 @@ func (h *ArticleHandler) Delete(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
 	}
 
-	if err := h.articleUsecase.Delete(c.Context(), article.ID, userToken.UserID); err != nil {
+	if err := h.articleUsecase.Delete(c.Context(), article.ID, userIdentity.UserID); err != nil {
 		if err.Error() == "article not found" {
 			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
 		}
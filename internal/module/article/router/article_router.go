@@ -1,24 +1,31 @@
 package router
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
 	"github.com/gomajido/hospital-cms-golang/internal/middleware"
 	"github.com/gomajido/hospital-cms-golang/internal/module/article/handler"
 )
 
+// publicRateLimit throttles anonymous reads of the articles
+var publicRateLimit = middleware.RateLimitPolicy{Name: "articles:public", Limit: 120, Window: time.Minute}
+
 // RegisterArticleRoutes registers all article routes
-func RegisterArticleRoutes(router fiber.Router, h *handler.ArticleHandler, authMiddleware *middleware.AuthMiddleware) {
+func RegisterArticleRoutes(router fiber.Router, h *handler.ArticleHandler, authMiddleware *middleware.AuthMiddleware, rateLimiter *middleware.RateLimiter) {
 	articles := router.Group("/articles")
 
 	// Public routes
-	articles.Get("", h.List)
-	articles.Get("/:id", h.GetByID)
-	articles.Get("/slug/:slug", h.GetBySlug)
+	limitPublic := rateLimiter.Limit(publicRateLimit)
+	articles.Get("", limitPublic, h.List)
+	articles.Get("/:id", limitPublic, h.GetByID)
+	articles.Get("/slug/:slug", limitPublic, h.GetBySlug)
 
-	// Protected routes for admins only
+	// Protected routes for article writers only
 	articles.Use(authMiddleware.Protected())
-	articles.Use(authMiddleware.HasAbility("admin"))
+	articles.Use(authMiddleware.HasAbility(constant.PERMISSION_ARTICLE_WRITE))
 	articles.Post("", h.Create)
 	articles.Put("/:id", h.Update)
 	articles.Delete("/:id", h.Delete)
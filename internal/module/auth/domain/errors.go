@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"time"
+)
+
+// LoginThrottledError is returned when a login is refused because of too many failed attempts
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return "too many login attempts"
+}
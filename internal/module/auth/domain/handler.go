@@ -8,12 +8,66 @@ type AuthHandler interface {
 	Register(c *fiber.Ctx) error
 	Login(c *fiber.Ctx) error
 	Logout(c *fiber.Ctx) error
+	RefreshToken(c *fiber.Ctx) error
+	VerifyLoginMFA(c *fiber.Ctx) error
+	EnrollLoginMFA(c *fiber.Ctx) error
+	StartOIDCLogin(c *fiber.Ctx) error
+	OIDCCallback(c *fiber.Ctx) error
+	VerifyEmail(c *fiber.Ctx) error
+	ResendVerificationEmail(c *fiber.Ctx) error
+	ForgotPassword(c *fiber.Ctx) error
+	ResetPassword(c *fiber.Ctx) error
+	ConfirmEmailChange(c *fiber.Ctx) error
+
+	// Two-factor authentication
+	EnrollMFA(c *fiber.Ctx) error
+	ConfirmMFA(c *fiber.Ctx) error
+	RegenerateRecoveryCodes(c *fiber.Ctx) error
+	DisableMFA(c *fiber.Ctx) error
+
+	// Sessions
+	ListSessions(c *fiber.Ctx) error
+	RevokeSession(c *fiber.Ctx) error
+	RevokeAllSessions(c *fiber.Ctx) error
 
 	// User management
 	GetUserByID(c *fiber.Ctx) error
 	UpdateUser(c *fiber.Ctx) error
+	ChangePassword(c *fiber.Ctx) error
+	ChangeEmail(c *fiber.Ctx) error
+	UnlockUser(c *fiber.Ctx) error
+	ListUsers(c *fiber.Ctx) error
+	GetUser(c *fiber.Ctx) error
+	SuspendUser(c *fiber.Ctx) error
+	ReactivateUser(c *fiber.Ctx) error
+	DeleteUser(c *fiber.Ctx) error
+	RestoreUser(c *fiber.Ctx) error
+	ListUserSessions(c *fiber.Ctx) error
+	RevokeUserSession(c *fiber.Ctx) error
+	RevokeUserSessions(c *fiber.Ctx) error
+
+	// Staff invitations
+	CreateInvitation(c *fiber.Ctx) error
+	ListInvitations(c *fiber.Ctx) error
+	RevokeInvitation(c *fiber.Ctx) error
+	AcceptInvitation(c *fiber.Ctx) error
 
 	// Role management
+	ListRoles(c *fiber.Ctx) error
+	GetRole(c *fiber.Ctx) error
+	CreateRole(c *fiber.Ctx) error
+	UpdateRole(c *fiber.Ctx) error
+	DeleteRole(c *fiber.Ctx) error
+	GetUsersByRole(c *fiber.Ctx) error
 	AssignRoles(c *fiber.Ctx) error
+	RevokeRoles(c *fiber.Ctx) error
 	GetUserRoles(c *fiber.Ctx) error
+
+	// Permission management
+	ListPermissions(c *fiber.Ctx) error
+	GetRolePermissions(c *fiber.Ctx) error
+	SetRolePermissions(c *fiber.Ctx) error
+
+	// Audit logs
+	ListAuditLogs(c *fiber.Ctx) error
 }
@@ -7,9 +7,11 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	domain "github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	uuid "github.com/google/uuid"
 )
 
 // MockAuthRepository is a mock of AuthRepository interface.
@@ -35,8 +37,23 @@ func (m *MockAuthRepository) EXPECT() *MockAuthRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AcceptInvitation mocks base method.
+func (m *MockAuthRepository) AcceptInvitation(ctx context.Context, id uuid.UUID, user *domain.User, roleIDs []uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, id, user, roleIDs)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockAuthRepositoryMockRecorder) AcceptInvitation(ctx, id, user, roleIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockAuthRepository)(nil).AcceptInvitation), ctx, id, user, roleIDs)
+}
+
 // AssignRolesToUser mocks base method.
-func (m *MockAuthRepository) AssignRolesToUser(ctx context.Context, userID string, roleIDs []string) error {
+func (m *MockAuthRepository) AssignRolesToUser(ctx context.Context, userID uuid.UUID, roleIDs []uuid.UUID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AssignRolesToUser", ctx, userID, roleIDs)
 	ret0, _ := ret[0].(error)
@@ -49,6 +66,105 @@ func (mr *MockAuthRepositoryMockRecorder) AssignRolesToUser(ctx, userID, roleIDs
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRolesToUser", reflect.TypeOf((*MockAuthRepository)(nil).AssignRolesToUser), ctx, userID, roleIDs)
 }
 
+// ClaimMFAStep mocks base method.
+func (m *MockAuthRepository) ClaimMFAStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimMFAStep", ctx, userID, step)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimMFAStep indicates an expected call of ClaimMFAStep.
+func (mr *MockAuthRepositoryMockRecorder) ClaimMFAStep(ctx, userID, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimMFAStep", reflect.TypeOf((*MockAuthRepository)(nil).ClaimMFAStep), ctx, userID, step)
+}
+
+// CreateAuditLog mocks base method.
+func (m *MockAuthRepository) CreateAuditLog(ctx context.Context, log *domain.AuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditLog", ctx, log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuditLog indicates an expected call of CreateAuditLog.
+func (mr *MockAuthRepositoryMockRecorder) CreateAuditLog(ctx, log interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditLog", reflect.TypeOf((*MockAuthRepository)(nil).CreateAuditLog), ctx, log)
+}
+
+// CreateEmailChangeToken mocks base method.
+func (m *MockAuthRepository) CreateEmailChangeToken(ctx context.Context, token *domain.EmailChangeToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailChangeToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEmailChangeToken indicates an expected call of CreateEmailChangeToken.
+func (mr *MockAuthRepositoryMockRecorder) CreateEmailChangeToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailChangeToken", reflect.TypeOf((*MockAuthRepository)(nil).CreateEmailChangeToken), ctx, token)
+}
+
+// CreateEmailVerificationToken mocks base method.
+func (m *MockAuthRepository) CreateEmailVerificationToken(ctx context.Context, token *domain.EmailVerificationToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailVerificationToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEmailVerificationToken indicates an expected call of CreateEmailVerificationToken.
+func (mr *MockAuthRepositoryMockRecorder) CreateEmailVerificationToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailVerificationToken", reflect.TypeOf((*MockAuthRepository)(nil).CreateEmailVerificationToken), ctx, token)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockAuthRepository) CreateInvitation(ctx context.Context, invitation *domain.Invitation, roleIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", ctx, invitation, roleIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockAuthRepositoryMockRecorder) CreateInvitation(ctx, invitation, roleIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockAuthRepository)(nil).CreateInvitation), ctx, invitation, roleIDs)
+}
+
+// CreatePasswordResetToken mocks base method.
+func (m *MockAuthRepository) CreatePasswordResetToken(ctx context.Context, token *domain.PasswordResetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken.
+func (mr *MockAuthRepositoryMockRecorder) CreatePasswordResetToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockAuthRepository)(nil).CreatePasswordResetToken), ctx, token)
+}
+
+// CreateRole mocks base method.
+func (m *MockAuthRepository) CreateRole(ctx context.Context, role *domain.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", ctx, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockAuthRepositoryMockRecorder) CreateRole(ctx, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockAuthRepository)(nil).CreateRole), ctx, role)
+}
+
 // CreateUser mocks base method.
 func (m *MockAuthRepository) CreateUser(ctx context.Context, user *domain.User) error {
 	m.ctrl.T.Helper()
@@ -63,22 +179,51 @@ func (mr *MockAuthRepositoryMockRecorder) CreateUser(ctx, user interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAuthRepository)(nil).CreateUser), ctx, user)
 }
 
-// CreateUserToken mocks base method.
-func (m *MockAuthRepository) CreateUserToken(ctx context.Context, token *domain.UserToken) error {
+// CreateUserIdentity mocks base method.
+func (m *MockAuthRepository) CreateUserIdentity(ctx context.Context, identity *domain.UserIdentity) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUserToken", ctx, token)
+	ret := m.ctrl.Call(m, "CreateUserIdentity", ctx, identity)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// CreateUserIdentity indicates an expected call of CreateUserIdentity.
+func (mr *MockAuthRepositoryMockRecorder) CreateUserIdentity(ctx, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserIdentity", reflect.TypeOf((*MockAuthRepository)(nil).CreateUserIdentity), ctx, identity)
+}
+
+// CreateUserToken mocks base method.
+func (m *MockAuthRepository) CreateUserToken(ctx context.Context, token *domain.UserToken) (*domain.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserToken", ctx, token)
+	ret0, _ := ret[0].(*domain.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // CreateUserToken indicates an expected call of CreateUserToken.
 func (mr *MockAuthRepositoryMockRecorder) CreateUserToken(ctx, token interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserToken", reflect.TypeOf((*MockAuthRepository)(nil).CreateUserToken), ctx, token)
 }
 
+// DeleteRole mocks base method.
+func (m *MockAuthRepository) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockAuthRepositoryMockRecorder) DeleteRole(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockAuthRepository)(nil).DeleteRole), ctx, id)
+}
+
 // DeleteUser mocks base method.
-func (m *MockAuthRepository) DeleteUser(ctx context.Context, id string) error {
+func (m *MockAuthRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
 	ret0, _ := ret[0].(error)
@@ -91,8 +236,111 @@ func (mr *MockAuthRepositoryMockRecorder) DeleteUser(ctx, id interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockAuthRepository)(nil).DeleteUser), ctx, id)
 }
 
+// DeleteUserMFA mocks base method.
+func (m *MockAuthRepository) DeleteUserMFA(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserMFA", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserMFA indicates an expected call of DeleteUserMFA.
+func (mr *MockAuthRepositoryMockRecorder) DeleteUserMFA(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserMFA", reflect.TypeOf((*MockAuthRepository)(nil).DeleteUserMFA), ctx, userID)
+}
+
+// EnableUserMFA mocks base method.
+func (m *MockAuthRepository) EnableUserMFA(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableUserMFA", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableUserMFA indicates an expected call of EnableUserMFA.
+func (mr *MockAuthRepositoryMockRecorder) EnableUserMFA(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableUserMFA", reflect.TypeOf((*MockAuthRepository)(nil).EnableUserMFA), ctx, userID)
+}
+
+// GetEmailChangeToken mocks base method.
+func (m *MockAuthRepository) GetEmailChangeToken(ctx context.Context, id uuid.UUID) (*domain.EmailChangeToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailChangeToken", ctx, id)
+	ret0, _ := ret[0].(*domain.EmailChangeToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailChangeToken indicates an expected call of GetEmailChangeToken.
+func (mr *MockAuthRepositoryMockRecorder) GetEmailChangeToken(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailChangeToken", reflect.TypeOf((*MockAuthRepository)(nil).GetEmailChangeToken), ctx, id)
+}
+
+// GetEmailVerificationToken mocks base method.
+func (m *MockAuthRepository) GetEmailVerificationToken(ctx context.Context, id uuid.UUID) (*domain.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailVerificationToken", ctx, id)
+	ret0, _ := ret[0].(*domain.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailVerificationToken indicates an expected call of GetEmailVerificationToken.
+func (mr *MockAuthRepositoryMockRecorder) GetEmailVerificationToken(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailVerificationToken", reflect.TypeOf((*MockAuthRepository)(nil).GetEmailVerificationToken), ctx, id)
+}
+
+// GetInvitationByID mocks base method.
+func (m *MockAuthRepository) GetInvitationByID(ctx context.Context, id uuid.UUID) (*domain.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationByID indicates an expected call of GetInvitationByID.
+func (mr *MockAuthRepositoryMockRecorder) GetInvitationByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationByID", reflect.TypeOf((*MockAuthRepository)(nil).GetInvitationByID), ctx, id)
+}
+
+// GetPasswordResetToken mocks base method.
+func (m *MockAuthRepository) GetPasswordResetToken(ctx context.Context, id uuid.UUID) (*domain.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordResetToken", ctx, id)
+	ret0, _ := ret[0].(*domain.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordResetToken indicates an expected call of GetPasswordResetToken.
+func (mr *MockAuthRepositoryMockRecorder) GetPasswordResetToken(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordResetToken", reflect.TypeOf((*MockAuthRepository)(nil).GetPasswordResetToken), ctx, id)
+}
+
+// GetPermissionsByNames mocks base method.
+func (m *MockAuthRepository) GetPermissionsByNames(ctx context.Context, names []string) ([]domain.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermissionsByNames", ctx, names)
+	ret0, _ := ret[0].([]domain.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPermissionsByNames indicates an expected call of GetPermissionsByNames.
+func (mr *MockAuthRepositoryMockRecorder) GetPermissionsByNames(ctx, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissionsByNames", reflect.TypeOf((*MockAuthRepository)(nil).GetPermissionsByNames), ctx, names)
+}
+
 // GetRoleByID mocks base method.
-func (m *MockAuthRepository) GetRoleByID(ctx context.Context, id string) (*domain.Role, error) {
+func (m *MockAuthRepository) GetRoleByID(ctx context.Context, id uuid.UUID) (*domain.Role, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetRoleByID", ctx, id)
 	ret0, _ := ret[0].(*domain.Role)
@@ -106,6 +354,21 @@ func (mr *MockAuthRepositoryMockRecorder) GetRoleByID(ctx, id interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleByID", reflect.TypeOf((*MockAuthRepository)(nil).GetRoleByID), ctx, id)
 }
 
+// GetRolePermissions mocks base method.
+func (m *MockAuthRepository) GetRolePermissions(ctx context.Context, roleID uuid.UUID) ([]domain.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolePermissions", ctx, roleID)
+	ret0, _ := ret[0].([]domain.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRolePermissions indicates an expected call of GetRolePermissions.
+func (mr *MockAuthRepositoryMockRecorder) GetRolePermissions(ctx, roleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolePermissions", reflect.TypeOf((*MockAuthRepository)(nil).GetRolePermissions), ctx, roleID)
+}
+
 // GetRolesByNames mocks base method.
 func (m *MockAuthRepository) GetRolesByNames(ctx context.Context, names []string) ([]domain.Role, error) {
 	m.ctrl.T.Helper()
@@ -137,7 +400,7 @@ func (mr *MockAuthRepositoryMockRecorder) GetUserByEmail(ctx, email interface{})
 }
 
 // GetUserByID mocks base method.
-func (m *MockAuthRepository) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
+func (m *MockAuthRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
 	ret0, _ := ret[0].(*domain.User)
@@ -151,8 +414,83 @@ func (mr *MockAuthRepositoryMockRecorder) GetUserByID(ctx, id interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockAuthRepository)(nil).GetUserByID), ctx, id)
 }
 
+// GetUserByIDWithDeleted mocks base method.
+func (m *MockAuthRepository) GetUserByIDWithDeleted(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByIDWithDeleted", ctx, id)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByIDWithDeleted indicates an expected call of GetUserByIDWithDeleted.
+func (mr *MockAuthRepositoryMockRecorder) GetUserByIDWithDeleted(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByIDWithDeleted", reflect.TypeOf((*MockAuthRepository)(nil).GetUserByIDWithDeleted), ctx, id)
+}
+
+// GetUserIDsByRoleID mocks base method.
+func (m *MockAuthRepository) GetUserIDsByRoleID(ctx context.Context, roleID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDsByRoleID", ctx, roleID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDsByRoleID indicates an expected call of GetUserIDsByRoleID.
+func (mr *MockAuthRepositoryMockRecorder) GetUserIDsByRoleID(ctx, roleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDsByRoleID", reflect.TypeOf((*MockAuthRepository)(nil).GetUserIDsByRoleID), ctx, roleID)
+}
+
+// GetUserIdentity mocks base method.
+func (m *MockAuthRepository) GetUserIdentity(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIdentity", ctx, provider, subject)
+	ret0, _ := ret[0].(*domain.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIdentity indicates an expected call of GetUserIdentity.
+func (mr *MockAuthRepositoryMockRecorder) GetUserIdentity(ctx, provider, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIdentity", reflect.TypeOf((*MockAuthRepository)(nil).GetUserIdentity), ctx, provider, subject)
+}
+
+// GetUserMFA mocks base method.
+func (m *MockAuthRepository) GetUserMFA(ctx context.Context, userID uuid.UUID) (*domain.UserMFA, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserMFA", ctx, userID)
+	ret0, _ := ret[0].(*domain.UserMFA)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserMFA indicates an expected call of GetUserMFA.
+func (mr *MockAuthRepositoryMockRecorder) GetUserMFA(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMFA", reflect.TypeOf((*MockAuthRepository)(nil).GetUserMFA), ctx, userID)
+}
+
+// GetUserPermissions mocks base method.
+func (m *MockAuthRepository) GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPermissions", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPermissions indicates an expected call of GetUserPermissions.
+func (mr *MockAuthRepositoryMockRecorder) GetUserPermissions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPermissions", reflect.TypeOf((*MockAuthRepository)(nil).GetUserPermissions), ctx, userID)
+}
+
 // GetUserRoles mocks base method.
-func (m *MockAuthRepository) GetUserRoles(ctx context.Context, userID string) ([]domain.Role, error) {
+func (m *MockAuthRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]domain.Role, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUserRoles", ctx, userID)
 	ret0, _ := ret[0].([]domain.Role)
@@ -166,37 +504,123 @@ func (mr *MockAuthRepositoryMockRecorder) GetUserRoles(ctx, userID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoles", reflect.TypeOf((*MockAuthRepository)(nil).GetUserRoles), ctx, userID)
 }
 
-// GetUserTokenByIDAndToken mocks base method.
-func (m *MockAuthRepository) GetUserTokenByIDAndToken(ctx context.Context, userID, token string) (*domain.UserToken, error) {
+// GetUserTokenByID mocks base method.
+func (m *MockAuthRepository) GetUserTokenByID(ctx context.Context, tokenID uuid.UUID) (*domain.UserToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserTokenByIDAndToken", ctx, userID, token)
+	ret := m.ctrl.Call(m, "GetUserTokenByID", ctx, tokenID)
 	ret0, _ := ret[0].(*domain.UserToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserTokenByIDAndToken indicates an expected call of GetUserTokenByIDAndToken.
-func (mr *MockAuthRepositoryMockRecorder) GetUserTokenByIDAndToken(ctx, userID, token interface{}) *gomock.Call {
+// GetUserTokenByID indicates an expected call of GetUserTokenByID.
+func (mr *MockAuthRepositoryMockRecorder) GetUserTokenByID(ctx, tokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTokenByID", reflect.TypeOf((*MockAuthRepository)(nil).GetUserTokenByID), ctx, tokenID)
+}
+
+// GetUsersByRoleID mocks base method.
+func (m *MockAuthRepository) GetUsersByRoleID(ctx context.Context, roleID uuid.UUID, page, limit int) ([]domain.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByRoleID", ctx, roleID, page, limit)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUsersByRoleID indicates an expected call of GetUsersByRoleID.
+func (mr *MockAuthRepositoryMockRecorder) GetUsersByRoleID(ctx, roleID, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByRoleID", reflect.TypeOf((*MockAuthRepository)(nil).GetUsersByRoleID), ctx, roleID, page, limit)
+}
+
+// InvalidateEmailChangeTokens mocks base method.
+func (m *MockAuthRepository) InvalidateEmailChangeTokens(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateEmailChangeTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateEmailChangeTokens indicates an expected call of InvalidateEmailChangeTokens.
+func (mr *MockAuthRepositoryMockRecorder) InvalidateEmailChangeTokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateEmailChangeTokens", reflect.TypeOf((*MockAuthRepository)(nil).InvalidateEmailChangeTokens), ctx, userID)
+}
+
+// InvalidateEmailVerificationTokens mocks base method.
+func (m *MockAuthRepository) InvalidateEmailVerificationTokens(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateEmailVerificationTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateEmailVerificationTokens indicates an expected call of InvalidateEmailVerificationTokens.
+func (mr *MockAuthRepositoryMockRecorder) InvalidateEmailVerificationTokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateEmailVerificationTokens", reflect.TypeOf((*MockAuthRepository)(nil).InvalidateEmailVerificationTokens), ctx, userID)
+}
+
+// InvalidateOtherUserTokens mocks base method.
+func (m *MockAuthRepository) InvalidateOtherUserTokens(ctx context.Context, userID, keepFamilyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateOtherUserTokens", ctx, userID, keepFamilyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateOtherUserTokens indicates an expected call of InvalidateOtherUserTokens.
+func (mr *MockAuthRepositoryMockRecorder) InvalidateOtherUserTokens(ctx, userID, keepFamilyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateOtherUserTokens", reflect.TypeOf((*MockAuthRepository)(nil).InvalidateOtherUserTokens), ctx, userID, keepFamilyID)
+}
+
+// InvalidatePasswordResetTokens mocks base method.
+func (m *MockAuthRepository) InvalidatePasswordResetTokens(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidatePasswordResetTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidatePasswordResetTokens indicates an expected call of InvalidatePasswordResetTokens.
+func (mr *MockAuthRepositoryMockRecorder) InvalidatePasswordResetTokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidatePasswordResetTokens", reflect.TypeOf((*MockAuthRepository)(nil).InvalidatePasswordResetTokens), ctx, userID)
+}
+
+// InvalidateTokenFamily mocks base method.
+func (m *MockAuthRepository) InvalidateTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateTokenFamily", ctx, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateTokenFamily indicates an expected call of InvalidateTokenFamily.
+func (mr *MockAuthRepositoryMockRecorder) InvalidateTokenFamily(ctx, familyID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTokenByIDAndToken", reflect.TypeOf((*MockAuthRepository)(nil).GetUserTokenByIDAndToken), ctx, userID, token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateTokenFamily", reflect.TypeOf((*MockAuthRepository)(nil).InvalidateTokenFamily), ctx, familyID)
 }
 
 // InvalidateUserToken mocks base method.
-func (m *MockAuthRepository) InvalidateUserToken(ctx context.Context, userID, token string) error {
+func (m *MockAuthRepository) InvalidateUserToken(ctx context.Context, tokenID uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InvalidateUserToken", ctx, userID, token)
+	ret := m.ctrl.Call(m, "InvalidateUserToken", ctx, tokenID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InvalidateUserToken indicates an expected call of InvalidateUserToken.
-func (mr *MockAuthRepositoryMockRecorder) InvalidateUserToken(ctx, userID, token interface{}) *gomock.Call {
+func (mr *MockAuthRepositoryMockRecorder) InvalidateUserToken(ctx, tokenID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateUserToken", reflect.TypeOf((*MockAuthRepository)(nil).InvalidateUserToken), ctx, userID, token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateUserToken", reflect.TypeOf((*MockAuthRepository)(nil).InvalidateUserToken), ctx, tokenID)
 }
 
 // InvalidateUserTokens mocks base method.
-func (m *MockAuthRepository) InvalidateUserTokens(ctx context.Context, userID string) error {
+func (m *MockAuthRepository) InvalidateUserTokens(ctx context.Context, userID uuid.UUID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "InvalidateUserTokens", ctx, userID)
 	ret0, _ := ret[0].(error)
@@ -209,16 +633,564 @@ func (mr *MockAuthRepositoryMockRecorder) InvalidateUserTokens(ctx, userID inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateUserTokens", reflect.TypeOf((*MockAuthRepository)(nil).InvalidateUserTokens), ctx, userID)
 }
 
-// UpdateUser mocks base method.
-func (m *MockAuthRepository) UpdateUser(ctx context.Context, user *domain.User) error {
+// ListAuditLogs mocks base method.
+func (m *MockAuthRepository) ListAuditLogs(ctx context.Context, filter *domain.AuditLogFilter, page, limit int) ([]domain.AuditLog, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", ctx, user)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListAuditLogs", ctx, filter, page, limit)
+	ret0, _ := ret[0].([]domain.AuditLog)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockAuthRepositoryMockRecorder) UpdateUser(ctx, user interface{}) *gomock.Call {
+// ListAuditLogs indicates an expected call of ListAuditLogs.
+func (mr *MockAuthRepositoryMockRecorder) ListAuditLogs(ctx, filter, page, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockAuthRepository)(nil).UpdateUser), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditLogs", reflect.TypeOf((*MockAuthRepository)(nil).ListAuditLogs), ctx, filter, page, limit)
+}
+
+// ListInvitations mocks base method.
+func (m *MockAuthRepository) ListInvitations(ctx context.Context, status string, page, limit int) ([]domain.Invitation, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvitations", ctx, status, page, limit)
+	ret0, _ := ret[0].([]domain.Invitation)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListInvitations indicates an expected call of ListInvitations.
+func (mr *MockAuthRepositoryMockRecorder) ListInvitations(ctx, status, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvitations", reflect.TypeOf((*MockAuthRepository)(nil).ListInvitations), ctx, status, page, limit)
+}
+
+// ListPermissions mocks base method.
+func (m *MockAuthRepository) ListPermissions(ctx context.Context) ([]domain.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPermissions", ctx)
+	ret0, _ := ret[0].([]domain.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPermissions indicates an expected call of ListPermissions.
+func (mr *MockAuthRepositoryMockRecorder) ListPermissions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissions", reflect.TypeOf((*MockAuthRepository)(nil).ListPermissions), ctx)
+}
+
+// ListRoles mocks base method.
+func (m *MockAuthRepository) ListRoles(ctx context.Context) ([]domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoles", ctx)
+	ret0, _ := ret[0].([]domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockAuthRepositoryMockRecorder) ListRoles(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockAuthRepository)(nil).ListRoles), ctx)
+}
+
+// ListUserSessions mocks base method.
+func (m *MockAuthRepository) ListUserSessions(ctx context.Context, userID uuid.UUID) ([]domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserSessions", ctx, userID)
+	ret0, _ := ret[0].([]domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserSessions indicates an expected call of ListUserSessions.
+func (mr *MockAuthRepositoryMockRecorder) ListUserSessions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserSessions", reflect.TypeOf((*MockAuthRepository)(nil).ListUserSessions), ctx, userID)
+}
+
+// ListUsers mocks base method.
+func (m *MockAuthRepository) ListUsers(ctx context.Context, filter *domain.UserFilter, page, limit int) ([]domain.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, filter, page, limit)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockAuthRepositoryMockRecorder) ListUsers(ctx, filter, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockAuthRepository)(nil).ListUsers), ctx, filter, page, limit)
+}
+
+// LockUser mocks base method.
+func (m *MockAuthRepository) LockUser(ctx context.Context, id uuid.UUID, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUser", ctx, id, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockUser indicates an expected call of LockUser.
+func (mr *MockAuthRepositoryMockRecorder) LockUser(ctx, id, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUser", reflect.TypeOf((*MockAuthRepository)(nil).LockUser), ctx, id, until)
+}
+
+// MarkEmailChangeTokenUsed mocks base method.
+func (m *MockAuthRepository) MarkEmailChangeTokenUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailChangeTokenUsed", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkEmailChangeTokenUsed indicates an expected call of MarkEmailChangeTokenUsed.
+func (mr *MockAuthRepositoryMockRecorder) MarkEmailChangeTokenUsed(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailChangeTokenUsed", reflect.TypeOf((*MockAuthRepository)(nil).MarkEmailChangeTokenUsed), ctx, id)
+}
+
+// MarkEmailVerificationTokenUsed mocks base method.
+func (m *MockAuthRepository) MarkEmailVerificationTokenUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailVerificationTokenUsed", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkEmailVerificationTokenUsed indicates an expected call of MarkEmailVerificationTokenUsed.
+func (mr *MockAuthRepositoryMockRecorder) MarkEmailVerificationTokenUsed(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailVerificationTokenUsed", reflect.TypeOf((*MockAuthRepository)(nil).MarkEmailVerificationTokenUsed), ctx, id)
+}
+
+// MarkPasswordResetTokenUsed mocks base method.
+func (m *MockAuthRepository) MarkPasswordResetTokenUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPasswordResetTokenUsed", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkPasswordResetTokenUsed indicates an expected call of MarkPasswordResetTokenUsed.
+func (mr *MockAuthRepositoryMockRecorder) MarkPasswordResetTokenUsed(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPasswordResetTokenUsed", reflect.TypeOf((*MockAuthRepository)(nil).MarkPasswordResetTokenUsed), ctx, id)
+}
+
+// MarkUserEmailVerified mocks base method.
+func (m *MockAuthRepository) MarkUserEmailVerified(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUserEmailVerified", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUserEmailVerified indicates an expected call of MarkUserEmailVerified.
+func (mr *MockAuthRepositoryMockRecorder) MarkUserEmailVerified(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUserEmailVerified", reflect.TypeOf((*MockAuthRepository)(nil).MarkUserEmailVerified), ctx, id)
+}
+
+// MarkUserTokenUsed mocks base method.
+func (m *MockAuthRepository) MarkUserTokenUsed(ctx context.Context, tokenID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUserTokenUsed", ctx, tokenID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUserTokenUsed indicates an expected call of MarkUserTokenUsed.
+func (mr *MockAuthRepositoryMockRecorder) MarkUserTokenUsed(ctx, tokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUserTokenUsed", reflect.TypeOf((*MockAuthRepository)(nil).MarkUserTokenUsed), ctx, tokenID)
+}
+
+// ReplaceRecoveryCodes mocks base method.
+func (m *MockAuthRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRecoveryCodes", ctx, userID, codes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRecoveryCodes indicates an expected call of ReplaceRecoveryCodes.
+func (mr *MockAuthRepositoryMockRecorder) ReplaceRecoveryCodes(ctx, userID, codes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecoveryCodes", reflect.TypeOf((*MockAuthRepository)(nil).ReplaceRecoveryCodes), ctx, userID, codes)
+}
+
+// RestoreUser mocks base method.
+func (m *MockAuthRepository) RestoreUser(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockAuthRepositoryMockRecorder) RestoreUser(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockAuthRepository)(nil).RestoreUser), ctx, id)
+}
+
+// RevokeInvitation mocks base method.
+func (m *MockAuthRepository) RevokeInvitation(ctx context.Context, id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeInvitation", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeInvitation indicates an expected call of RevokeInvitation.
+func (mr *MockAuthRepositoryMockRecorder) RevokeInvitation(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeInvitation", reflect.TypeOf((*MockAuthRepository)(nil).RevokeInvitation), ctx, id)
+}
+
+// RevokePendingInvitations mocks base method.
+func (m *MockAuthRepository) RevokePendingInvitations(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokePendingInvitations", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokePendingInvitations indicates an expected call of RevokePendingInvitations.
+func (mr *MockAuthRepositoryMockRecorder) RevokePendingInvitations(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokePendingInvitations", reflect.TypeOf((*MockAuthRepository)(nil).RevokePendingInvitations), ctx, email)
+}
+
+// RevokeRolesFromUser mocks base method.
+func (m *MockAuthRepository) RevokeRolesFromUser(ctx context.Context, userID uuid.UUID, roleIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRolesFromUser", ctx, userID, roleIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRolesFromUser indicates an expected call of RevokeRolesFromUser.
+func (mr *MockAuthRepositoryMockRecorder) RevokeRolesFromUser(ctx, userID, roleIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRolesFromUser", reflect.TypeOf((*MockAuthRepository)(nil).RevokeRolesFromUser), ctx, userID, roleIDs)
+}
+
+// SetRolePermissions mocks base method.
+func (m *MockAuthRepository) SetRolePermissions(ctx context.Context, roleID uuid.UUID, permissionIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRolePermissions", ctx, roleID, permissionIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRolePermissions indicates an expected call of SetRolePermissions.
+func (mr *MockAuthRepositoryMockRecorder) SetRolePermissions(ctx, roleID, permissionIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRolePermissions", reflect.TypeOf((*MockAuthRepository)(nil).SetRolePermissions), ctx, roleID, permissionIDs)
+}
+
+// TouchUserIdentity mocks base method.
+func (m *MockAuthRepository) TouchUserIdentity(ctx context.Context, id uuid.UUID, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchUserIdentity", ctx, id, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchUserIdentity indicates an expected call of TouchUserIdentity.
+func (mr *MockAuthRepositoryMockRecorder) TouchUserIdentity(ctx, id, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchUserIdentity", reflect.TypeOf((*MockAuthRepository)(nil).TouchUserIdentity), ctx, id, email)
+}
+
+// TouchUserToken mocks base method.
+func (m *MockAuthRepository) TouchUserToken(ctx context.Context, tokenID uuid.UUID, client domain.ClientInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchUserToken", ctx, tokenID, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchUserToken indicates an expected call of TouchUserToken.
+func (mr *MockAuthRepositoryMockRecorder) TouchUserToken(ctx, tokenID, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchUserToken", reflect.TypeOf((*MockAuthRepository)(nil).TouchUserToken), ctx, tokenID, client)
+}
+
+// UnlockUser mocks base method.
+func (m *MockAuthRepository) UnlockUser(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockAuthRepositoryMockRecorder) UnlockUser(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockAuthRepository)(nil).UnlockUser), ctx, id)
+}
+
+// UpdateRole mocks base method.
+func (m *MockAuthRepository) UpdateRole(ctx context.Context, role *domain.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockAuthRepositoryMockRecorder) UpdateRole(ctx, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockAuthRepository)(nil).UpdateRole), ctx, role)
+}
+
+// UpdateUser mocks base method.
+func (m *MockAuthRepository) UpdateUser(ctx context.Context, user *domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockAuthRepositoryMockRecorder) UpdateUser(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockAuthRepository)(nil).UpdateUser), ctx, user)
+}
+
+// UpdateUserEmail mocks base method.
+func (m *MockAuthRepository) UpdateUserEmail(ctx context.Context, id uuid.UUID, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserEmail", ctx, id, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserEmail indicates an expected call of UpdateUserEmail.
+func (mr *MockAuthRepositoryMockRecorder) UpdateUserEmail(ctx, id, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserEmail", reflect.TypeOf((*MockAuthRepository)(nil).UpdateUserEmail), ctx, id, email)
+}
+
+// UpdateUserPassword mocks base method.
+func (m *MockAuthRepository) UpdateUserPassword(ctx context.Context, id uuid.UUID, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", ctx, id, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockAuthRepositoryMockRecorder) UpdateUserPassword(ctx, id, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockAuthRepository)(nil).UpdateUserPassword), ctx, id, password)
+}
+
+// UpdateUserTokensAbility mocks base method.
+func (m *MockAuthRepository) UpdateUserTokensAbility(ctx context.Context, userID uuid.UUID, ability []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserTokensAbility", ctx, userID, ability)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserTokensAbility indicates an expected call of UpdateUserTokensAbility.
+func (mr *MockAuthRepositoryMockRecorder) UpdateUserTokensAbility(ctx, userID, ability interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserTokensAbility", reflect.TypeOf((*MockAuthRepository)(nil).UpdateUserTokensAbility), ctx, userID, ability)
+}
+
+// UpsertUserMFA mocks base method.
+func (m *MockAuthRepository) UpsertUserMFA(ctx context.Context, mfa *domain.UserMFA) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserMFA", ctx, mfa)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertUserMFA indicates an expected call of UpsertUserMFA.
+func (mr *MockAuthRepositoryMockRecorder) UpsertUserMFA(ctx, mfa interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserMFA", reflect.TypeOf((*MockAuthRepository)(nil).UpsertUserMFA), ctx, mfa)
+}
+
+// UseLoginRecoveryCode mocks base method.
+func (m *MockAuthRepository) UseLoginRecoveryCode(ctx context.Context, tokenID, userID uuid.UUID, code string) (bool, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseLoginRecoveryCode", ctx, tokenID, userID, code)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UseLoginRecoveryCode indicates an expected call of UseLoginRecoveryCode.
+func (mr *MockAuthRepositoryMockRecorder) UseLoginRecoveryCode(ctx, tokenID, userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseLoginRecoveryCode", reflect.TypeOf((*MockAuthRepository)(nil).UseLoginRecoveryCode), ctx, tokenID, userID, code)
+}
+
+// MockLoginAttemptRepository is a mock of LoginAttemptRepository interface.
+type MockLoginAttemptRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAttemptRepositoryMockRecorder
+}
+
+// MockLoginAttemptRepositoryMockRecorder is the mock recorder for MockLoginAttemptRepository.
+type MockLoginAttemptRepositoryMockRecorder struct {
+	mock *MockLoginAttemptRepository
+}
+
+// NewMockLoginAttemptRepository creates a new mock instance.
+func NewMockLoginAttemptRepository(ctrl *gomock.Controller) *MockLoginAttemptRepository {
+	mock := &MockLoginAttemptRepository{ctrl: ctrl}
+	mock.recorder = &MockLoginAttemptRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAttemptRepository) EXPECT() *MockLoginAttemptRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetDelay mocks base method.
+func (m *MockLoginAttemptRepository) GetDelay(ctx context.Context, key string) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelay", ctx, key)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelay indicates an expected call of GetDelay.
+func (mr *MockLoginAttemptRepositoryMockRecorder) GetDelay(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelay", reflect.TypeOf((*MockLoginAttemptRepository)(nil).GetDelay), ctx, key)
+}
+
+// GetFailures mocks base method.
+func (m *MockLoginAttemptRepository) GetFailures(ctx context.Context, key string) (int64, time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFailures", ctx, key)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(time.Duration)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFailures indicates an expected call of GetFailures.
+func (mr *MockLoginAttemptRepositoryMockRecorder) GetFailures(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFailures", reflect.TypeOf((*MockLoginAttemptRepository)(nil).GetFailures), ctx, key)
+}
+
+// IncrementFailures mocks base method.
+func (m *MockLoginAttemptRepository) IncrementFailures(ctx context.Context, key string, window time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementFailures", ctx, key, window)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementFailures indicates an expected call of IncrementFailures.
+func (mr *MockLoginAttemptRepositoryMockRecorder) IncrementFailures(ctx, key, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementFailures", reflect.TypeOf((*MockLoginAttemptRepository)(nil).IncrementFailures), ctx, key, window)
+}
+
+// ResetFailures mocks base method.
+func (m *MockLoginAttemptRepository) ResetFailures(ctx context.Context, keys ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResetFailures", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetFailures indicates an expected call of ResetFailures.
+func (mr *MockLoginAttemptRepositoryMockRecorder) ResetFailures(ctx interface{}, keys ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetFailures", reflect.TypeOf((*MockLoginAttemptRepository)(nil).ResetFailures), varargs...)
+}
+
+// SetDelay mocks base method.
+func (m *MockLoginAttemptRepository) SetDelay(ctx context.Context, key string, delay time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDelay", ctx, key, delay)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDelay indicates an expected call of SetDelay.
+func (mr *MockLoginAttemptRepositoryMockRecorder) SetDelay(ctx, key, delay interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelay", reflect.TypeOf((*MockLoginAttemptRepository)(nil).SetDelay), ctx, key, delay)
+}
+
+// MockOIDCStateRepository is a mock of OIDCStateRepository interface.
+type MockOIDCStateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCStateRepositoryMockRecorder
+}
+
+// MockOIDCStateRepositoryMockRecorder is the mock recorder for MockOIDCStateRepository.
+type MockOIDCStateRepositoryMockRecorder struct {
+	mock *MockOIDCStateRepository
+}
+
+// NewMockOIDCStateRepository creates a new mock instance.
+func NewMockOIDCStateRepository(ctrl *gomock.Controller) *MockOIDCStateRepository {
+	mock := &MockOIDCStateRepository{ctrl: ctrl}
+	mock.recorder = &MockOIDCStateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCStateRepository) EXPECT() *MockOIDCStateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConsumeState mocks base method.
+func (m *MockOIDCStateRepository) ConsumeState(ctx context.Context, state string) (*domain.OIDCState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeState", ctx, state)
+	ret0, _ := ret[0].(*domain.OIDCState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeState indicates an expected call of ConsumeState.
+func (mr *MockOIDCStateRepositoryMockRecorder) ConsumeState(ctx, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeState", reflect.TypeOf((*MockOIDCStateRepository)(nil).ConsumeState), ctx, state)
+}
+
+// SaveState mocks base method.
+func (m *MockOIDCStateRepository) SaveState(ctx context.Context, state string, value *domain.OIDCState, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveState", ctx, state, value, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveState indicates an expected call of SaveState.
+func (mr *MockOIDCStateRepositoryMockRecorder) SaveState(ctx, state, value, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveState", reflect.TypeOf((*MockOIDCStateRepository)(nil).SaveState), ctx, state, value, ttl)
 }
@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	domain "github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	uuid "github.com/google/uuid"
 )
 
 // MockAuthUsecase is a mock of AuthUsecase interface.
@@ -35,8 +36,23 @@ func (m *MockAuthUsecase) EXPECT() *MockAuthUsecaseMockRecorder {
 	return m.recorder
 }
 
+// AcceptInvitation mocks base method.
+func (m *MockAuthUsecase) AcceptInvitation(ctx context.Context, req *domain.AcceptInvitationRequest) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, req)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockAuthUsecaseMockRecorder) AcceptInvitation(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockAuthUsecase)(nil).AcceptInvitation), ctx, req)
+}
+
 // AssignRoles mocks base method.
-func (m *MockAuthUsecase) AssignRoles(ctx context.Context, userID string, roleNames []string) error {
+func (m *MockAuthUsecase) AssignRoles(ctx context.Context, userID uuid.UUID, roleNames []string) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AssignRoles", ctx, userID, roleNames)
 	ret0, _ := ret[0].(error)
@@ -49,23 +65,227 @@ func (mr *MockAuthUsecaseMockRecorder) AssignRoles(ctx, userID, roleNames interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRoles", reflect.TypeOf((*MockAuthUsecase)(nil).AssignRoles), ctx, userID, roleNames)
 }
 
+// ChangePassword mocks base method.
+func (m *MockAuthUsecase) ChangePassword(ctx context.Context, userID, currentTokenID uuid.UUID, req *domain.ChangePasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, currentTokenID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockAuthUsecaseMockRecorder) ChangePassword(ctx, userID, currentTokenID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAuthUsecase)(nil).ChangePassword), ctx, userID, currentTokenID, req)
+}
+
+// ConfirmEmailChange mocks base method.
+func (m *MockAuthUsecase) ConfirmEmailChange(ctx context.Context, req *domain.VerifyEmailRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmailChange", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmEmailChange indicates an expected call of ConfirmEmailChange.
+func (mr *MockAuthUsecaseMockRecorder) ConfirmEmailChange(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmailChange", reflect.TypeOf((*MockAuthUsecase)(nil).ConfirmEmailChange), ctx, req)
+}
+
+// ConfirmMFA mocks base method.
+func (m *MockAuthUsecase) ConfirmMFA(ctx context.Context, userID uuid.UUID, code string) (*domain.RecoveryCodesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmMFA", ctx, userID, code)
+	ret0, _ := ret[0].(*domain.RecoveryCodesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmMFA indicates an expected call of ConfirmMFA.
+func (mr *MockAuthUsecaseMockRecorder) ConfirmMFA(ctx, userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmMFA", reflect.TypeOf((*MockAuthUsecase)(nil).ConfirmMFA), ctx, userID, code)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockAuthUsecase) CreateInvitation(ctx context.Context, actorID uuid.UUID, req *domain.CreateInvitationRequest) (*domain.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", ctx, actorID, req)
+	ret0, _ := ret[0].(*domain.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockAuthUsecaseMockRecorder) CreateInvitation(ctx, actorID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockAuthUsecase)(nil).CreateInvitation), ctx, actorID, req)
+}
+
+// CreateRole mocks base method.
+func (m *MockAuthUsecase) CreateRole(ctx context.Context, req *domain.RoleRequest) (*domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", ctx, req)
+	ret0, _ := ret[0].(*domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockAuthUsecaseMockRecorder) CreateRole(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockAuthUsecase)(nil).CreateRole), ctx, req)
+}
+
+// DeleteRole mocks base method.
+func (m *MockAuthUsecase) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockAuthUsecaseMockRecorder) DeleteRole(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockAuthUsecase)(nil).DeleteRole), ctx, id)
+}
+
+// DeleteUser mocks base method.
+func (m *MockAuthUsecase) DeleteUser(ctx context.Context, actorID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, actorID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockAuthUsecaseMockRecorder) DeleteUser(ctx, actorID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockAuthUsecase)(nil).DeleteUser), ctx, actorID, userID)
+}
+
+// DisableMFA mocks base method.
+func (m *MockAuthUsecase) DisableMFA(ctx context.Context, userID uuid.UUID, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableMFA", ctx, userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableMFA indicates an expected call of DisableMFA.
+func (mr *MockAuthUsecaseMockRecorder) DisableMFA(ctx, userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableMFA", reflect.TypeOf((*MockAuthUsecase)(nil).DisableMFA), ctx, userID, code)
+}
+
+// EnrollLoginMFA mocks base method.
+func (m *MockAuthUsecase) EnrollLoginMFA(ctx context.Context, mfaToken string) (*domain.MFAEnrollmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollLoginMFA", ctx, mfaToken)
+	ret0, _ := ret[0].(*domain.MFAEnrollmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollLoginMFA indicates an expected call of EnrollLoginMFA.
+func (mr *MockAuthUsecaseMockRecorder) EnrollLoginMFA(ctx, mfaToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollLoginMFA", reflect.TypeOf((*MockAuthUsecase)(nil).EnrollLoginMFA), ctx, mfaToken)
+}
+
+// EnrollMFA mocks base method.
+func (m *MockAuthUsecase) EnrollMFA(ctx context.Context, userID uuid.UUID) (*domain.MFAEnrollmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollMFA", ctx, userID)
+	ret0, _ := ret[0].(*domain.MFAEnrollmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollMFA indicates an expected call of EnrollMFA.
+func (mr *MockAuthUsecaseMockRecorder) EnrollMFA(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollMFA", reflect.TypeOf((*MockAuthUsecase)(nil).EnrollMFA), ctx, userID)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockAuthUsecase) ForgotPassword(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockAuthUsecaseMockRecorder) ForgotPassword(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockAuthUsecase)(nil).ForgotPassword), ctx, email)
+}
+
+// GetRoleByID mocks base method.
+func (m *MockAuthUsecase) GetRoleByID(ctx context.Context, id uuid.UUID) (*domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoleByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoleByID indicates an expected call of GetRoleByID.
+func (mr *MockAuthUsecaseMockRecorder) GetRoleByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleByID", reflect.TypeOf((*MockAuthUsecase)(nil).GetRoleByID), ctx, id)
+}
+
+// GetRolePermissions mocks base method.
+func (m *MockAuthUsecase) GetRolePermissions(ctx context.Context, roleID uuid.UUID) ([]domain.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolePermissions", ctx, roleID)
+	ret0, _ := ret[0].([]domain.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRolePermissions indicates an expected call of GetRolePermissions.
+func (mr *MockAuthUsecaseMockRecorder) GetRolePermissions(ctx, roleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolePermissions", reflect.TypeOf((*MockAuthUsecase)(nil).GetRolePermissions), ctx, roleID)
+}
+
+// GetUser mocks base method.
+func (m *MockAuthUsecase) GetUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userID)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockAuthUsecaseMockRecorder) GetUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockAuthUsecase)(nil).GetUser), ctx, userID)
+}
+
 // GetUserByID mocks base method.
-func (m *MockAuthUsecase) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
+func (m *MockAuthUsecase) GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, userID)
 	ret0, _ := ret[0].(*domain.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserByID indicates an expected call of GetUserByID.
-func (mr *MockAuthUsecaseMockRecorder) GetUserByID(ctx, id interface{}) *gomock.Call {
+func (mr *MockAuthUsecaseMockRecorder) GetUserByID(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockAuthUsecase)(nil).GetUserByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockAuthUsecase)(nil).GetUserByID), ctx, userID)
 }
 
 // GetUserRoles mocks base method.
-func (m *MockAuthUsecase) GetUserRoles(ctx context.Context, userID string) ([]domain.Role, error) {
+func (m *MockAuthUsecase) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]domain.Role, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUserRoles", ctx, userID)
 	ret0, _ := ret[0].([]domain.Role)
@@ -79,6 +299,158 @@ func (mr *MockAuthUsecaseMockRecorder) GetUserRoles(ctx, userID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoles", reflect.TypeOf((*MockAuthUsecase)(nil).GetUserRoles), ctx, userID)
 }
 
+// GetUserTokenByID mocks base method.
+func (m *MockAuthUsecase) GetUserTokenByID(ctx context.Context, tokenID uuid.UUID) (*domain.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTokenByID", ctx, tokenID)
+	ret0, _ := ret[0].(*domain.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTokenByID indicates an expected call of GetUserTokenByID.
+func (mr *MockAuthUsecaseMockRecorder) GetUserTokenByID(ctx, tokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTokenByID", reflect.TypeOf((*MockAuthUsecase)(nil).GetUserTokenByID), ctx, tokenID)
+}
+
+// GetUsersByRole mocks base method.
+func (m *MockAuthUsecase) GetUsersByRole(ctx context.Context, roleID uuid.UUID, page, limit int) ([]domain.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByRole", ctx, roleID, page, limit)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUsersByRole indicates an expected call of GetUsersByRole.
+func (mr *MockAuthUsecaseMockRecorder) GetUsersByRole(ctx, roleID, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByRole", reflect.TypeOf((*MockAuthUsecase)(nil).GetUsersByRole), ctx, roleID, page, limit)
+}
+
+// InvalidateUserToken mocks base method.
+func (m *MockAuthUsecase) InvalidateUserToken(ctx context.Context, tokenID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateUserToken", ctx, tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateUserToken indicates an expected call of InvalidateUserToken.
+func (mr *MockAuthUsecaseMockRecorder) InvalidateUserToken(ctx, tokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateUserToken", reflect.TypeOf((*MockAuthUsecase)(nil).InvalidateUserToken), ctx, tokenID)
+}
+
+// InvalidateUserTokens mocks base method.
+func (m *MockAuthUsecase) InvalidateUserTokens(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateUserTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateUserTokens indicates an expected call of InvalidateUserTokens.
+func (mr *MockAuthUsecaseMockRecorder) InvalidateUserTokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateUserTokens", reflect.TypeOf((*MockAuthUsecase)(nil).InvalidateUserTokens), ctx, userID)
+}
+
+// ListAuditLogs mocks base method.
+func (m *MockAuthUsecase) ListAuditLogs(ctx context.Context, filter *domain.AuditLogFilter, page, limit int) ([]domain.AuditLog, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditLogs", ctx, filter, page, limit)
+	ret0, _ := ret[0].([]domain.AuditLog)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAuditLogs indicates an expected call of ListAuditLogs.
+func (mr *MockAuthUsecaseMockRecorder) ListAuditLogs(ctx, filter, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditLogs", reflect.TypeOf((*MockAuthUsecase)(nil).ListAuditLogs), ctx, filter, page, limit)
+}
+
+// ListInvitations mocks base method.
+func (m *MockAuthUsecase) ListInvitations(ctx context.Context, status string, page, limit int) ([]domain.Invitation, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvitations", ctx, status, page, limit)
+	ret0, _ := ret[0].([]domain.Invitation)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListInvitations indicates an expected call of ListInvitations.
+func (mr *MockAuthUsecaseMockRecorder) ListInvitations(ctx, status, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvitations", reflect.TypeOf((*MockAuthUsecase)(nil).ListInvitations), ctx, status, page, limit)
+}
+
+// ListPermissions mocks base method.
+func (m *MockAuthUsecase) ListPermissions(ctx context.Context) ([]domain.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPermissions", ctx)
+	ret0, _ := ret[0].([]domain.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPermissions indicates an expected call of ListPermissions.
+func (mr *MockAuthUsecaseMockRecorder) ListPermissions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissions", reflect.TypeOf((*MockAuthUsecase)(nil).ListPermissions), ctx)
+}
+
+// ListRoles mocks base method.
+func (m *MockAuthUsecase) ListRoles(ctx context.Context) ([]domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoles", ctx)
+	ret0, _ := ret[0].([]domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockAuthUsecaseMockRecorder) ListRoles(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockAuthUsecase)(nil).ListRoles), ctx)
+}
+
+// ListSessions mocks base method.
+func (m *MockAuthUsecase) ListSessions(ctx context.Context, userID, currentTokenID uuid.UUID) ([]domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, userID, currentTokenID)
+	ret0, _ := ret[0].([]domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockAuthUsecaseMockRecorder) ListSessions(ctx, userID, currentTokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockAuthUsecase)(nil).ListSessions), ctx, userID, currentTokenID)
+}
+
+// ListUsers mocks base method.
+func (m *MockAuthUsecase) ListUsers(ctx context.Context, filter *domain.UserFilter, page, limit int) ([]domain.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, filter, page, limit)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockAuthUsecaseMockRecorder) ListUsers(ctx, filter, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockAuthUsecase)(nil).ListUsers), ctx, filter, page, limit)
+}
+
 // Login mocks base method.
 func (m *MockAuthUsecase) Login(ctx context.Context, req *domain.LoginRequest) (*domain.LoginResponse, error) {
 	m.ctrl.T.Helper()
@@ -95,17 +467,76 @@ func (mr *MockAuthUsecaseMockRecorder) Login(ctx, req interface{}) *gomock.Call
 }
 
 // Logout mocks base method.
-func (m *MockAuthUsecase) Logout(ctx context.Context, userID, token string) error {
+func (m *MockAuthUsecase) Logout(ctx context.Context, tokenID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Logout", ctx, userID, token)
+	ret := m.ctrl.Call(m, "Logout", ctx, tokenID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Logout indicates an expected call of Logout.
-func (mr *MockAuthUsecaseMockRecorder) Logout(ctx, userID, token interface{}) *gomock.Call {
+func (mr *MockAuthUsecaseMockRecorder) Logout(ctx, tokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthUsecase)(nil).Logout), ctx, tokenID)
+}
+
+// OIDCLogin mocks base method.
+func (m *MockAuthUsecase) OIDCLogin(ctx context.Context, req *domain.OIDCCallbackRequest) (*domain.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OIDCLogin", ctx, req)
+	ret0, _ := ret[0].(*domain.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OIDCLogin indicates an expected call of OIDCLogin.
+func (mr *MockAuthUsecaseMockRecorder) OIDCLogin(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OIDCLogin", reflect.TypeOf((*MockAuthUsecase)(nil).OIDCLogin), ctx, req)
+}
+
+// ReactivateUser mocks base method.
+func (m *MockAuthUsecase) ReactivateUser(ctx context.Context, actorID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateUser", ctx, actorID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReactivateUser indicates an expected call of ReactivateUser.
+func (mr *MockAuthUsecaseMockRecorder) ReactivateUser(ctx, actorID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateUser", reflect.TypeOf((*MockAuthUsecase)(nil).ReactivateUser), ctx, actorID, userID)
+}
+
+// RefreshToken mocks base method.
+func (m *MockAuthUsecase) RefreshToken(ctx context.Context, req *domain.RefreshTokenRequest) (*domain.TokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", ctx, req)
+	ret0, _ := ret[0].(*domain.TokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockAuthUsecaseMockRecorder) RefreshToken(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockAuthUsecase)(nil).RefreshToken), ctx, req)
+}
+
+// RegenerateRecoveryCodes mocks base method.
+func (m *MockAuthUsecase) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (*domain.RecoveryCodesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateRecoveryCodes", ctx, userID, code)
+	ret0, _ := ret[0].(*domain.RecoveryCodesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegenerateRecoveryCodes indicates an expected call of RegenerateRecoveryCodes.
+func (mr *MockAuthUsecaseMockRecorder) RegenerateRecoveryCodes(ctx, userID, code interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthUsecase)(nil).Logout), ctx, userID, token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateRecoveryCodes", reflect.TypeOf((*MockAuthUsecase)(nil).RegenerateRecoveryCodes), ctx, userID, code)
 }
 
 // Register mocks base method.
@@ -123,16 +554,257 @@ func (mr *MockAuthUsecaseMockRecorder) Register(ctx, req interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAuthUsecase)(nil).Register), ctx, req)
 }
 
-// UpdateUser mocks base method.
-func (m *MockAuthUsecase) UpdateUser(ctx context.Context, user *domain.User) error {
+// RequestEmailChange mocks base method.
+func (m *MockAuthUsecase) RequestEmailChange(ctx context.Context, userID uuid.UUID, req *domain.ChangeEmailRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestEmailChange", ctx, userID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestEmailChange indicates an expected call of RequestEmailChange.
+func (mr *MockAuthUsecaseMockRecorder) RequestEmailChange(ctx, userID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestEmailChange", reflect.TypeOf((*MockAuthUsecase)(nil).RequestEmailChange), ctx, userID, req)
+}
+
+// ResendVerificationEmail mocks base method.
+func (m *MockAuthUsecase) ResendVerificationEmail(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerificationEmail", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerificationEmail indicates an expected call of ResendVerificationEmail.
+func (mr *MockAuthUsecaseMockRecorder) ResendVerificationEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerificationEmail", reflect.TypeOf((*MockAuthUsecase)(nil).ResendVerificationEmail), ctx, email)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAuthUsecase) ResetPassword(ctx context.Context, req *domain.ResetPasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAuthUsecaseMockRecorder) ResetPassword(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAuthUsecase)(nil).ResetPassword), ctx, req)
+}
+
+// RestoreUser mocks base method.
+func (m *MockAuthUsecase) RestoreUser(ctx context.Context, actorID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, actorID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockAuthUsecaseMockRecorder) RestoreUser(ctx, actorID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockAuthUsecase)(nil).RestoreUser), ctx, actorID, userID)
+}
+
+// RevokeAllSessions mocks base method.
+func (m *MockAuthUsecase) RevokeAllSessions(ctx context.Context, actorID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllSessions", ctx, actorID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllSessions indicates an expected call of RevokeAllSessions.
+func (mr *MockAuthUsecaseMockRecorder) RevokeAllSessions(ctx, actorID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllSessions", reflect.TypeOf((*MockAuthUsecase)(nil).RevokeAllSessions), ctx, actorID, userID)
+}
+
+// RevokeInvitation mocks base method.
+func (m *MockAuthUsecase) RevokeInvitation(ctx context.Context, actorID, invitationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeInvitation", ctx, actorID, invitationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeInvitation indicates an expected call of RevokeInvitation.
+func (mr *MockAuthUsecaseMockRecorder) RevokeInvitation(ctx, actorID, invitationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeInvitation", reflect.TypeOf((*MockAuthUsecase)(nil).RevokeInvitation), ctx, actorID, invitationID)
+}
+
+// RevokeRoles mocks base method.
+func (m *MockAuthUsecase) RevokeRoles(ctx context.Context, userID uuid.UUID, roleNames []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRoles", ctx, userID, roleNames)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRoles indicates an expected call of RevokeRoles.
+func (mr *MockAuthUsecaseMockRecorder) RevokeRoles(ctx, userID, roleNames interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRoles", reflect.TypeOf((*MockAuthUsecase)(nil).RevokeRoles), ctx, userID, roleNames)
+}
+
+// RevokeSession mocks base method.
+func (m *MockAuthUsecase) RevokeSession(ctx context.Context, actorID, userID, sessionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, actorID, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockAuthUsecaseMockRecorder) RevokeSession(ctx, actorID, userID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAuthUsecase)(nil).RevokeSession), ctx, actorID, userID, sessionID)
+}
+
+// SetRolePermissions mocks base method.
+func (m *MockAuthUsecase) SetRolePermissions(ctx context.Context, roleID uuid.UUID, permissionNames []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRolePermissions", ctx, roleID, permissionNames)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRolePermissions indicates an expected call of SetRolePermissions.
+func (mr *MockAuthUsecaseMockRecorder) SetRolePermissions(ctx, roleID, permissionNames interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRolePermissions", reflect.TypeOf((*MockAuthUsecase)(nil).SetRolePermissions), ctx, roleID, permissionNames)
+}
+
+// StartOIDCLogin mocks base method.
+func (m *MockAuthUsecase) StartOIDCLogin(ctx context.Context, provider string) (*domain.OIDCAuthorizationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartOIDCLogin", ctx, provider)
+	ret0, _ := ret[0].(*domain.OIDCAuthorizationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartOIDCLogin indicates an expected call of StartOIDCLogin.
+func (mr *MockAuthUsecaseMockRecorder) StartOIDCLogin(ctx, provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartOIDCLogin", reflect.TypeOf((*MockAuthUsecase)(nil).StartOIDCLogin), ctx, provider)
+}
+
+// SuspendUser mocks base method.
+func (m *MockAuthUsecase) SuspendUser(ctx context.Context, actorID, userID uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", ctx, user)
+	ret := m.ctrl.Call(m, "SuspendUser", ctx, actorID, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockAuthUsecaseMockRecorder) UpdateUser(ctx, user interface{}) *gomock.Call {
+// SuspendUser indicates an expected call of SuspendUser.
+func (mr *MockAuthUsecaseMockRecorder) SuspendUser(ctx, actorID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendUser", reflect.TypeOf((*MockAuthUsecase)(nil).SuspendUser), ctx, actorID, userID)
+}
+
+// TouchUserToken mocks base method.
+func (m *MockAuthUsecase) TouchUserToken(ctx context.Context, token *domain.UserToken, client domain.ClientInfo) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TouchUserToken", ctx, token, client)
+}
+
+// TouchUserToken indicates an expected call of TouchUserToken.
+func (mr *MockAuthUsecaseMockRecorder) TouchUserToken(ctx, token, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchUserToken", reflect.TypeOf((*MockAuthUsecase)(nil).TouchUserToken), ctx, token, client)
+}
+
+// UnlockUser mocks base method.
+func (m *MockAuthUsecase) UnlockUser(ctx context.Context, actorID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", ctx, actorID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockAuthUsecaseMockRecorder) UnlockUser(ctx, actorID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockAuthUsecase)(nil).UnlockUser), ctx, actorID, userID)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockAuthUsecase) UpdateProfile(ctx context.Context, userID uuid.UUID, req *domain.UpdateUserRequest) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, userID, req)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockAuthUsecaseMockRecorder) UpdateProfile(ctx, userID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockAuthUsecase)(nil).UpdateProfile), ctx, userID, req)
+}
+
+// UpdateRole mocks base method.
+func (m *MockAuthUsecase) UpdateRole(ctx context.Context, id uuid.UUID, req *domain.RoleRequest) (*domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, id, req)
+	ret0, _ := ret[0].(*domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockAuthUsecaseMockRecorder) UpdateRole(ctx, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockAuthUsecase)(nil).UpdateRole), ctx, id, req)
+}
+
+// ValidateUserToken mocks base method.
+func (m *MockAuthUsecase) ValidateUserToken(ctx context.Context, tokenID, token string) (*domain.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateUserToken", ctx, tokenID, token)
+	ret0, _ := ret[0].(*domain.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateUserToken indicates an expected call of ValidateUserToken.
+func (mr *MockAuthUsecaseMockRecorder) ValidateUserToken(ctx, tokenID, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateUserToken", reflect.TypeOf((*MockAuthUsecase)(nil).ValidateUserToken), ctx, tokenID, token)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockAuthUsecase) VerifyEmail(ctx context.Context, req *domain.VerifyEmailRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockAuthUsecaseMockRecorder) VerifyEmail(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockAuthUsecase)(nil).VerifyEmail), ctx, req)
+}
+
+// VerifyLoginMFA mocks base method.
+func (m *MockAuthUsecase) VerifyLoginMFA(ctx context.Context, req *domain.MFALoginRequest) (*domain.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyLoginMFA", ctx, req)
+	ret0, _ := ret[0].(*domain.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyLoginMFA indicates an expected call of VerifyLoginMFA.
+func (mr *MockAuthUsecaseMockRecorder) VerifyLoginMFA(ctx, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockAuthUsecase)(nil).UpdateUser), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyLoginMFA", reflect.TypeOf((*MockAuthUsecase)(nil).VerifyLoginMFA), ctx, req)
 }
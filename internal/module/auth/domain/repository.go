@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,18 +13,111 @@ type AuthRepository interface {
 	CreateUser(ctx context.Context, user *User) error
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (*User, error)
+	GetUserByIDWithDeleted(ctx context.Context, id uuid.UUID) (*User, error)
+	ListUsers(ctx context.Context, filter *UserFilter, page, limit int) ([]User, int64, error)
 	UpdateUser(ctx context.Context, user *User) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	RestoreUser(ctx context.Context, id uuid.UUID) error
+	MarkUserEmailVerified(ctx context.Context, id uuid.UUID) error
+	UpdateUserPassword(ctx context.Context, id uuid.UUID, password string) error
+	UpdateUserEmail(ctx context.Context, id uuid.UUID, email string) error
+	LockUser(ctx context.Context, id uuid.UUID, until time.Time) error
+	UnlockUser(ctx context.Context, id uuid.UUID) error
 
 	// Role operations
+	ListRoles(ctx context.Context) ([]Role, error)
+	CreateRole(ctx context.Context, role *Role) error
+	UpdateRole(ctx context.Context, role *Role) error
+	DeleteRole(ctx context.Context, id uuid.UUID) error
 	GetRolesByNames(ctx context.Context, names []string) ([]Role, error)
 	GetRoleByID(ctx context.Context, id uuid.UUID) (*Role, error)
 	AssignRolesToUser(ctx context.Context, userID uuid.UUID, roleIDs []uuid.UUID) error
+	RevokeRolesFromUser(ctx context.Context, userID uuid.UUID, roleIDs []uuid.UUID) error
 	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]Role, error)
+	GetUsersByRoleID(ctx context.Context, roleID uuid.UUID, page, limit int) ([]User, int64, error)
+	GetUserIDsByRoleID(ctx context.Context, roleID uuid.UUID) ([]uuid.UUID, error)
+
+	// Permission operations
+	ListPermissions(ctx context.Context) ([]Permission, error)
+	GetPermissionsByNames(ctx context.Context, names []string) ([]Permission, error)
+	GetRolePermissions(ctx context.Context, roleID uuid.UUID) ([]Permission, error)
+	SetRolePermissions(ctx context.Context, roleID uuid.UUID, permissionIDs []uuid.UUID) error
+	GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error)
 
 	// Token management
 	CreateUserToken(ctx context.Context, token *UserToken) (*UserToken, error)
 	GetUserTokenByID(ctx context.Context, tokenID uuid.UUID) (*UserToken, error)
 	InvalidateUserToken(ctx context.Context, tokenID uuid.UUID) error
 	InvalidateUserTokens(ctx context.Context, userID uuid.UUID) error
+	InvalidateOtherUserTokens(ctx context.Context, userID, keepFamilyID uuid.UUID) error
+	InvalidateTokenFamily(ctx context.Context, familyID uuid.UUID) error
+	MarkUserTokenUsed(ctx context.Context, tokenID uuid.UUID) (bool, error)
+	UpdateUserTokensAbility(ctx context.Context, userID uuid.UUID, ability []string) error
+	TouchUserToken(ctx context.Context, tokenID uuid.UUID, client ClientInfo) error
+
+	// Sessions
+	ListUserSessions(ctx context.Context, userID uuid.UUID) ([]Session, error)
+
+	// Email verification
+	CreateEmailVerificationToken(ctx context.Context, token *EmailVerificationToken) error
+	GetEmailVerificationToken(ctx context.Context, id uuid.UUID) (*EmailVerificationToken, error)
+	MarkEmailVerificationTokenUsed(ctx context.Context, id uuid.UUID) (bool, error)
+	InvalidateEmailVerificationTokens(ctx context.Context, userID uuid.UUID) error
+
+	// Password reset
+	CreatePasswordResetToken(ctx context.Context, token *PasswordResetToken) error
+	GetPasswordResetToken(ctx context.Context, id uuid.UUID) (*PasswordResetToken, error)
+	MarkPasswordResetTokenUsed(ctx context.Context, id uuid.UUID) (bool, error)
+	InvalidatePasswordResetTokens(ctx context.Context, userID uuid.UUID) error
+
+	// Email change
+	CreateEmailChangeToken(ctx context.Context, token *EmailChangeToken) error
+	GetEmailChangeToken(ctx context.Context, id uuid.UUID) (*EmailChangeToken, error)
+	MarkEmailChangeTokenUsed(ctx context.Context, id uuid.UUID) (bool, error)
+	InvalidateEmailChangeTokens(ctx context.Context, userID uuid.UUID) error
+
+	// Invitations
+	CreateInvitation(ctx context.Context, invitation *Invitation, roleIDs []uuid.UUID) error
+	GetInvitationByID(ctx context.Context, id uuid.UUID) (*Invitation, error)
+	ListInvitations(ctx context.Context, status string, page, limit int) ([]Invitation, int64, error)
+	RevokeInvitation(ctx context.Context, id uuid.UUID) (bool, error)
+	RevokePendingInvitations(ctx context.Context, email string) error
+	AcceptInvitation(ctx context.Context, id uuid.UUID, user *User, roleIDs []uuid.UUID) (bool, error)
+
+	// External identities
+	GetUserIdentity(ctx context.Context, provider, subject string) (*UserIdentity, error)
+	CreateUserIdentity(ctx context.Context, identity *UserIdentity) error
+	TouchUserIdentity(ctx context.Context, id uuid.UUID, email string) error
+
+	// Two-factor authentication
+	GetUserMFA(ctx context.Context, userID uuid.UUID) (*UserMFA, error)
+	UpsertUserMFA(ctx context.Context, mfa *UserMFA) error
+	EnableUserMFA(ctx context.Context, userID uuid.UUID) error
+	ClaimMFAStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	DeleteUserMFA(ctx context.Context, userID uuid.UUID) error
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []string) error
+	UseLoginRecoveryCode(ctx context.Context, tokenID, userID uuid.UUID, code string) (claimed bool, used bool, err error)
+
+	// Audit logs
+	CreateAuditLog(ctx context.Context, log *AuditLog) error
+	ListAuditLogs(ctx context.Context, filter *AuditLogFilter, page, limit int) ([]AuditLog, int64, error)
+}
+
+// LoginAttemptRepository counts failed login attempts per key (email or IP address)
+type LoginAttemptRepository interface {
+	// IncrementFailures adds a failure, starting a new window when none is open, and returns the count
+	IncrementFailures(ctx context.Context, key string, window time.Duration) (int64, error)
+	// GetFailures returns the failures counted in the open window and how long the window has left
+	GetFailures(ctx context.Context, key string) (int64, time.Duration, error)
+	ResetFailures(ctx context.Context, keys ...string) error
+	SetDelay(ctx context.Context, key string, delay time.Duration) error
+	// GetDelay returns how long logins for the key must still wait, zero when they are allowed
+	GetDelay(ctx context.Context, key string) (time.Duration, error)
+}
+
+// OIDCStateRepository keeps the pending OIDC logins, each state can be consumed once
+type OIDCStateRepository interface {
+	SaveState(ctx context.Context, state string, value *OIDCState, ttl time.Duration) error
+	// ConsumeState returns nil when the state is unknown, expired or already used
+	ConsumeState(ctx context.Context, state string) (*OIDCState, error)
 }
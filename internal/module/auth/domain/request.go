@@ -1,16 +1,131 @@
 package domain
 
-// RegisterRequest represents the registration request payload
+import "github.com/google/uuid"
+
+// RegisterRequest represents the registration request payload. Self-registered
+// users always get the member role, staff accounts are created through invitations.
 type RegisterRequest struct {
-	Email     string   `json:"email" validate:"required,email"`
-	Password  string   `json:"password" validate:"required,min=8"`
-	Name      string   `json:"name" validate:"required"`
-	Phone     string   `json:"phone" validate:"required"`
-	RoleNames []string `json:"role_names" validate:"required,min=1"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8"`
+	Name     string `json:"name" validate:"required"`
+	Phone    string `json:"phone" validate:"required"`
 }
 
 // LoginRequest represents the login request payload
 type LoginRequest struct {
+	Email     string `json:"email" validate:"required,email"`
+	Password  string `json:"password" validate:"required"`
+	IP        string `json:"-"` // Client address, set by the handler for throttling
+	UserAgent string `json:"-"` // Set by the handler to describe the session
+}
+
+// RefreshTokenRequest represents the refresh token request payload
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+	IP           string `json:"-"`
+	UserAgent    string `json:"-"`
+}
+
+// VerifyEmailRequest represents the query of a signed email verification link
+type VerifyEmailRequest struct {
+	ID        string `query:"id"`
+	Token     string `query:"token"`
+	Expires   string `query:"expires"`
+	Signature string `query:"signature"`
+}
+
+// ResendVerificationRequest represents the request to resend the verification email
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ForgotPasswordRequest represents the request to receive a password reset email
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordRequest represents the request to set a new password with a reset token
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+// ChangePasswordRequest represents the request of a signed-in user to set a new password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	Password        string `json:"password" validate:"required,min=8"`
+}
+
+// ChangeEmailRequest represents the request of a signed-in user to move to a new email address
+type ChangeEmailRequest struct {
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required"`
+	Password string `json:"password" validate:"required"` // Current password
+}
+
+// CreateInvitationRequest represents the request of an administrator to invite a staff member
+type CreateInvitationRequest struct {
+	Email     string   `json:"email" validate:"required,email"`
+	Name      string   `json:"name" validate:"required"`
+	RoleNames []string `json:"role_names" validate:"required,min=1"`
+}
+
+// AcceptInvitationRequest represents the request of an invitee to create their account
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
+	Phone    string `json:"phone"`
+}
+
+// MFALoginRequest completes a login with a TOTP code or a recovery code
+type MFALoginRequest struct {
+	MFAToken     string `json:"mfa_token" validate:"required"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+	IP           string `json:"-"` // Client address, set by the handler for the audit log
+	UserAgent    string `json:"-"`
+}
+
+// MFATokenRequest carries the pending token of a login waiting for the second factor
+type MFATokenRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+}
+
+// MFACodeRequest carries a TOTP code of the authenticated user
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required,len=6"`
+}
+
+// OIDCCallbackRequest completes an OIDC login with the authorization code sent back by the provider
+type OIDCCallbackRequest struct {
+	Provider  string `json:"-"` // Provider name, set by the handler from the path
+	Code      string `json:"code" validate:"required"`
+	State     string `json:"state" validate:"required"`
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// OIDCAuthorizationResponse points the user to the login page of the provider
+type OIDCAuthorizationResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+// ClientInfo describes the device a session is used from
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+// UserFilter narrows down the users listed to administrators
+type UserFilter struct {
+	Status       string
+	Role         string // Role name
+	RegisterFrom string
+	Search       string // Matched against the email and the name
+	Deleted      bool   // List soft-deleted users instead of live ones
+}
+
+// AuditLogFilter narrows down the audit logs returned to reviewers
+type AuditLogFilter struct {
+	Event  string
+	UserID *uuid.UUID
 }
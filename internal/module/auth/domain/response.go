@@ -11,13 +11,40 @@ type RegisterResponse struct {
 	User *User `json:"user"`
 }
 
-// LoginResponse represents the response after successful login
+// LoginResponse represents the response after successful login. When a second
+// factor is required it only carries the MFA token to exchange at /auth/login/mfa.
 type LoginResponse struct {
-	ID        uuid.UUID `json:"id"`
-	Email     string    `json:"email"`
-	Name      string    `json:"name"`
-	Phone     string    `json:"phone"`
-	Status    string    `json:"status"`
-	Token     string    `json:"token"`
-	ExpiredAt time.Time `json:"expired_at"`
+	ID                    uuid.UUID  `json:"id"`
+	Email                 string     `json:"email"`
+	Name                  string     `json:"name"`
+	Phone                 string     `json:"phone"`
+	Status                string     `json:"status"`
+	Token                 string     `json:"token,omitempty"`
+	ExpiredAt             *time.Time `json:"expired_at,omitempty"`
+	RefreshToken          string     `json:"refresh_token,omitempty"`
+	RefreshExpiredAt      *time.Time `json:"refresh_expired_at,omitempty"`
+	MFARequired           bool       `json:"mfa_required"`
+	MFAEnrollmentRequired bool       `json:"mfa_enrollment_required,omitempty"` // The role requires MFA and none is set up yet
+	MFAToken              string     `json:"mfa_token,omitempty"`
+	MFAExpiredAt          *time.Time `json:"mfa_expired_at,omitempty"`
+	RecoveryCodes         []string   `json:"recovery_codes,omitempty"` // Only returned when MFA was enabled by this login
+}
+
+// TokenResponse represents a rotated access and refresh token pair
+type TokenResponse struct {
+	Token            string    `json:"token"`
+	ExpiredAt        time.Time `json:"expired_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiredAt time.Time `json:"refresh_expired_at"`
+}
+
+// MFAEnrollmentResponse carries a new TOTP secret for the authenticator app
+type MFAEnrollmentResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"` // otpauth:// URI to render as a QR code
+}
+
+// RecoveryCodesResponse carries single-use recovery codes, shown only once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
 }
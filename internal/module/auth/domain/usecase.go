@@ -12,16 +12,72 @@ type AuthUsecase interface {
 	Register(ctx context.Context, req *RegisterRequest) (*RegisterResponse, error)
 	Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error)
 	Logout(ctx context.Context, tokenID string) error
-	UpdateUser(ctx context.Context, user *User) error
+	RefreshToken(ctx context.Context, req *RefreshTokenRequest) (*TokenResponse, error)
+	VerifyLoginMFA(ctx context.Context, req *MFALoginRequest) (*LoginResponse, error)
+	EnrollLoginMFA(ctx context.Context, mfaToken string) (*MFAEnrollmentResponse, error)
+	StartOIDCLogin(ctx context.Context, provider string) (*OIDCAuthorizationResponse, error)
+	OIDCLogin(ctx context.Context, req *OIDCCallbackRequest) (*LoginResponse, error)
+	VerifyEmail(ctx context.Context, req *VerifyEmailRequest) error
+	ResendVerificationEmail(ctx context.Context, email string) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, req *ResetPasswordRequest) error
 	GetUserByID(ctx context.Context, userID uuid.UUID) (*User, error)
+	UnlockUser(ctx context.Context, actorID, userID uuid.UUID) error
+
+	// Profile operations
+	UpdateProfile(ctx context.Context, userID uuid.UUID, req *UpdateUserRequest) (*User, error)
+	ChangePassword(ctx context.Context, userID, currentTokenID uuid.UUID, req *ChangePasswordRequest) error
+	RequestEmailChange(ctx context.Context, userID uuid.UUID, req *ChangeEmailRequest) error
+	ConfirmEmailChange(ctx context.Context, req *VerifyEmailRequest) error
+
+	// User administration
+	ListUsers(ctx context.Context, filter *UserFilter, page, limit int) ([]User, int64, error)
+	GetUser(ctx context.Context, userID uuid.UUID) (*User, error)
+	SuspendUser(ctx context.Context, actorID, userID uuid.UUID) error
+	ReactivateUser(ctx context.Context, actorID, userID uuid.UUID) error
+	DeleteUser(ctx context.Context, actorID, userID uuid.UUID) error
+	RestoreUser(ctx context.Context, actorID, userID uuid.UUID) error
+
+	// Staff invitations
+	CreateInvitation(ctx context.Context, actorID uuid.UUID, req *CreateInvitationRequest) (*Invitation, error)
+	ListInvitations(ctx context.Context, status string, page, limit int) ([]Invitation, int64, error)
+	RevokeInvitation(ctx context.Context, actorID, invitationID uuid.UUID) error
+	AcceptInvitation(ctx context.Context, req *AcceptInvitationRequest) (*User, error)
+
+	// Two-factor authentication
+	EnrollMFA(ctx context.Context, userID uuid.UUID) (*MFAEnrollmentResponse, error)
+	ConfirmMFA(ctx context.Context, userID uuid.UUID, code string) (*RecoveryCodesResponse, error)
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (*RecoveryCodesResponse, error)
+	DisableMFA(ctx context.Context, userID uuid.UUID, code string) error
 
 	// Role operations
+	ListRoles(ctx context.Context) ([]Role, error)
+	GetRoleByID(ctx context.Context, id uuid.UUID) (*Role, error)
+	CreateRole(ctx context.Context, req *RoleRequest) (*Role, error)
+	UpdateRole(ctx context.Context, id uuid.UUID, req *RoleRequest) (*Role, error)
+	DeleteRole(ctx context.Context, id uuid.UUID) error
 	AssignRoles(ctx context.Context, userID uuid.UUID, roleNames []string) error
+	RevokeRoles(ctx context.Context, userID uuid.UUID, roleNames []string) error
 	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]Role, error)
+	GetUsersByRole(ctx context.Context, roleID uuid.UUID, page, limit int) ([]User, int64, error)
+
+	// Permission operations
+	ListPermissions(ctx context.Context) ([]Permission, error)
+	GetRolePermissions(ctx context.Context, roleID uuid.UUID) ([]Permission, error)
+	SetRolePermissions(ctx context.Context, roleID uuid.UUID, permissionNames []string) error
 
 	// Token operations
 	GetUserTokenByID(ctx context.Context, tokenID uuid.UUID) (*UserToken, error)
-	ValidateUserToken(ctx context.Context, tokenID string, token string) error
+	ValidateUserToken(ctx context.Context, tokenID string, token string) (*UserToken, error)
 	InvalidateUserToken(ctx context.Context, tokenID uuid.UUID) error
 	InvalidateUserTokens(ctx context.Context, userID uuid.UUID) error
+	TouchUserToken(ctx context.Context, token *UserToken, client ClientInfo)
+
+	// Session operations
+	ListSessions(ctx context.Context, userID, currentTokenID uuid.UUID) ([]Session, error)
+	RevokeSession(ctx context.Context, actorID, userID, sessionID uuid.UUID) error
+	RevokeAllSessions(ctx context.Context, actorID, userID uuid.UUID) error
+
+	// Audit operations
+	ListAuditLogs(ctx context.Context, filter *AuditLogFilter, page, limit int) ([]AuditLog, int64, error)
 }
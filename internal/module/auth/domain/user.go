@@ -13,7 +13,9 @@ type User struct {
 	Name            string     `json:"name"`
 	Phone           string     `json:"phone"`
 	Status          string     `json:"status"`
+	RegisterFrom    string     `json:"register_from"`
 	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	LockedUntil     *time.Time `json:"locked_until,omitempty"` // Set while the account is locked after failed logins
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
 	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
@@ -21,22 +23,135 @@ type User struct {
 }
 
 type Role struct {
-	ID          uuid.UUID  `json:"id"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	ID          uuid.UUID    `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	MFARequired bool         `json:"mfa_required"` // Users with this role must sign in with a second factor
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	DeletedAt   *time.Time   `json:"deleted_at,omitempty"`
+	Permissions []Permission `json:"permissions,omitempty"`
+}
+
+// Permission represents a fine-grained ability granted to roles
+type Permission struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
 }
 
 // UserToken represents a user's authentication token
 type UserToken struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Token      string     `json:"-"` // SHA-256 digest, the raw token is only returned at issue time
+	Type       string     `json:"type"`
+	FamilyID   uuid.UUID  `json:"family_id"` // Shared by every token issued from the same login
+	Ability    []string   `json:"ability"`
+	UserAgent  string     `json:"user_agent,omitempty"`
+	IPAddress  string     `json:"ip_address,omitempty"`
+	ExpiredAt  time.Time  `json:"expired_at"`
+	UsedAt     *time.Time `json:"used_at,omitempty"`      // Set once a refresh token has been rotated
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"` // Last authenticated request, written at most every SESSION_TOUCH_INTERVAL
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Session is a login of a user: the access and refresh tokens sharing a family
+type Session struct {
+	ID         uuid.UUID  `json:"id"` // Family ID of the tokens
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiredAt  time.Time  `json:"expired_at"`
+	Current    bool       `json:"current"` // Whether the request was made with this session
+}
+
+// EmailVerificationToken is a single-use token sent to confirm a user's email address
+type EmailVerificationToken struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Token     string     `json:"-"` // SHA-256 digest
+	ExpiredAt time.Time  `json:"expired_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// PasswordResetToken is a single-use token sent to reset a forgotten password
+type PasswordResetToken struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Token     string     `json:"-"` // SHA-256 digest
+	ExpiredAt time.Time  `json:"expired_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// EmailChangeToken is a single-use token sent to a new address to confirm an email change
+type EmailChangeToken struct {
 	ID        uuid.UUID  `json:"id"`
 	UserID    uuid.UUID  `json:"user_id"`
-	Token     string     `json:"token"`
-	Ability   []string   `json:"ability"`
+	NewEmail  string     `json:"new_email"`
+	Token     string     `json:"-"` // SHA-256 digest
 	ExpiredAt time.Time  `json:"expired_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
 	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Invitation lets a staff member create an account with roles chosen by an administrator
+type Invitation struct {
+	ID         uuid.UUID  `json:"id"`
+	Email      string     `json:"email"`
+	Name       string     `json:"name"`
+	Token      string     `json:"-"` // SHA-256 digest
+	Status     string     `json:"status"`
+	Roles      []Role     `json:"roles"`
+	InvitedBy  *uuid.UUID `json:"invited_by,omitempty"`
+	UserID     *uuid.UUID `json:"user_id,omitempty"` // Account created on acceptance
+	ExpiredAt  time.Time  `json:"expired_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// UserIdentity links a user to an account of an external OpenID Connect provider
+type UserIdentity struct {
+	ID          uuid.UUID  `json:"id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Provider    string     `json:"provider"`
+	Subject     string     `json:"subject"`
+	Email       string     `json:"email,omitempty"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// OIDCState is kept between the authorization request and the callback of an OIDC login
+type OIDCState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+// AuditLog records a security relevant authentication event
+type AuditLog struct {
+	ID        uuid.UUID              `json:"id"`
+	Event     string                 `json:"event"`
+	UserID    *uuid.UUID             `json:"user_id,omitempty"`  // User the event is about
+	ActorID   *uuid.UUID             `json:"actor_id,omitempty"` // User who triggered the event, nil for the system
+	Email     string                 `json:"email,omitempty"`
+	IPAddress string                 `json:"ip_address,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// UserMFA holds the TOTP second factor of a user
+type UserMFA struct {
+	UserID       uuid.UUID  `json:"user_id"`
+	Secret       string     `json:"-"`          // Encrypted TOTP secret
+	LastUsedStep *int64     `json:"-"`          // Time step of the last accepted code
+	EnabledAt    *time.Time `json:"enabled_at"` // Nil until the first code is confirmed
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
 }
@@ -2,9 +2,12 @@ package domain
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
+	"strings"
 
 	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/signedurl"
 	"github.com/gomajido/hospital-cms-golang/internal/response"
 )
 
@@ -16,23 +19,42 @@ const (
 	PHONE_FIELD    = "phone"
 	ROLE_IDS_FIELD = "role_ids"
 	ROLE_NAMES_FIELD = "role_names"
+	PERMISSION_NAMES_FIELD = "permission_names"
 	USER_ID_FIELD  = "user_id"
+	REFRESH_TOKEN_FIELD = "refresh_token"
+	TOKEN_FIELD    = "token"
+	MFA_TOKEN_FIELD = "mfa_token"
+	CODE_FIELD     = "code"
+	RECOVERY_CODE_FIELD = "recovery_code"
+	STATUS_FIELD   = "status"
+	CURRENT_PASSWORD_FIELD = "current_password"
+	STATE_FIELD    = "state"
 )
 
-// UpdateUserRequest represents the request to update user details
+// UpdateUserRequest represents the request of a user to update their profile.
+// Email and password have their own flows and the status is managed by administrators.
 type UpdateUserRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"-"` // Never expose password in JSON
-	Name     string `json:"name"`
-	Phone    string `json:"phone"`
-	Status   string `json:"status"`
+	Name  string `json:"name"`
+	Phone string `json:"phone"`
 }
 
-// AssignRolesRequest represents the request to assign roles to a user
+// AssignRolesRequest represents the request to assign or revoke roles of a user
 type AssignRolesRequest struct {
 	RoleNames []string `json:"role_names"`
 }
 
+// RolePermissionsRequest represents the request to set the permissions of a role
+type RolePermissionsRequest struct {
+	PermissionNames []string `json:"permission_names"`
+}
+
+// RoleRequest represents the request to create or update a role
+type RoleRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MFARequired bool   `json:"mfa_required"`
+}
+
 func (r *RegisterRequest) Validate() []response.ErrorInfo {
 	var errorInfo []response.ErrorInfo
 
@@ -105,7 +127,25 @@ func (u *UpdateUserRequest) Validate() []response.ErrorInfo {
 		})
 	}
 
-	if u.Password != constant.EMPTY_STRING && len(u.Password) < 8 {
+	return errorInfo
+}
+
+func (c *ChangePasswordRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if c.CurrentPassword == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        CURRENT_PASSWORD_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, CURRENT_PASSWORD_FIELD),
+		})
+	}
+
+	if c.Password == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        PASSWORD_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, PASSWORD_FIELD),
+		})
+	} else if len(c.Password) < 8 {
 		errorInfo = append(errorInfo, response.ErrorInfo{
 			Field:        PASSWORD_FIELD,
 			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MIN_LENGTH, PASSWORD_FIELD, 8),
@@ -115,6 +155,31 @@ func (u *UpdateUserRequest) Validate() []response.ErrorInfo {
 	return errorInfo
 }
 
+func (c *ChangeEmailRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if c.Email == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        EMAIL_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, EMAIL_FIELD),
+		})
+	} else if !isValidEmail(c.Email) {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        EMAIL_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_FORMAT, EMAIL_FIELD, "email"),
+		})
+	}
+
+	if c.Password == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        PASSWORD_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, PASSWORD_FIELD),
+		})
+	}
+
+	return errorInfo
+}
+
 func (a *AssignRolesRequest) Validate() []response.ErrorInfo {
 	var errorInfo []response.ErrorInfo
 
@@ -128,6 +193,310 @@ func (a *AssignRolesRequest) Validate() []response.ErrorInfo {
 	return errorInfo
 }
 
+func (r *RoleRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if r.Name == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        NAME_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, NAME_FIELD),
+		})
+	} else if len(r.Name) > 255 {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        NAME_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MAX_LENGTH, NAME_FIELD, 255),
+		})
+	}
+
+	return errorInfo
+}
+
+func (r *RolePermissionsRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	// An empty list is allowed and removes every permission from the role
+	if r.PermissionNames == nil {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        PERMISSION_NAMES_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, PERMISSION_NAMES_FIELD),
+		})
+	}
+
+	return errorInfo
+}
+
+func (r *RefreshTokenRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if r.RefreshToken == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        REFRESH_TOKEN_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, REFRESH_TOKEN_FIELD),
+		})
+	}
+
+	return errorInfo
+}
+
+func (v *VerifyEmailRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if v.ID == constant.EMPTY_STRING || v.Token == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        TOKEN_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, TOKEN_FIELD),
+		})
+	}
+
+	return errorInfo
+}
+
+// Values returns the signed query parameters of the verification link
+func (v *VerifyEmailRequest) Values() url.Values {
+	return url.Values{
+		"id":                      {v.ID},
+		"token":                   {v.Token},
+		signedurl.PARAM_EXPIRES:   {v.Expires},
+		signedurl.PARAM_SIGNATURE: {v.Signature},
+	}
+}
+
+func (r *ResendVerificationRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if r.Email == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        EMAIL_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, EMAIL_FIELD),
+		})
+	} else if !isValidEmail(r.Email) {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        EMAIL_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_FORMAT, EMAIL_FIELD, "email"),
+		})
+	}
+
+	return errorInfo
+}
+
+func (f *ForgotPasswordRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if f.Email == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        EMAIL_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, EMAIL_FIELD),
+		})
+	} else if !isValidEmail(f.Email) {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        EMAIL_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_FORMAT, EMAIL_FIELD, "email"),
+		})
+	}
+
+	return errorInfo
+}
+
+func (r *ResetPasswordRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if r.Token == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        TOKEN_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, TOKEN_FIELD),
+		})
+	}
+
+	if r.Password == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        PASSWORD_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, PASSWORD_FIELD),
+		})
+	} else if len(r.Password) < 8 {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        PASSWORD_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MIN_LENGTH, PASSWORD_FIELD, 8),
+		})
+	}
+
+	return errorInfo
+}
+
+func (m *MFALoginRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if m.MFAToken == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        MFA_TOKEN_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, MFA_TOKEN_FIELD),
+		})
+	}
+
+	if m.Code == constant.EMPTY_STRING && m.RecoveryCode == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        CODE_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, CODE_FIELD+" or "+RECOVERY_CODE_FIELD),
+		})
+	} else if m.Code != constant.EMPTY_STRING && !isValidTOTPCode(m.Code) {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        CODE_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_FORMAT, CODE_FIELD, "6 digits"),
+		})
+	}
+
+	return errorInfo
+}
+
+func (o *OIDCCallbackRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if o.Code == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        CODE_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, CODE_FIELD),
+		})
+	}
+
+	if o.State == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        STATE_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, STATE_FIELD),
+		})
+	}
+
+	return errorInfo
+}
+
+func (m *MFATokenRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if m.MFAToken == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        MFA_TOKEN_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, MFA_TOKEN_FIELD),
+		})
+	}
+
+	return errorInfo
+}
+
+func (m *MFACodeRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if m.Code == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        CODE_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, CODE_FIELD),
+		})
+	} else if !isValidTOTPCode(m.Code) {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        CODE_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_FORMAT, CODE_FIELD, "6 digits"),
+		})
+	}
+
+	return errorInfo
+}
+
+func (f *UserFilter) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	switch f.Status {
+	case constant.EMPTY_STRING, constant.USER_STATUS_ACTIVE, constant.USER_STATUS_INACTIVE, constant.USER_STATUS_SUSPENDED:
+	default:
+		statuses := strings.Join([]string{constant.USER_STATUS_ACTIVE, constant.USER_STATUS_INACTIVE, constant.USER_STATUS_SUSPENDED}, ", ")
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        STATUS_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_VALUE, STATUS_FIELD, statuses),
+		})
+	}
+
+	return errorInfo
+}
+
+func (r *CreateInvitationRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if r.Email == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        EMAIL_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, EMAIL_FIELD),
+		})
+	} else if !isValidEmail(r.Email) {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        EMAIL_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_FORMAT, EMAIL_FIELD, "email"),
+		})
+	}
+
+	if r.Name == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        NAME_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, NAME_FIELD),
+		})
+	}
+
+	if len(r.RoleNames) == 0 {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        ROLE_NAMES_FIELD,
+			ErrorMessage: "At least one role name is required",
+		})
+	}
+
+	return errorInfo
+}
+
+func (r *AcceptInvitationRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if r.Token == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        TOKEN_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, TOKEN_FIELD),
+		})
+	}
+
+	if r.Password == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        PASSWORD_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, PASSWORD_FIELD),
+		})
+	} else if len(r.Password) < 8 {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        PASSWORD_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MIN_LENGTH, PASSWORD_FIELD, 8),
+		})
+	}
+
+	return errorInfo
+}
+
+// ValidateInvitationStatus checks the status used to filter invitations
+func ValidateInvitationStatus(status string) []response.ErrorInfo {
+	switch status {
+	case constant.EMPTY_STRING, constant.INVITATION_STATUS_PENDING, constant.INVITATION_STATUS_ACCEPTED,
+		constant.INVITATION_STATUS_REVOKED, constant.INVITATION_STATUS_EXPIRED:
+		return nil
+	}
+
+	statuses := strings.Join([]string{
+		constant.INVITATION_STATUS_PENDING,
+		constant.INVITATION_STATUS_ACCEPTED,
+		constant.INVITATION_STATUS_REVOKED,
+		constant.INVITATION_STATUS_EXPIRED,
+	}, ", ")
+	return []response.ErrorInfo{{
+		Field:        STATUS_FIELD,
+		ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_VALUE, STATUS_FIELD, statuses),
+	}}
+}
+
+var totpCodeRegex = regexp.MustCompile(`^[0-9]{6}$`)
+
+func isValidTOTPCode(code string) bool {
+	return totpCodeRegex.MatchString(code)
+}
+
 func isValidEmail(email string) bool {
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	return emailRegex.MatchString(email)
@@ -1,9 +1,14 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
+	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/identity"
 	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
 	"github.com/gomajido/hospital-cms-golang/internal/response"
 	"github.com/google/uuid"
@@ -53,28 +58,661 @@ func (h *authHandler) Login(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
 	}
 
+	req.IP = c.IP()
+	req.UserAgent = c.Get(fiber.HeaderUserAgent)
+
 	resp, err := h.usecase.Login(c.Context(), &req)
 	if err != nil {
-		if err.Error() == "invalid credentials" {
+		var throttled *domain.LoginThrottledError
+		if errors.As(err, &throttled) {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			return c.Status(fiber.StatusTooManyRequests).JSON(response.StatusTooManyRequests.WithError(err))
+		}
+
+		switch err.Error() {
+		case "invalid credentials":
+			return c.Status(fiber.StatusUnauthorized).JSON(response.ErrUnauthorized.WithError(err))
+		case "email not verified", "account is not active":
+			return c.Status(fiber.StatusForbidden).JSON(response.ErrForbidden.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(resp))
+}
+
+// RefreshToken exchanges a refresh token for a new access and refresh token pair
+func (h *authHandler) RefreshToken(c *fiber.Ctx) error {
+	var req domain.RefreshTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	req.IP = c.IP()
+	req.UserAgent = c.Get(fiber.HeaderUserAgent)
+
+	resp, err := h.usecase.RefreshToken(c.Context(), &req)
+	if err != nil {
+		switch err.Error() {
+		case "invalid refresh token", "refresh token expired", "refresh token reused":
+			return c.Status(fiber.StatusUnauthorized).JSON(response.ErrUnauthorized.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(resp))
+}
+
+// StartOIDCLogin returns the URL of the provider login page the user is sent to
+func (h *authHandler) StartOIDCLogin(c *fiber.Ctx) error {
+	resp, err := h.usecase.StartOIDCLogin(c.Context(), c.Params("provider"))
+	if err != nil {
+		if err.Error() == "unknown provider" {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(resp))
+}
+
+// OIDCCallback completes a provider login with the authorization code handed to the frontend
+func (h *authHandler) OIDCCallback(c *fiber.Ctx) error {
+	var req domain.OIDCCallbackRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	req.Provider = c.Params("provider")
+	req.IP = c.IP()
+	req.UserAgent = c.Get(fiber.HeaderUserAgent)
+
+	resp, err := h.usecase.OIDCLogin(c.Context(), &req)
+	if err != nil {
+		var throttled *domain.LoginThrottledError
+		if errors.As(err, &throttled) {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			return c.Status(fiber.StatusTooManyRequests).JSON(response.StatusTooManyRequests.WithError(err))
+		}
+
+		switch err.Error() {
+		case "unknown provider":
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		case "invalid state":
+			return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest.WithError(err))
+		case "sso login failed":
+			return c.Status(fiber.StatusUnauthorized).JSON(response.ErrUnauthorized.WithError(err))
+		case "email not verified", "account is not active":
+			return c.Status(fiber.StatusForbidden).JSON(response.ErrForbidden.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(resp))
+}
+
+// VerifyLoginMFA completes a login waiting for the second factor
+func (h *authHandler) VerifyLoginMFA(c *fiber.Ctx) error {
+	var req domain.MFALoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	req.IP = c.IP()
+	req.UserAgent = c.Get(fiber.HeaderUserAgent)
+
+	resp, err := h.usecase.VerifyLoginMFA(c.Context(), &req)
+	if err != nil {
+		switch err.Error() {
+		case "invalid mfa token", "mfa token expired", "invalid mfa code":
 			return c.Status(fiber.StatusUnauthorized).JSON(response.ErrUnauthorized.WithError(err))
+		case "mfa not enrolled":
+			return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(resp))
+}
+
+// EnrollLoginMFA sets up the second factor of a user who must have one before logging in
+func (h *authHandler) EnrollLoginMFA(c *fiber.Ctx) error {
+	var req domain.MFATokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	resp, err := h.usecase.EnrollLoginMFA(c.Context(), req.MFAToken)
+	if err != nil {
+		switch err.Error() {
+		case "invalid mfa token", "mfa token expired":
+			return c.Status(fiber.StatusUnauthorized).JSON(response.ErrUnauthorized.WithError(err))
+		case "mfa already enabled":
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(resp))
+}
+
+// VerifyEmail confirms the email address of a user from a signed link
+func (h *authHandler) VerifyEmail(c *fiber.Ctx) error {
+	var req domain.VerifyEmailRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	if err := h.usecase.VerifyEmail(c.Context(), &req); err != nil {
+		switch err.Error() {
+		case "invalid verification link", "verification link expired":
+			return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
+
+// ResendVerificationEmail sends a new verification link to an unverified user
+func (h *authHandler) ResendVerificationEmail(c *fiber.Ctx) error {
+	var req domain.ResendVerificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	if err := h.usecase.ResendVerificationEmail(c.Context(), req.Email); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	// Same answer whether or not the email is registered
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
+
+// ForgotPassword sends a password reset email
+func (h *authHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req domain.ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	if err := h.usecase.ForgotPassword(c.Context(), req.Email); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	// Same answer whether or not the email is registered
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
+
+// ResetPassword sets a new password with a reset token
+func (h *authHandler) ResetPassword(c *fiber.Ctx) error {
+	var req domain.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	if err := h.usecase.ResetPassword(c.Context(), &req); err != nil {
+		switch err.Error() {
+		case "invalid reset token", "reset token expired":
+			return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest.WithError(err))
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
 	}
 
-	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(resp))
-}
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
+
+// Logout handles user logout
+func (h *authHandler) Logout(c *fiber.Ctx) error {
+	id, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	if err := h.usecase.Logout(c.Context(), id.TokenID.String()); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
+
+// ListSessions lists the active sessions of the authenticated user
+func (h *authHandler) ListSessions(c *fiber.Ctx) error {
+	id, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	sessions, err := h.usecase.ListSessions(c.Context(), id.UserID, id.TokenID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(sessions))
+}
+
+// RevokeSession signs one of the authenticated user's sessions out
+func (h *authHandler) RevokeSession(c *fiber.Ctx) error {
+	id, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid session id format")))
+	}
+
+	return sessionRevoked(c, h.usecase.RevokeSession(c.Context(), id.UserID, id.UserID, sessionID))
+}
+
+// RevokeAllSessions signs the authenticated user out everywhere
+func (h *authHandler) RevokeAllSessions(c *fiber.Ctx) error {
+	id, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	return sessionRevoked(c, h.usecase.RevokeAllSessions(c.Context(), id.UserID, id.UserID))
+}
+
+// ListUserSessions lists the active sessions of any user
+func (h *authHandler) ListUserSessions(c *fiber.Ctx) error {
+	id, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid user id format")))
+	}
+
+	sessions, err := h.usecase.ListSessions(c.Context(), userID, id.TokenID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(sessions))
+}
+
+// RevokeUserSession signs one session of any user out
+func (h *authHandler) RevokeUserSession(c *fiber.Ctx) error {
+	id, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid user id format")))
+	}
+
+	sessionID, err := uuid.Parse(c.Params("session_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid session id format")))
+	}
+
+	return sessionRevoked(c, h.usecase.RevokeSession(c.Context(), id.UserID, userID, sessionID))
+}
+
+// RevokeUserSessions signs any user out everywhere
+func (h *authHandler) RevokeUserSessions(c *fiber.Ctx) error {
+	id, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid user id format")))
+	}
+
+	return sessionRevoked(c, h.usecase.RevokeAllSessions(c.Context(), id.UserID, userID))
+}
+
+// sessionRevoked writes the response of a session revocation
+func sessionRevoked(c *fiber.Ctx, err error) error {
+	if err != nil {
+		if err.Error() == "session not found" {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
+
+// EnrollMFA starts the TOTP enrollment of the authenticated user
+func (h *authHandler) EnrollMFA(c *fiber.Ctx) error {
+	id, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	resp, err := h.usecase.EnrollMFA(c.Context(), id.UserID)
+	if err != nil {
+		if err.Error() == "mfa already enabled" {
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(resp))
+}
+
+// ConfirmMFA enables the enrolled TOTP factor of the authenticated user
+func (h *authHandler) ConfirmMFA(c *fiber.Ctx) error {
+	id, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	var req domain.MFACodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	resp, err := h.usecase.ConfirmMFA(c.Context(), id.UserID, req.Code)
+	if err != nil {
+		return mfaError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(resp))
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the authenticated user
+func (h *authHandler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	id, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	var req domain.MFACodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	resp, err := h.usecase.RegenerateRecoveryCodes(c.Context(), id.UserID, req.Code)
+	if err != nil {
+		return mfaError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(resp))
+}
+
+// DisableMFA removes the TOTP factor of the authenticated user
+func (h *authHandler) DisableMFA(c *fiber.Ctx) error {
+	id, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	var req domain.MFACodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	if err := h.usecase.DisableMFA(c.Context(), id.UserID, req.Code); err != nil {
+		return mfaError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
+
+// mfaError maps the errors of the MFA management endpoints
+func mfaError(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "invalid mfa code":
+		return c.Status(fiber.StatusUnauthorized).JSON(response.ErrUnauthorized.WithError(err))
+	case "mfa not enrolled", "mfa not enabled":
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest.WithError(err))
+	case "mfa already enabled":
+		return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+	case "mfa is required for your role":
+		return c.Status(fiber.StatusForbidden).JSON(response.ErrForbidden.WithError(err))
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+}
+
+// GetUserByID retrieves a user by their ID, or the authenticated user when no ID is given
+func (h *authHandler) GetUserByID(c *fiber.Ctx) error {
+	var userID uuid.UUID
+	if userIDStr := c.Params("id"); userIDStr != "" {
+		parsedID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid user id format")))
+		}
+		userID = parsedID
+	} else {
+		id, err := identity.FromContext(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+		}
+		userID = id.UserID
+	}
+
+	user, err := h.usecase.GetUserByID(c.Context(), userID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(user))
+}
+
+// UpdateUser handles updating user details
+func (h *authHandler) UpdateUser(c *fiber.Ctx) error {
+	// Get user identity from context
+	id, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	var req domain.UpdateUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest.WithError(err))
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithErrorInfo(err))
+	}
+
+	user, err := h.usecase.UpdateProfile(c.Context(), id.UserID, &req)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(user))
+}
+
+// ChangePassword changes the password of the authenticated user and signs out their other sessions
+func (h *authHandler) ChangePassword(c *fiber.Ctx) error {
+	id, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	var req domain.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	if err := h.usecase.ChangePassword(c.Context(), id.UserID, id.TokenID, &req); err != nil {
+		if err.Error() == "invalid current password" {
+			return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
+
+// ChangeEmail sends a confirmation link to the new email address of the authenticated user
+func (h *authHandler) ChangeEmail(c *fiber.Ctx) error {
+	id, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	var req domain.ChangeEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	if err := h.usecase.RequestEmailChange(c.Context(), id.UserID, &req); err != nil {
+		switch err.Error() {
+		case "invalid current password", "email unchanged":
+			return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest.WithError(err))
+		case "email already in use":
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
+
+// ConfirmEmailChange handles the signed link sent to the new email address
+func (h *authHandler) ConfirmEmailChange(c *fiber.Ctx) error {
+	var req domain.VerifyEmailRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	if err := h.usecase.ConfirmEmailChange(c.Context(), &req); err != nil {
+		switch err.Error() {
+		case "invalid confirmation link", "confirmation link expired":
+			return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest.WithError(err))
+		case "email already in use":
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
+
+// AssignRoles assigns roles to a user
+func (h *authHandler) AssignRoles(c *fiber.Ctx) error {
+	userIDStr := c.Params("id")
+	if userIDStr == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("user id is required")))
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid user id format")))
+	}
+
+	var req domain.AssignRolesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	if err := h.usecase.AssignRoles(c.Context(), userID, req.RoleNames); err != nil {
+		if err.Error() == "user not found" || err.Error() == "role not found" {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
+
+// RevokeRoles removes roles from a user
+func (h *authHandler) RevokeRoles(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid user id format")))
+	}
+
+	var req domain.AssignRolesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
 
-// Logout handles user logout
-func (h *authHandler) Logout(c *fiber.Ctx) error {
-	if err := h.usecase.Logout(c.Context(), c.Locals("user_token").(string)); err != nil {
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	if err := h.usecase.RevokeRoles(c.Context(), userID, req.RoleNames); err != nil {
+		if err.Error() == "user not found" || err.Error() == "role not found" {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response.Ok)
 }
 
-// GetUserByID retrieves a user by their ID
-func (h *authHandler) GetUserByID(c *fiber.Ctx) error {
+// GetUserRoles retrieves all roles assigned to a user
+func (h *authHandler) GetUserRoles(c *fiber.Ctx) error {
 	userIDStr := c.Params("id")
 	if userIDStr == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("user id is required")))
@@ -85,7 +723,88 @@ func (h *authHandler) GetUserByID(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid user id format")))
 	}
 
-	user, err := h.usecase.GetUserByID(c.Context(), userID)
+	roles, err := h.usecase.GetUserRoles(c.Context(), userID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(roles))
+}
+
+// UnlockUser lifts the lockout of a user after too many failed logins
+func (h *authHandler) UnlockUser(c *fiber.Ctx) error {
+	id, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid user id format")))
+	}
+
+	if err := h.usecase.UnlockUser(c.Context(), id.UserID, userID); err != nil {
+		if err.Error() == "user not found" {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
+
+// ListUsers pages through users, filtered by status, role, registration source or a search term
+func (h *authHandler) ListUsers(c *fiber.Ctx) error {
+	filter := &domain.UserFilter{
+		Status:       c.Query("status"),
+		Role:         c.Query("role"),
+		RegisterFrom: c.Query("register_from"),
+		Search:       c.Query("search"),
+		Deleted:      c.QueryBool("deleted"),
+	}
+
+	if errors := filter.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	users, total, err := h.usecase.ListUsers(c.Context(), filter, page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	listResponse := response.ListResponse{
+		Meta: response.MetaResponse{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+		},
+		Data: users,
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(listResponse))
+}
+
+// GetUser retrieves any user, including soft-deleted ones
+func (h *authHandler) GetUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid user id format")))
+	}
+
+	user, err := h.usecase.GetUser(c.Context(), userID)
 	if err != nil {
 		if err.Error() == "user not found" {
 			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
@@ -96,42 +815,135 @@ func (h *authHandler) GetUserByID(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(user))
 }
 
-// UpdateUser handles updating user details
-func (h *authHandler) UpdateUser(c *fiber.Ctx) error {
-	// Get user token from context
-	userToken, ok := c.Locals("user_token").(*domain.UserToken)
-	if !ok {
-		err := fmt.Errorf("missing user token")
+// SuspendUser suspends a user and signs them out everywhere
+func (h *authHandler) SuspendUser(c *fiber.Ctx) error {
+	return h.manageUser(c, h.usecase.SuspendUser)
+}
+
+// ReactivateUser lifts the suspension of a user
+func (h *authHandler) ReactivateUser(c *fiber.Ctx) error {
+	return h.manageUser(c, h.usecase.ReactivateUser)
+}
+
+// DeleteUser soft-deletes a user
+func (h *authHandler) DeleteUser(c *fiber.Ctx) error {
+	return h.manageUser(c, h.usecase.DeleteUser)
+}
+
+// RestoreUser restores a soft-deleted user
+func (h *authHandler) RestoreUser(c *fiber.Ctx) error {
+	return h.manageUser(c, h.usecase.RestoreUser)
+}
+
+// manageUser runs an administrative action of the authenticated user on the user of the path
+func (h *authHandler) manageUser(c *fiber.Ctx, action func(ctx context.Context, actorID, userID uuid.UUID) error) error {
+	id, err := identity.FromContext(c)
+	if err != nil {
 		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
 	}
 
-	var req domain.UpdateUserRequest
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid user id format")))
+	}
+
+	if err := action(c.Context(), id.UserID, userID); err != nil {
+		switch err.Error() {
+		case "user not found":
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		case "cannot change your own account":
+			return c.Status(fiber.StatusForbidden).JSON(response.ErrForbidden.WithError(err))
+		case "user already suspended", "user is not suspended":
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
+
+// CreateInvitation invites a staff member by email with pre-assigned roles
+func (h *authHandler) CreateInvitation(c *fiber.Ctx) error {
+	id, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	var req domain.CreateInvitationRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest.WithError(err))
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
 	}
 
-	if err := req.Validate(); err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithErrorInfo(err))
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
 	}
 
-	// Get current user data
-	user, err := h.usecase.GetUserByID(c.Context(), userToken.UserID)
+	invitation, err := h.usecase.CreateInvitation(c.Context(), id.UserID, &req)
 	if err != nil {
-		if err.Error() == "user not found" {
+		switch err.Error() {
+		case "role not found":
 			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		case "user with this email already exists":
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
 	}
 
-	// Update user fields
-	user.Name = req.Name
-	user.Phone = req.Phone
+	return c.Status(fiber.StatusCreated).JSON(response.Ok.WithData(invitation))
+}
+
+// ListInvitations lists invitations, optionally narrowed to a status
+func (h *authHandler) ListInvitations(c *fiber.Ctx) error {
+	status := c.Query("status")
+	if errors := domain.ValidateInvitationStatus(status); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
 
-	// Update user in database
-	err = h.usecase.UpdateUser(c.Context(), user)
+	invitations, total, err := h.usecase.ListInvitations(c.Context(), status, page, limit)
 	if err != nil {
-		if err.Error() == "user not found" {
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	listResponse := response.ListResponse{
+		Meta: response.MetaResponse{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+		},
+		Data: invitations,
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(listResponse))
+}
+
+// RevokeInvitation cancels a pending invitation
+func (h *authHandler) RevokeInvitation(c *fiber.Ctx) error {
+	id, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	invitationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid invitation id format")))
+	}
+
+	if err := h.usecase.RevokeInvitation(c.Context(), id.UserID, invitationID); err != nil {
+		switch err.Error() {
+		case "invitation not found":
 			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		case "invitation is no longer pending":
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
 	}
@@ -139,19 +951,62 @@ func (h *authHandler) UpdateUser(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(response.Ok)
 }
 
-// AssignRoles assigns roles to a user
-func (h *authHandler) AssignRoles(c *fiber.Ctx) error {
-	userIDStr := c.Params("id")
-	if userIDStr == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("user id is required")))
+// AcceptInvitation creates the account of an invitee from the emailed invite token
+func (h *authHandler) AcceptInvitation(c *fiber.Ctx) error {
+	var req domain.AcceptInvitationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
 	}
 
-	userID, err := uuid.Parse(userIDStr)
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	user, err := h.usecase.AcceptInvitation(c.Context(), &req)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid user id format")))
+		switch err.Error() {
+		case "invalid invitation", "invitation expired":
+			return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest.WithError(err))
+		case "user with this email already exists":
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
 	}
 
-	var req domain.AssignRolesRequest
+	return c.Status(fiber.StatusCreated).JSON(response.Ok.WithData(user))
+}
+
+// ListRoles retrieves all roles
+func (h *authHandler) ListRoles(c *fiber.Ctx) error {
+	roles, err := h.usecase.ListRoles(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(roles))
+}
+
+// GetRole retrieves a role by its ID
+func (h *authHandler) GetRole(c *fiber.Ctx) error {
+	roleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid role id format")))
+	}
+
+	role, err := h.usecase.GetRoleByID(c.Context(), roleID)
+	if err != nil {
+		if err.Error() == "role not found" {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(role))
+}
+
+// CreateRole creates a new role
+func (h *authHandler) CreateRole(c *fiber.Ctx) error {
+	var req domain.RoleRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
 	}
@@ -160,8 +1015,56 @@ func (h *authHandler) AssignRoles(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
 	}
 
-	if err := h.usecase.AssignRoles(c.Context(), userID, req.RoleNames); err != nil {
-		if err.Error() == "user not found" {
+	role, err := h.usecase.CreateRole(c.Context(), &req)
+	if err != nil {
+		if err.Error() == "role already exists" {
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Ok.WithData(role))
+}
+
+// UpdateRole updates an existing role
+func (h *authHandler) UpdateRole(c *fiber.Ctx) error {
+	roleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid role id format")))
+	}
+
+	var req domain.RoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	role, err := h.usecase.UpdateRole(c.Context(), roleID, &req)
+	if err != nil {
+		if err.Error() == "role not found" {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		if err.Error() == "role already exists" {
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(role))
+}
+
+// DeleteRole deletes a role and removes it from its users
+func (h *authHandler) DeleteRole(c *fiber.Ctx) error {
+	roleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid role id format")))
+	}
+
+	if err := h.usecase.DeleteRole(c.Context(), roleID); err != nil {
+		if err.Error() == "role not found" {
 			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
@@ -170,25 +1073,131 @@ func (h *authHandler) AssignRoles(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(response.Ok)
 }
 
-// GetUserRoles retrieves all roles assigned to a user
-func (h *authHandler) GetUserRoles(c *fiber.Ctx) error {
-	userIDStr := c.Params("id")
-	if userIDStr == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("user id is required")))
+// GetUsersByRole lists the users having a role
+func (h *authHandler) GetUsersByRole(c *fiber.Ctx) error {
+	roleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid role id format")))
 	}
 
-	userID, err := uuid.Parse(userIDStr)
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	users, total, err := h.usecase.GetUsersByRole(c.Context(), roleID, page, limit)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid user id format")))
+		if err.Error() == "role not found" {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
 	}
 
-	roles, err := h.usecase.GetUserRoles(c.Context(), userID)
+	listResponse := response.ListResponse{
+		Meta: response.MetaResponse{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+		},
+		Data: users,
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(listResponse))
+}
+
+// ListPermissions retrieves all permissions
+func (h *authHandler) ListPermissions(c *fiber.Ctx) error {
+	permissions, err := h.usecase.ListPermissions(c.Context())
 	if err != nil {
-		if err.Error() == "user not found" {
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(permissions))
+}
+
+// GetRolePermissions retrieves the permissions granted to a role
+func (h *authHandler) GetRolePermissions(c *fiber.Ctx) error {
+	roleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid role id format")))
+	}
+
+	permissions, err := h.usecase.GetRolePermissions(c.Context(), roleID)
+	if err != nil {
+		if err.Error() == "role not found" {
 			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
 	}
 
-	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(roles))
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(permissions))
+}
+
+// SetRolePermissions replaces the permissions granted to a role
+func (h *authHandler) SetRolePermissions(c *fiber.Ctx) error {
+	roleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid role id format")))
+	}
+
+	var req domain.RolePermissionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	if err := h.usecase.SetRolePermissions(c.Context(), roleID, req.PermissionNames); err != nil {
+		if err.Error() == "role not found" || err.Error() == "permission not found" {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
+
+// ListAuditLogs lists authentication audit logs, optionally filtered by event and user
+func (h *authHandler) ListAuditLogs(c *fiber.Ctx) error {
+	filter := &domain.AuditLogFilter{Event: c.Query("event")}
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(fmt.Errorf("invalid user id format")))
+		}
+		filter.UserID = &userID
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	logs, total, err := h.usecase.ListAuditLogs(c.Context(), filter, page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	listResponse := response.ListResponse{
+		Meta: response.MetaResponse{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+		},
+		Data: logs,
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(listResponse))
 }
@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/pkg/app_log"
+	"github.com/gomajido/hospital-cms-golang/pkg/db/redis"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	userTokenCacheKey   = "auth:user_token:%s"
+	userTokensCacheKey  = "auth:user_tokens:%s"
+	tokenFamilyCacheKey = "auth:token_family:%s"
+
+	revokedTokenKey  = "auth:revoked_token:%s"
+	revokedUserKey   = "auth:revoked_user:%s"
+	revokedFamilyKey = "auth:revoked_family:%s"
+
+	// userTokenCacheMaxTTL bounds how long a token stays cached even when it lives longer
+	userTokenCacheMaxTTL = time.Minute * 15
+
+	// revocationMarkerTTL bounds how long a lookup started before a revocation may take
+	// to fill the cache. Until the marker expires the affected tokens are read from MySQL.
+	revocationMarkerTTL = time.Minute
+)
+
+// cacheUserTokenScript fills the cache only when no revocation marker covers the token.
+// A lookup racing a revocation may have read the row before it was invalidated; the
+// revocation writes its marker before evicting, so the stale row is either refused here
+// or written early enough to be evicted.
+var cacheUserTokenScript = goredis.NewScript(`
+if redis.call('EXISTS', KEYS[4], KEYS[5], KEYS[6]) > 0 then
+	return 0
+end
+
+redis.call('SADD', KEYS[2], KEYS[1])
+redis.call('PEXPIRE', KEYS[2], ARGV[3])
+redis.call('SADD', KEYS[3], KEYS[1])
+redis.call('PEXPIRE', KEYS[3], ARGV[3])
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return 1
+`)
+
+// authCacheRepository caches user tokens in Redis in front of the MySQL repository.
+// Every other operation is delegated to the wrapped repository.
+type authCacheRepository struct {
+	domain.AuthRepository
+	redis *redis.Redis
+}
+
+// cachedUserToken keeps the token digest, which UserToken never serializes
+type cachedUserToken struct {
+	domain.UserToken
+	TokenHash string `json:"token_hash"`
+}
+
+// NewAuthCacheRepository wraps an auth repository with a Redis token cache
+func NewAuthCacheRepository(repo domain.AuthRepository, redis *redis.Redis) domain.AuthRepository {
+	return &authCacheRepository{
+		AuthRepository: repo,
+		redis:          redis,
+	}
+}
+
+func (r *authCacheRepository) GetUserTokenByID(ctx context.Context, tokenID uuid.UUID) (*domain.UserToken, error) {
+	key := fmt.Sprintf(userTokenCacheKey, tokenID)
+
+	val, err := r.redis.Client.Get(ctx, key).Result()
+	if err == nil {
+		var cached cachedUserToken
+		if err := json.Unmarshal([]byte(val), &cached); err == nil {
+			cached.UserToken.Token = cached.TokenHash
+			return &cached.UserToken, nil
+		}
+	} else if err != goredis.Nil {
+		app_log.Errorf("Failed to read user token cache: %v", err)
+	}
+
+	userToken, err := r.AuthRepository.GetUserTokenByID(ctx, tokenID)
+	if err != nil || userToken == nil {
+		return userToken, err
+	}
+
+	r.cacheUserToken(ctx, userToken)
+
+	return userToken, nil
+}
+
+func (r *authCacheRepository) InvalidateUserToken(ctx context.Context, tokenID uuid.UUID) error {
+	if err := r.AuthRepository.InvalidateUserToken(ctx, tokenID); err != nil {
+		return err
+	}
+
+	r.revoke(ctx, fmt.Sprintf(revokedTokenKey, tokenID))
+	r.evict(ctx, fmt.Sprintf(userTokenCacheKey, tokenID))
+	return nil
+}
+
+func (r *authCacheRepository) InvalidateUserTokens(ctx context.Context, userID uuid.UUID) error {
+	if err := r.AuthRepository.InvalidateUserTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	r.revoke(ctx, fmt.Sprintf(revokedUserKey, userID))
+	r.evictSet(ctx, fmt.Sprintf(userTokensCacheKey, userID))
+	return nil
+}
+
+func (r *authCacheRepository) InvalidateOtherUserTokens(ctx context.Context, userID, keepFamilyID uuid.UUID) error {
+	if err := r.AuthRepository.InvalidateOtherUserTokens(ctx, userID, keepFamilyID); err != nil {
+		return err
+	}
+
+	// The kept tokens are evicted as well and simply reloaded on their next use
+	r.revoke(ctx, fmt.Sprintf(revokedUserKey, userID))
+	r.evictSet(ctx, fmt.Sprintf(userTokensCacheKey, userID))
+	return nil
+}
+
+func (r *authCacheRepository) InvalidateTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	if err := r.AuthRepository.InvalidateTokenFamily(ctx, familyID); err != nil {
+		return err
+	}
+
+	// Tokens issued before rotation existed use their own ID as family
+	r.revoke(ctx, fmt.Sprintf(revokedFamilyKey, familyID))
+	r.evict(ctx, fmt.Sprintf(userTokenCacheKey, familyID))
+	r.evictSet(ctx, fmt.Sprintf(tokenFamilyCacheKey, familyID))
+	return nil
+}
+
+func (r *authCacheRepository) MarkUserTokenUsed(ctx context.Context, tokenID uuid.UUID) (bool, error) {
+	marked, err := r.AuthRepository.MarkUserTokenUsed(ctx, tokenID)
+	if err != nil {
+		return false, err
+	}
+
+	r.revoke(ctx, fmt.Sprintf(revokedTokenKey, tokenID))
+	r.evict(ctx, fmt.Sprintf(userTokenCacheKey, tokenID))
+	return marked, nil
+}
+
+func (r *authCacheRepository) UseLoginRecoveryCode(ctx context.Context, tokenID, userID uuid.UUID, code string) (bool, bool, error) {
+	claimed, used, err := r.AuthRepository.UseLoginRecoveryCode(ctx, tokenID, userID, code)
+	if err != nil {
+		return false, false, err
+	}
+
+	if used {
+		r.revoke(ctx, fmt.Sprintf(revokedTokenKey, tokenID))
+		r.evict(ctx, fmt.Sprintf(userTokenCacheKey, tokenID))
+	}
+	return claimed, used, nil
+}
+
+func (r *authCacheRepository) UpdateUserTokensAbility(ctx context.Context, userID uuid.UUID, ability []string) error {
+	if err := r.AuthRepository.UpdateUserTokensAbility(ctx, userID, ability); err != nil {
+		return err
+	}
+
+	r.revoke(ctx, fmt.Sprintf(revokedUserKey, userID))
+	r.evictSet(ctx, fmt.Sprintf(userTokensCacheKey, userID))
+	return nil
+}
+
+func (r *authCacheRepository) TouchUserToken(ctx context.Context, tokenID uuid.UUID, client domain.ClientInfo) error {
+	if err := r.AuthRepository.TouchUserToken(ctx, tokenID, client); err != nil {
+		return err
+	}
+
+	// Reloaded with the new activity, so the next requests skip the write
+	r.evict(ctx, fmt.Sprintf(userTokenCacheKey, tokenID))
+	return nil
+}
+
+// cacheUserToken stores a token until it expires, and indexes it by user and
+// family so bulk revocations can find it. Tokens under a revocation marker are
+// not stored. Failures only cost a cache miss.
+func (r *authCacheRepository) cacheUserToken(ctx context.Context, userToken *domain.UserToken) {
+	ttl := time.Until(userToken.ExpiredAt)
+	if ttl <= 0 {
+		return
+	}
+	if ttl > userTokenCacheMaxTTL {
+		ttl = userTokenCacheMaxTTL
+	}
+
+	val, err := json.Marshal(cachedUserToken{UserToken: *userToken, TokenHash: userToken.Token})
+	if err != nil {
+		app_log.Errorf("Failed to marshal user token cache: %v", err)
+		return
+	}
+
+	keys := []string{
+		fmt.Sprintf(userTokenCacheKey, userToken.ID),
+		fmt.Sprintf(userTokensCacheKey, userToken.UserID),
+		fmt.Sprintf(tokenFamilyCacheKey, userToken.FamilyID),
+		fmt.Sprintf(revokedTokenKey, userToken.ID),
+		fmt.Sprintf(revokedUserKey, userToken.UserID),
+		fmt.Sprintf(revokedFamilyKey, userToken.FamilyID),
+	}
+	err = cacheUserTokenScript.Run(ctx, r.redis.Client, keys,
+		val, ttl.Milliseconds(), userTokenCacheMaxTTL.Milliseconds()).Err()
+	if err != nil {
+		app_log.Errorf("Failed to write user token cache: %v", err)
+	}
+}
+
+// revoke writes a revocation marker before the matching cache entries are evicted,
+// so lookups that read the row before the revocation cannot cache it again
+func (r *authCacheRepository) revoke(ctx context.Context, key string) {
+	if err := r.redis.Client.Set(ctx, key, 1, revocationMarkerTTL).Err(); err != nil {
+		app_log.Errorf("Failed to write user token revocation marker: %v", err)
+	}
+}
+
+// evict removes cached tokens by key
+func (r *authCacheRepository) evict(ctx context.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	if err := r.redis.Client.Del(ctx, keys...).Err(); err != nil {
+		app_log.Errorf("Failed to evict user token cache: %v", err)
+	}
+}
+
+// evictSet removes every cached token referenced by an index set, then the set itself
+func (r *authCacheRepository) evictSet(ctx context.Context, setKey string) {
+	keys, err := r.redis.Client.SMembers(ctx, setKey).Result()
+	if err != nil {
+		app_log.Errorf("Failed to read user token cache index: %v", err)
+		return
+	}
+
+	r.evict(ctx, append(keys, setKey)...)
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain/mocks"
+	"github.com/gomajido/hospital-cms-golang/pkg/db/redis"
+)
+
+func newTestCacheRepository(t *testing.T) (*miniredis.Miniredis, *mocks.MockAuthRepository, domain.AuthRepository) {
+	server := miniredis.RunT(t)
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockAuthRepository(ctrl)
+	client := &redis.Redis{Client: goredis.NewClient(&goredis.Options{Addr: server.Addr()})}
+
+	return server, repo, NewAuthCacheRepository(repo, client)
+}
+
+func newTestUserToken(lifetime time.Duration) *domain.UserToken {
+	id := uuid.New()
+	return &domain.UserToken{
+		ID:        id,
+		UserID:    uuid.New(),
+		Token:     "digest",
+		Type:      "access",
+		FamilyID:  id,
+		Ability:   []string{"article:write"},
+		ExpiredAt: time.Now().Add(lifetime),
+	}
+}
+
+func TestAuthCacheRepository_GetUserTokenByID(t *testing.T) {
+	server, repo, cache := newTestCacheRepository(t)
+	ctx := context.Background()
+	userToken := newTestUserToken(5 * time.Minute)
+
+	// Only the first lookup reaches the database
+	repo.EXPECT().GetUserTokenByID(gomock.Any(), userToken.ID).Return(userToken, nil).Times(1)
+
+	for i := 0; i < 2; i++ {
+		got, err := cache.GetUserTokenByID(ctx, userToken.ID)
+		if err != nil {
+			t.Fatalf("GetUserTokenByID() unexpected error = %v", err)
+		}
+		if got.Token != userToken.Token || got.UserID != userToken.UserID || len(got.Ability) != 1 {
+			t.Errorf("GetUserTokenByID() = %+v, want %+v", got, userToken)
+		}
+	}
+
+	ttl := server.TTL("auth:user_token:" + userToken.ID.String())
+	if ttl <= 0 || ttl > 5*time.Minute {
+		t.Errorf("cache TTL = %v, want bounded by expired_at", ttl)
+	}
+}
+
+func TestAuthCacheRepository_TTLIsCapped(t *testing.T) {
+	server, repo, cache := newTestCacheRepository(t)
+	userToken := newTestUserToken(24 * time.Hour)
+
+	repo.EXPECT().GetUserTokenByID(gomock.Any(), userToken.ID).Return(userToken, nil)
+
+	if _, err := cache.GetUserTokenByID(context.Background(), userToken.ID); err != nil {
+		t.Fatalf("GetUserTokenByID() unexpected error = %v", err)
+	}
+
+	if ttl := server.TTL("auth:user_token:" + userToken.ID.String()); ttl > userTokenCacheMaxTTL {
+		t.Errorf("cache TTL = %v, want at most %v", ttl, userTokenCacheMaxTTL)
+	}
+}
+
+func TestAuthCacheRepository_Eviction(t *testing.T) {
+	tests := []struct {
+		name       string
+		invalidate func(ctx context.Context, repo *mocks.MockAuthRepository, cache domain.AuthRepository, userToken *domain.UserToken) error
+	}{
+		{
+			name: "InvalidateUserToken",
+			invalidate: func(ctx context.Context, repo *mocks.MockAuthRepository, cache domain.AuthRepository, userToken *domain.UserToken) error {
+				repo.EXPECT().InvalidateUserToken(gomock.Any(), userToken.ID).Return(nil)
+				return cache.InvalidateUserToken(ctx, userToken.ID)
+			},
+		},
+		{
+			name: "InvalidateUserTokens",
+			invalidate: func(ctx context.Context, repo *mocks.MockAuthRepository, cache domain.AuthRepository, userToken *domain.UserToken) error {
+				repo.EXPECT().InvalidateUserTokens(gomock.Any(), userToken.UserID).Return(nil)
+				return cache.InvalidateUserTokens(ctx, userToken.UserID)
+			},
+		},
+		{
+			name: "InvalidateOtherUserTokens",
+			invalidate: func(ctx context.Context, repo *mocks.MockAuthRepository, cache domain.AuthRepository, userToken *domain.UserToken) error {
+				keep := uuid.New()
+				repo.EXPECT().InvalidateOtherUserTokens(gomock.Any(), userToken.UserID, keep).Return(nil)
+				return cache.InvalidateOtherUserTokens(ctx, userToken.UserID, keep)
+			},
+		},
+		{
+			name: "InvalidateTokenFamily",
+			invalidate: func(ctx context.Context, repo *mocks.MockAuthRepository, cache domain.AuthRepository, userToken *domain.UserToken) error {
+				repo.EXPECT().InvalidateTokenFamily(gomock.Any(), userToken.FamilyID).Return(nil)
+				return cache.InvalidateTokenFamily(ctx, userToken.FamilyID)
+			},
+		},
+		{
+			name: "UpdateUserTokensAbility",
+			invalidate: func(ctx context.Context, repo *mocks.MockAuthRepository, cache domain.AuthRepository, userToken *domain.UserToken) error {
+				repo.EXPECT().UpdateUserTokensAbility(gomock.Any(), userToken.UserID, []string{}).Return(nil)
+				return cache.UpdateUserTokensAbility(ctx, userToken.UserID, []string{})
+			},
+		},
+		{
+			name: "TouchUserToken",
+			invalidate: func(ctx context.Context, repo *mocks.MockAuthRepository, cache domain.AuthRepository, userToken *domain.UserToken) error {
+				client := domain.ClientInfo{IPAddress: "10.0.0.1", UserAgent: "curl/8.0"}
+				repo.EXPECT().TouchUserToken(gomock.Any(), userToken.ID, client).Return(nil)
+				return cache.TouchUserToken(ctx, userToken.ID, client)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, repo, cache := newTestCacheRepository(t)
+			ctx := context.Background()
+			userToken := newTestUserToken(5 * time.Minute)
+
+			repo.EXPECT().GetUserTokenByID(gomock.Any(), userToken.ID).Return(userToken, nil)
+			if _, err := cache.GetUserTokenByID(ctx, userToken.ID); err != nil {
+				t.Fatalf("GetUserTokenByID() unexpected error = %v", err)
+			}
+
+			if err := tt.invalidate(ctx, repo, cache, userToken); err != nil {
+				t.Fatalf("%s() unexpected error = %v", tt.name, err)
+			}
+
+			if server.Exists("auth:user_token:" + userToken.ID.String()) {
+				t.Errorf("%s() left the token in the cache", tt.name)
+			}
+		})
+	}
+}
+
+func TestAuthCacheRepository_InvalidateDuringMiss(t *testing.T) {
+	tests := []struct {
+		name       string
+		invalidate func(ctx context.Context, repo *mocks.MockAuthRepository, cache domain.AuthRepository, userToken *domain.UserToken) error
+	}{
+		{
+			name: "InvalidateUserToken",
+			invalidate: func(ctx context.Context, repo *mocks.MockAuthRepository, cache domain.AuthRepository, userToken *domain.UserToken) error {
+				repo.EXPECT().InvalidateUserToken(gomock.Any(), userToken.ID).Return(nil)
+				return cache.InvalidateUserToken(ctx, userToken.ID)
+			},
+		},
+		{
+			name: "InvalidateUserTokens",
+			invalidate: func(ctx context.Context, repo *mocks.MockAuthRepository, cache domain.AuthRepository, userToken *domain.UserToken) error {
+				repo.EXPECT().InvalidateUserTokens(gomock.Any(), userToken.UserID).Return(nil)
+				return cache.InvalidateUserTokens(ctx, userToken.UserID)
+			},
+		},
+		{
+			name: "InvalidateTokenFamily",
+			invalidate: func(ctx context.Context, repo *mocks.MockAuthRepository, cache domain.AuthRepository, userToken *domain.UserToken) error {
+				repo.EXPECT().InvalidateTokenFamily(gomock.Any(), userToken.FamilyID).Return(nil)
+				return cache.InvalidateTokenFamily(ctx, userToken.FamilyID)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, repo, cache := newTestCacheRepository(t)
+			ctx := context.Background()
+			userToken := newTestUserToken(5 * time.Minute)
+
+			// The row is read while still valid, then revoked before the lookup fills the cache
+			repo.EXPECT().GetUserTokenByID(gomock.Any(), userToken.ID).DoAndReturn(
+				func(ctx context.Context, _ uuid.UUID) (*domain.UserToken, error) {
+					if err := tt.invalidate(ctx, repo, cache, userToken); err != nil {
+						t.Fatalf("%s() unexpected error = %v", tt.name, err)
+					}
+					return userToken, nil
+				})
+
+			if _, err := cache.GetUserTokenByID(ctx, userToken.ID); err != nil {
+				t.Fatalf("GetUserTokenByID() unexpected error = %v", err)
+			}
+
+			if server.Exists("auth:user_token:" + userToken.ID.String()) {
+				t.Errorf("%s() during a cache miss let the stale token be cached", tt.name)
+			}
+
+			// Once the marker expires tokens are cached again
+			server.FastForward(revocationMarkerTTL)
+			repo.EXPECT().GetUserTokenByID(gomock.Any(), userToken.ID).Return(userToken, nil)
+			if _, err := cache.GetUserTokenByID(ctx, userToken.ID); err != nil {
+				t.Fatalf("GetUserTokenByID() unexpected error = %v", err)
+			}
+			if !server.Exists("auth:user_token:" + userToken.ID.String()) {
+				t.Errorf("GetUserTokenByID() after the marker expired did not cache the token")
+			}
+		})
+	}
+}
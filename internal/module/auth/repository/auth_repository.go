@@ -7,11 +7,16 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
 	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
 	"github.com/google/uuid"
 )
 
+// likeEscaper escapes the wildcards of a LIKE pattern
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 type authRepository struct {
 	db *sql.DB
 }
@@ -24,12 +29,15 @@ func NewAuthRepository(db *sql.DB) domain.AuthRepository {
 
 func (r *authRepository) CreateUser(ctx context.Context, user *domain.User) error {
 	query := `
-		INSERT INTO users (id, email, password, name, phone, status, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())
+		INSERT INTO users (id, email, password, name, phone, status, register_from, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
 	`
 
 	user.ID = uuid.New()
-	user.Status = "inactive" // Default status
+	user.Status = constant.USER_STATUS_INACTIVE // Default status until the email is verified
+	if user.RegisterFrom == "" {
+		user.RegisterFrom = "web"
+	}
 
 	_, err := r.db.ExecContext(ctx, query,
 		user.ID,
@@ -38,6 +46,7 @@ func (r *authRepository) CreateUser(ctx context.Context, user *domain.User) erro
 		user.Name,
 		user.Phone,
 		user.Status,
+		user.RegisterFrom,
 	)
 
 	if err != nil {
@@ -49,7 +58,7 @@ func (r *authRepository) CreateUser(ctx context.Context, user *domain.User) erro
 
 func (r *authRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, email, password, name, phone, status, email_verified_at, created_at, updated_at
+		SELECT id, email, password, name, phone, status, register_from, email_verified_at, locked_until, created_at, updated_at
 		FROM users
 		WHERE email = ? AND deleted_at IS NULL
 	`
@@ -62,7 +71,9 @@ func (r *authRepository) GetUserByEmail(ctx context.Context, email string) (*dom
 		&user.Name,
 		&user.Phone,
 		&user.Status,
+		&user.RegisterFrom,
 		&user.EmailVerifiedAt,
+		&user.LockedUntil,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -85,16 +96,28 @@ func (r *authRepository) GetUserByEmail(ctx context.Context, email string) (*dom
 }
 
 func (r *authRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	return r.getUserByID(ctx, id, false)
+}
+
+// GetUserByIDWithDeleted retrieves a user even when it was soft-deleted, for administrators
+func (r *authRepository) GetUserByIDWithDeleted(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	return r.getUserByID(ctx, id, true)
+}
+
+func (r *authRepository) getUserByID(ctx context.Context, id uuid.UUID, withDeleted bool) (*domain.User, error) {
 	query := `
-		SELECT id, email, password, name, phone, status, created_at, updated_at
+		SELECT id, email, password, name, phone, status, register_from, email_verified_at, locked_until, created_at, updated_at, deleted_at
 		FROM users
 		WHERE id = ?
 	`
+	if !withDeleted {
+		query += " AND deleted_at IS NULL"
+	}
 
 	user := &domain.User{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&user.ID, &user.Email, &user.Password, &user.Name,
-		&user.Phone, &user.Status, &user.CreatedAt, &user.UpdatedAt,
+		&user.ID, &user.Email, &user.Password, &user.Name, &user.Phone, &user.Status, &user.RegisterFrom,
+		&user.EmailVerifiedAt, &user.LockedUntil, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -145,6 +168,144 @@ func (r *authRepository) UpdateUser(ctx context.Context, user *domain.User) erro
 	return nil
 }
 
+// MarkUserEmailVerified records the email verification and activates a pending account
+func (r *authRepository) MarkUserEmailVerified(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE users
+		SET email_verified_at = COALESCE(email_verified_at, NOW()),
+			status = IF(status = ?, ?, status),
+			updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, constant.USER_STATUS_INACTIVE, constant.USER_STATUS_ACTIVE, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+func (r *authRepository) UpdateUserPassword(ctx context.Context, id uuid.UUID, password string) error {
+	query := `
+		UPDATE users
+		SET password = ?, updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, password, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+// UpdateUserEmail moves a user to a confirmed new address
+func (r *authRepository) UpdateUserEmail(ctx context.Context, id uuid.UUID, email string) error {
+	query := `
+		UPDATE users
+		SET email = ?, email_verified_at = NOW(), updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, email, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+// LockUser blocks logins for a user until the given time
+func (r *authRepository) LockUser(ctx context.Context, id uuid.UUID, until time.Time) error {
+	query := `
+		UPDATE users
+		SET locked_until = ?, updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	_, err := r.db.ExecContext(ctx, query, until, id)
+	return err
+}
+
+// UnlockUser clears a lockout before it runs out
+func (r *authRepository) UnlockUser(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE users
+		SET locked_until = NULL, updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+// RestoreUser undoes the soft deletion of a user
+func (r *authRepository) RestoreUser(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE users
+		SET deleted_at = NULL, updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NOT NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
 func (r *authRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	query := `
 		UPDATE users
@@ -169,6 +330,118 @@ func (r *authRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+func (r *authRepository) ListRoles(ctx context.Context) ([]domain.Role, error) {
+	query := `
+		SELECT id, name, description, mfa_required, created_at, updated_at
+		FROM roles
+		WHERE deleted_at IS NULL
+		ORDER BY name ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []domain.Role
+	for rows.Next() {
+		var role domain.Role
+		var description sql.NullString
+		err := rows.Scan(
+			&role.ID,
+			&role.Name,
+			&description,
+			&role.MFARequired,
+			&role.CreatedAt,
+			&role.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		role.Description = description.String
+		roles = append(roles, role)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (r *authRepository) CreateRole(ctx context.Context, role *domain.Role) error {
+	query := `
+		INSERT INTO roles (id, name, description, mfa_required, created_at, updated_at)
+		VALUES (?, ?, ?, ?, NOW(), NOW())
+	`
+
+	role.ID = uuid.New()
+
+	_, err := r.db.ExecContext(ctx, query, role.ID, role.Name, role.Description, role.MFARequired)
+	return err
+}
+
+func (r *authRepository) UpdateRole(ctx context.Context, role *domain.Role) error {
+	query := `
+		UPDATE roles
+		SET name = ?, description = ?, mfa_required = ?, updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, role.Name, role.Description, role.MFARequired, role.ID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return errors.New("role not found")
+	}
+
+	return nil
+}
+
+func (r *authRepository) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// The name gets a suffix so it can be reused by a new role
+	query := `
+		UPDATE roles
+		SET name = CONCAT(LEFT(name, 200), '#deleted#', id), deleted_at = NOW(), updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	result, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return errors.New("role not found")
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM user_roles WHERE role_id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (r *authRepository) GetRolesByNames(ctx context.Context, names []string) ([]domain.Role, error) {
 	// Create placeholders for the IN clause
 	placeholders := make([]string, len(names))
@@ -179,7 +452,7 @@ func (r *authRepository) GetRolesByNames(ctx context.Context, names []string) ([
 	}
 
 	query := fmt.Sprintf(`
-		SELECT id, name, description, created_at, updated_at
+		SELECT id, name, description, mfa_required, created_at, updated_at
 		FROM roles
 		WHERE name IN (%s) AND deleted_at IS NULL
 	`, strings.Join(placeholders, ","))
@@ -197,6 +470,7 @@ func (r *authRepository) GetRolesByNames(ctx context.Context, names []string) ([
 			&role.ID,
 			&role.Name,
 			&role.Description,
+			&role.MFARequired,
 			&role.CreatedAt,
 			&role.UpdatedAt,
 		)
@@ -215,14 +489,14 @@ func (r *authRepository) GetRolesByNames(ctx context.Context, names []string) ([
 
 func (r *authRepository) GetRoleByID(ctx context.Context, id uuid.UUID) (*domain.Role, error) {
 	query := `
-		SELECT id, name, description, created_at, updated_at
+		SELECT id, name, description, mfa_required, created_at, updated_at
 		FROM roles
-		WHERE id = ?
+		WHERE id = ? AND deleted_at IS NULL
 	`
 
 	role := &domain.Role{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&role.ID, &role.Name, &role.Description,
+		&role.ID, &role.Name, &role.Description, &role.MFARequired,
 		&role.CreatedAt, &role.UpdatedAt,
 	)
 
@@ -244,16 +518,13 @@ func (r *authRepository) AssignRolesToUser(ctx context.Context, userID uuid.UUID
 	}
 	defer tx.Rollback()
 
-	// Delete existing roles
-	_, err = tx.ExecContext(ctx, "DELETE FROM user_roles WHERE user_id = ?", userID)
-	if err != nil {
-		return err
-	}
-
-	// Insert new roles
-	query := "INSERT INTO user_roles (user_id, role_id) VALUES (?, ?)"
+	// Insert new roles, roles the user already has are kept as they are
+	query := `
+		INSERT INTO user_roles (id, user_id, role_id, created_at, updated_at)
+		VALUES (?, ?, ?, NOW(), NOW())
+		ON DUPLICATE KEY UPDATE deleted_at = NULL, updated_at = NOW()`
 	for _, roleID := range roleIDs {
-		_, err = tx.ExecContext(ctx, query, userID, roleID)
+		_, err = tx.ExecContext(ctx, query, uuid.New(), userID, roleID)
 		if err != nil {
 			return err
 		}
@@ -262,12 +533,34 @@ func (r *authRepository) AssignRolesToUser(ctx context.Context, userID uuid.UUID
 	return tx.Commit()
 }
 
+func (r *authRepository) RevokeRolesFromUser(ctx context.Context, userID uuid.UUID, roleIDs []uuid.UUID) error {
+	if len(roleIDs) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(roleIDs))
+	args := make([]interface{}, 0, len(roleIDs)+1)
+	args = append(args, userID)
+	for i, roleID := range roleIDs {
+		placeholders[i] = "?"
+		args = append(args, roleID)
+	}
+
+	query := fmt.Sprintf(`
+		DELETE FROM user_roles
+		WHERE user_id = ? AND role_id IN (%s)
+	`, strings.Join(placeholders, ","))
+
+	_, err := r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
 func (r *authRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]domain.Role, error) {
 	query := `
-		SELECT r.id, r.name, r.description
+		SELECT r.id, r.name, r.description, r.mfa_required
 		FROM roles r
 		INNER JOIN user_roles ur ON ur.role_id = r.id
-		WHERE ur.user_id = ?
+		WHERE ur.user_id = ? AND ur.deleted_at IS NULL AND r.deleted_at IS NULL
 	`
 
 	rows, err := r.db.QueryContext(ctx, query, userID)
@@ -279,7 +572,7 @@ func (r *authRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]
 	var roles []domain.Role
 	for rows.Next() {
 		var role domain.Role
-		if err := rows.Scan(&role.ID, &role.Name, &role.Description); err != nil {
+		if err := rows.Scan(&role.ID, &role.Name, &role.Description, &role.MFARequired); err != nil {
 			return nil, err
 		}
 		roles = append(roles, role)
@@ -292,46 +585,353 @@ func (r *authRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]
 	return roles, nil
 }
 
-func (r *authRepository) CreateUserToken(ctx context.Context, token *domain.UserToken) (*domain.UserToken, error) {
-	query := `
-		INSERT INTO user_tokens (id, user_id, token, ability, expired_at, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, NOW(), NOW())`
+// ListUsers pages through users matching the filter, newest first
+func (r *authRepository) ListUsers(ctx context.Context, filter *domain.UserFilter, page, limit int) ([]domain.User, int64, error) {
+	where := []string{"u.deleted_at IS NULL"}
+	args := []interface{}{}
 
-	abilityJSON, err := json.Marshal(token.Ability)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal ability: %w", err)
+	if filter != nil {
+		if filter.Deleted {
+			where[0] = "u.deleted_at IS NOT NULL"
+		}
+		if filter.Status != "" {
+			where = append(where, "u.status = ?")
+			args = append(args, filter.Status)
+		}
+		if filter.RegisterFrom != "" {
+			where = append(where, "u.register_from = ?")
+			args = append(args, filter.RegisterFrom)
+		}
+		if filter.Role != "" {
+			where = append(where, `EXISTS (
+				SELECT 1 FROM user_roles ur
+				INNER JOIN roles ro ON ro.id = ur.role_id AND ro.deleted_at IS NULL
+				WHERE ur.user_id = u.id AND ur.deleted_at IS NULL AND ro.name = ?)`)
+			args = append(args, filter.Role)
+		}
+		if filter.Search != "" {
+			pattern := "%" + likeEscaper.Replace(filter.Search) + "%"
+			where = append(where, "(u.email LIKE ? OR u.name LIKE ?)")
+			args = append(args, pattern, pattern)
+		}
 	}
+	whereClause := strings.Join(where, " AND ")
 
-	_, err = r.db.ExecContext(ctx, query,
-		token.ID,
-		token.UserID,
-		token.Token,
-		abilityJSON,
-		token.ExpiredAt,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create user token: %w", err)
+	var total int64
+	countQuery := "SELECT COUNT(*) FROM users u WHERE " + whereClause
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
 	}
 
-	return token, nil
-}
-
-func (r *authRepository) GetUserTokenByID(ctx context.Context, tokenID uuid.UUID) (*domain.UserToken, error) {
 	query := `
-		SELECT id, user_id, token, ability, expired_at, created_at, updated_at, deleted_at
-		FROM user_tokens
-		WHERE id = ? AND deleted_at IS NULL`
-
-	userToken := &domain.UserToken{}
-	var abilityJSON []byte
-	var deletedAt sql.NullTime
+		SELECT u.id, u.email, u.name, u.phone, u.status, u.register_from, u.email_verified_at, u.locked_until, u.created_at, u.updated_at, u.deleted_at
+		FROM users u
+		WHERE ` + whereClause + `
+		ORDER BY u.created_at DESC
+		LIMIT ? OFFSET ?`
+
+	offset := (page - 1) * limit
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
 
-	err := r.db.QueryRowContext(ctx, query, tokenID).Scan(
+	users := []domain.User{}
+	for rows.Next() {
+		var user domain.User
+		var phone sql.NullString
+		err := rows.Scan(
+			&user.ID,
+			&user.Email,
+			&user.Name,
+			&phone,
+			&user.Status,
+			&user.RegisterFrom,
+			&user.EmailVerifiedAt,
+			&user.LockedUntil,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.DeletedAt,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		user.Phone = phone.String
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+func (r *authRepository) GetUsersByRoleID(ctx context.Context, roleID uuid.UUID, page, limit int) ([]domain.User, int64, error) {
+	var total int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM users u
+		INNER JOIN user_roles ur ON ur.user_id = u.id
+		WHERE ur.role_id = ? AND ur.deleted_at IS NULL AND u.deleted_at IS NULL
+	`
+	if err := r.db.QueryRowContext(ctx, countQuery, roleID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT u.id, u.email, u.name, u.phone, u.status, u.email_verified_at, u.created_at, u.updated_at
+		FROM users u
+		INNER JOIN user_roles ur ON ur.user_id = u.id
+		WHERE ur.role_id = ? AND ur.deleted_at IS NULL AND u.deleted_at IS NULL
+		ORDER BY u.name ASC
+		LIMIT ? OFFSET ?
+	`
+
+	offset := (page - 1) * limit
+	rows, err := r.db.QueryContext(ctx, query, roleID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var user domain.User
+		var phone sql.NullString
+		err := rows.Scan(
+			&user.ID,
+			&user.Email,
+			&user.Name,
+			&phone,
+			&user.Status,
+			&user.EmailVerifiedAt,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		user.Phone = phone.String
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+func (r *authRepository) GetUserIDsByRoleID(ctx context.Context, roleID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT user_id
+		FROM user_roles
+		WHERE role_id = ? AND deleted_at IS NULL
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, roleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []uuid.UUID
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
+
+func (r *authRepository) ListPermissions(ctx context.Context) ([]domain.Permission, error) {
+	query := `
+		SELECT id, name, description
+		FROM permissions
+		ORDER BY name ASC
+	`
+
+	return r.queryPermissions(ctx, query)
+}
+
+func (r *authRepository) GetPermissionsByNames(ctx context.Context, names []string) ([]domain.Permission, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(names))
+	args := make([]interface{}, len(names))
+	for i := range names {
+		placeholders[i] = "?"
+		args[i] = names[i]
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, name, description
+		FROM permissions
+		WHERE name IN (%s)
+	`, strings.Join(placeholders, ","))
+
+	return r.queryPermissions(ctx, query, args...)
+}
+
+func (r *authRepository) GetRolePermissions(ctx context.Context, roleID uuid.UUID) ([]domain.Permission, error) {
+	query := `
+		SELECT p.id, p.name, p.description
+		FROM permissions p
+		INNER JOIN role_permissions rp ON rp.permission_id = p.id
+		WHERE rp.role_id = ?
+		ORDER BY p.name ASC
+	`
+
+	return r.queryPermissions(ctx, query, roleID)
+}
+
+func (r *authRepository) SetRolePermissions(ctx context.Context, roleID uuid.UUID, permissionIDs []uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Delete existing permissions
+	_, err = tx.ExecContext(ctx, "DELETE FROM role_permissions WHERE role_id = ?", roleID)
+	if err != nil {
+		return err
+	}
+
+	// Insert new permissions
+	query := "INSERT INTO role_permissions (id, role_id, permission_id) VALUES (?, ?, ?)"
+	for _, permissionID := range permissionIDs {
+		_, err = tx.ExecContext(ctx, query, uuid.New(), roleID, permissionID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *authRepository) GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	query := `
+		SELECT DISTINCT p.name
+		FROM permissions p
+		INNER JOIN role_permissions rp ON rp.permission_id = p.id
+		INNER JOIN roles r ON r.id = rp.role_id
+		INNER JOIN user_roles ur ON ur.role_id = r.id
+		WHERE ur.user_id = ? AND ur.deleted_at IS NULL AND r.deleted_at IS NULL
+		ORDER BY p.name ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var permissions []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, name)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+func (r *authRepository) queryPermissions(ctx context.Context, query string, args ...interface{}) ([]domain.Permission, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var permissions []domain.Permission
+	for rows.Next() {
+		var permission domain.Permission
+		var description sql.NullString
+		if err := rows.Scan(&permission.ID, &permission.Name, &description); err != nil {
+			return nil, err
+		}
+		permission.Description = description.String
+		permissions = append(permissions, permission)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+func (r *authRepository) CreateUserToken(ctx context.Context, token *domain.UserToken) (*domain.UserToken, error) {
+	query := `
+		INSERT INTO user_tokens (id, user_id, token, type, family_id, ability, user_agent, ip_address, expired_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, NULLIF(?, ''), NULLIF(?, ''), ?, NOW(), NOW())`
+
+	abilityJSON, err := json.Marshal(token.Ability)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ability: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		token.ID,
+		token.UserID,
+		token.Token,
+		token.Type,
+		token.FamilyID,
+		abilityJSON,
+		token.UserAgent,
+		token.IPAddress,
+		token.ExpiredAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (r *authRepository) GetUserTokenByID(ctx context.Context, tokenID uuid.UUID) (*domain.UserToken, error) {
+	query := `
+		SELECT t.id, t.user_id, t.token, t.type, t.family_id, t.ability, t.user_agent, t.ip_address, t.expired_at, t.used_at, t.last_used_at, t.created_at, t.updated_at, t.deleted_at
+		FROM user_tokens t
+		INNER JOIN users u ON u.id = t.user_id AND u.deleted_at IS NULL AND u.status = ?
+		WHERE t.id = ? AND t.deleted_at IS NULL`
+
+	userToken := &domain.UserToken{}
+	var abilityJSON []byte
+	var familyID, userAgent, ipAddress sql.NullString
+	var usedAt, lastUsedAt, deletedAt sql.NullTime
+
+	// Tokens of suspended or deleted users stop working at once
+	err := r.db.QueryRowContext(ctx, query, constant.USER_STATUS_ACTIVE, tokenID).Scan(
 		&userToken.ID,
 		&userToken.UserID,
 		&userToken.Token,
+		&userToken.Type,
+		&familyID,
 		&abilityJSON,
+		&userAgent,
+		&ipAddress,
 		&userToken.ExpiredAt,
+		&usedAt,
+		&lastUsedAt,
 		&userToken.CreatedAt,
 		&userToken.UpdatedAt,
 		&deletedAt,
@@ -344,6 +944,25 @@ func (r *authRepository) GetUserTokenByID(ctx context.Context, tokenID uuid.UUID
 		return nil, err
 	}
 
+	// Tokens issued before rotation existed form a family of their own
+	userToken.FamilyID = userToken.ID
+	if familyID.Valid {
+		if userToken.FamilyID, err = uuid.Parse(familyID.String); err != nil {
+			return nil, err
+		}
+	}
+
+	userToken.UserAgent = userAgent.String
+	userToken.IPAddress = ipAddress.String
+
+	if usedAt.Valid {
+		userToken.UsedAt = &usedAt.Time
+	}
+
+	if lastUsedAt.Valid {
+		userToken.LastUsedAt = &lastUsedAt.Time
+	}
+
 	if deletedAt.Valid {
 		userToken.DeletedAt = &deletedAt.Time
 	}
@@ -382,7 +1001,1025 @@ func (r *authRepository) InvalidateUserToken(ctx context.Context, tokenID uuid.U
 }
 
 func (r *authRepository) InvalidateUserTokens(ctx context.Context, userID uuid.UUID) error {
-	query := `UPDATE user_tokens SET is_valid = false WHERE user_id = ?`
+	query := `
+		UPDATE user_tokens
+		SET deleted_at = NOW()
+		WHERE user_id = ? AND deleted_at IS NULL`
+
 	_, err := r.db.ExecContext(ctx, query, userID)
 	return err
 }
+
+// InvalidateOtherUserTokens revokes every token of a user except those of one login
+func (r *authRepository) InvalidateOtherUserTokens(ctx context.Context, userID, keepFamilyID uuid.UUID) error {
+	query := `
+		UPDATE user_tokens
+		SET deleted_at = NOW()
+		WHERE user_id = ? AND COALESCE(family_id, id) <> ? AND deleted_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, userID, keepFamilyID)
+	return err
+}
+
+// InvalidateTokenFamily revokes every access and refresh token issued from the same login
+func (r *authRepository) InvalidateTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	query := `
+		UPDATE user_tokens
+		SET deleted_at = NOW()
+		WHERE (family_id = ? OR id = ?) AND deleted_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, familyID, familyID)
+	return err
+}
+
+// MarkUserTokenUsed flags a refresh token as rotated. It reports false when the
+// token was already used, so concurrent refreshes cannot both succeed.
+func (r *authRepository) MarkUserTokenUsed(ctx context.Context, tokenID uuid.UUID) (bool, error) {
+	query := `
+		UPDATE user_tokens
+		SET used_at = NOW()
+		WHERE id = ? AND used_at IS NULL AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, tokenID)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+func (r *authRepository) UpdateUserTokensAbility(ctx context.Context, userID uuid.UUID, ability []string) error {
+	query := `
+		UPDATE user_tokens
+		SET ability = ?, updated_at = NOW()
+		WHERE user_id = ? AND type = ? AND deleted_at IS NULL AND expired_at > NOW()`
+
+	abilityJSON, err := json.Marshal(ability)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ability: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query, abilityJSON, userID, constant.AUTH_TOKEN_TYPE_ACCESS)
+	return err
+}
+
+// TouchUserToken records that a token was just used, and from which device
+func (r *authRepository) TouchUserToken(ctx context.Context, tokenID uuid.UUID, client domain.ClientInfo) error {
+	query := `
+		UPDATE user_tokens
+		SET last_used_at = NOW(), ip_address = NULLIF(?, ''), user_agent = NULLIF(?, '')
+		WHERE id = ? AND deleted_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, client.IPAddress, client.UserAgent, tokenID)
+	return err
+}
+
+// ListUserSessions groups the live access and refresh tokens of a user by family. The device
+// is taken from the newest token of the family, as it follows the client across refreshes.
+func (r *authRepository) ListUserSessions(ctx context.Context, userID uuid.UUID) ([]domain.Session, error) {
+	query := `
+		SELECT family_id, user_agent, ip_address, last_used_at, created_at, expired_at
+		FROM (
+			SELECT COALESCE(family_id, id) AS family_id, user_agent, ip_address,
+				MAX(last_used_at) OVER w AS last_used_at,
+				MIN(created_at) OVER w AS created_at,
+				MAX(expired_at) OVER w AS expired_at,
+				ROW_NUMBER() OVER (PARTITION BY COALESCE(family_id, id) ORDER BY created_at DESC, type = ? DESC) AS position
+			FROM user_tokens
+			WHERE user_id = ? AND type IN (?, ?) AND deleted_at IS NULL
+			WINDOW w AS (PARTITION BY COALESCE(family_id, id))
+		) sessions
+		WHERE position = 1 AND expired_at > NOW()
+		ORDER BY COALESCE(last_used_at, created_at) DESC`
+
+	rows, err := r.db.QueryContext(ctx, query,
+		constant.AUTH_TOKEN_TYPE_ACCESS,
+		userID,
+		constant.AUTH_TOKEN_TYPE_ACCESS,
+		constant.AUTH_TOKEN_TYPE_REFRESH,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []domain.Session{}
+	for rows.Next() {
+		var session domain.Session
+		var userAgent, ipAddress sql.NullString
+		var lastUsedAt sql.NullTime
+
+		if err := rows.Scan(&session.ID, &userAgent, &ipAddress, &lastUsedAt, &session.CreatedAt, &session.ExpiredAt); err != nil {
+			return nil, err
+		}
+
+		session.UserAgent = userAgent.String
+		session.IPAddress = ipAddress.String
+		if lastUsedAt.Valid {
+			session.LastUsedAt = &lastUsedAt.Time
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (r *authRepository) CreateEmailVerificationToken(ctx context.Context, token *domain.EmailVerificationToken) error {
+	query := `
+		INSERT INTO email_verification_tokens (id, user_id, token, expired_at, created_at)
+		VALUES (?, ?, ?, ?, NOW())`
+
+	_, err := r.db.ExecContext(ctx, query, token.ID, token.UserID, token.Token, token.ExpiredAt)
+	if err != nil {
+		return fmt.Errorf("failed to create email verification token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *authRepository) GetEmailVerificationToken(ctx context.Context, id uuid.UUID) (*domain.EmailVerificationToken, error) {
+	query := `
+		SELECT id, user_id, token, expired_at, used_at, created_at
+		FROM email_verification_tokens
+		WHERE id = ?`
+
+	token := &domain.EmailVerificationToken{}
+	var usedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Token,
+		&token.ExpiredAt,
+		&usedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+
+	return token, nil
+}
+
+// MarkEmailVerificationTokenUsed consumes a token, reporting false when it was already used
+func (r *authRepository) MarkEmailVerificationTokenUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE email_verification_tokens
+		SET used_at = NOW()
+		WHERE id = ? AND used_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+// InvalidateEmailVerificationTokens consumes every outstanding token of a user
+func (r *authRepository) InvalidateEmailVerificationTokens(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE email_verification_tokens
+		SET used_at = NOW()
+		WHERE user_id = ? AND used_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
+
+func (r *authRepository) CreateEmailChangeToken(ctx context.Context, token *domain.EmailChangeToken) error {
+	query := `
+		INSERT INTO email_change_tokens (id, user_id, new_email, token, expired_at, created_at)
+		VALUES (?, ?, ?, ?, ?, NOW())`
+
+	_, err := r.db.ExecContext(ctx, query, token.ID, token.UserID, token.NewEmail, token.Token, token.ExpiredAt)
+	if err != nil {
+		return fmt.Errorf("failed to create email change token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *authRepository) GetEmailChangeToken(ctx context.Context, id uuid.UUID) (*domain.EmailChangeToken, error) {
+	query := `
+		SELECT id, user_id, new_email, token, expired_at, used_at, created_at
+		FROM email_change_tokens
+		WHERE id = ?`
+
+	token := &domain.EmailChangeToken{}
+	var usedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.NewEmail,
+		&token.Token,
+		&token.ExpiredAt,
+		&usedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+
+	return token, nil
+}
+
+// MarkEmailChangeTokenUsed consumes a token, reporting false when it was already used
+func (r *authRepository) MarkEmailChangeTokenUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE email_change_tokens
+		SET used_at = NOW()
+		WHERE id = ? AND used_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+// InvalidateEmailChangeTokens consumes every outstanding token of a user
+func (r *authRepository) InvalidateEmailChangeTokens(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE email_change_tokens
+		SET used_at = NOW()
+		WHERE user_id = ? AND used_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
+
+func (r *authRepository) CreatePasswordResetToken(ctx context.Context, token *domain.PasswordResetToken) error {
+	query := `
+		INSERT INTO password_reset_tokens (id, user_id, token, expired_at, created_at)
+		VALUES (?, ?, ?, ?, NOW())`
+
+	_, err := r.db.ExecContext(ctx, query, token.ID, token.UserID, token.Token, token.ExpiredAt)
+	if err != nil {
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *authRepository) GetPasswordResetToken(ctx context.Context, id uuid.UUID) (*domain.PasswordResetToken, error) {
+	query := `
+		SELECT id, user_id, token, expired_at, used_at, created_at
+		FROM password_reset_tokens
+		WHERE id = ?`
+
+	token := &domain.PasswordResetToken{}
+	var usedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Token,
+		&token.ExpiredAt,
+		&usedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+
+	return token, nil
+}
+
+// MarkPasswordResetTokenUsed consumes a token, reporting false when it was already used
+func (r *authRepository) MarkPasswordResetTokenUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE id = ? AND used_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+// InvalidatePasswordResetTokens consumes every outstanding reset token of a user
+func (r *authRepository) InvalidatePasswordResetTokens(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE user_id = ? AND used_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
+
+// invitationStatusExpr derives the status of an invitation from its timestamps
+const invitationStatusExpr = `CASE
+		WHEN i.accepted_at IS NOT NULL THEN 'accepted'
+		WHEN i.revoked_at IS NOT NULL THEN 'revoked'
+		WHEN i.expired_at <= NOW() THEN 'expired'
+		ELSE 'pending'
+	END`
+
+// CreateInvitation stores an invitation along with the roles granted on acceptance
+func (r *authRepository) CreateInvitation(ctx context.Context, invitation *domain.Invitation, roleIDs []uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO invitations (id, email, name, token, invited_by, expired_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, NOW())`
+
+	_, err = tx.ExecContext(ctx, query,
+		invitation.ID,
+		invitation.Email,
+		invitation.Name,
+		invitation.Token,
+		invitation.InvitedBy,
+		invitation.ExpiredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	for _, roleID := range roleIDs {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO invitation_roles (invitation_id, role_id) VALUES (?, ?)", invitation.ID, roleID); err != nil {
+			return fmt.Errorf("failed to create invitation role: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetInvitationByID returns nil when the invitation does not exist
+func (r *authRepository) GetInvitationByID(ctx context.Context, id uuid.UUID) (*domain.Invitation, error) {
+	query := `
+		SELECT i.id, i.email, i.name, i.token, ` + invitationStatusExpr + `, i.invited_by, i.user_id, i.expired_at, i.accepted_at, i.revoked_at, i.created_at
+		FROM invitations i
+		WHERE i.id = ?`
+
+	invitation, err := scanInvitation(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	invitations := []domain.Invitation{*invitation}
+	if err := r.loadInvitationRoles(ctx, invitations); err != nil {
+		return nil, err
+	}
+
+	return &invitations[0], nil
+}
+
+// ListInvitations pages through invitations, newest first, optionally narrowed to a status
+func (r *authRepository) ListInvitations(ctx context.Context, status string, page, limit int) ([]domain.Invitation, int64, error) {
+	where := "1 = 1"
+	args := []interface{}{}
+	if status != "" {
+		where = invitationStatusExpr + " = ?"
+		args = append(args, status)
+	}
+
+	var total int64
+	countQuery := "SELECT COUNT(*) FROM invitations i WHERE " + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT i.id, i.email, i.name, i.token, ` + invitationStatusExpr + `, i.invited_by, i.user_id, i.expired_at, i.accepted_at, i.revoked_at, i.created_at
+		FROM invitations i
+		WHERE ` + where + `
+		ORDER BY i.created_at DESC
+		LIMIT ? OFFSET ?`
+
+	offset := (page - 1) * limit
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	invitations := []domain.Invitation{}
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		invitations = append(invitations, *invitation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.loadInvitationRoles(ctx, invitations); err != nil {
+		return nil, 0, err
+	}
+
+	return invitations, total, nil
+}
+
+// RevokeInvitation cancels a pending invitation, reporting false when it was no longer pending
+func (r *authRepository) RevokeInvitation(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE invitations
+		SET revoked_at = NOW()
+		WHERE id = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expired_at > NOW()`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+// RevokePendingInvitations cancels every outstanding invitation sent to an email
+func (r *authRepository) RevokePendingInvitations(ctx context.Context, email string) error {
+	query := `
+		UPDATE invitations
+		SET revoked_at = NOW()
+		WHERE email = ? AND accepted_at IS NULL AND revoked_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, email)
+	return err
+}
+
+// AcceptInvitation consumes a pending invitation and creates its account in one transaction: the
+// user is stored active with a verified email, linked to the invitation and given the invited roles.
+// It reports false, leaving everything unchanged, when the invitation was already used, revoked or expired.
+func (r *authRepository) AcceptInvitation(ctx context.Context, id uuid.UUID, user *domain.User, roleIDs []uuid.UUID) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE invitations
+		SET accepted_at = NOW()
+		WHERE id = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expired_at > NOW()`
+
+	result, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rows != 1 {
+		return false, nil
+	}
+
+	// The invitation link proves the address, so the account starts verified and active
+	query = `
+		INSERT INTO users (id, email, password, name, phone, status, register_from, email_verified_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, NOW(), NOW(), NOW())
+	`
+
+	user.ID = uuid.New()
+	user.Status = constant.USER_STATUS_ACTIVE
+	if user.RegisterFrom == "" {
+		user.RegisterFrom = "web"
+	}
+
+	_, err = tx.ExecContext(ctx, query,
+		user.ID,
+		user.Email,
+		user.Password,
+		user.Name,
+		user.Phone,
+		user.Status,
+		user.RegisterFrom,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create invited user: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE invitations SET user_id = ? WHERE id = ?", user.ID, id); err != nil {
+		return false, fmt.Errorf("failed to link invitation to user: %w", err)
+	}
+
+	query = `
+		INSERT INTO user_roles (id, user_id, role_id, created_at, updated_at)
+		VALUES (?, ?, ?, NOW(), NOW())`
+	for _, roleID := range roleIDs {
+		if _, err := tx.ExecContext(ctx, query, uuid.New(), user.ID, roleID); err != nil {
+			return false, fmt.Errorf("failed to assign invited role: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanInvitation(row rowScanner) (*domain.Invitation, error) {
+	invitation := &domain.Invitation{}
+	var invitedBy, userID uuid.NullUUID
+	var acceptedAt, revokedAt sql.NullTime
+
+	err := row.Scan(
+		&invitation.ID,
+		&invitation.Email,
+		&invitation.Name,
+		&invitation.Token,
+		&invitation.Status,
+		&invitedBy,
+		&userID,
+		&invitation.ExpiredAt,
+		&acceptedAt,
+		&revokedAt,
+		&invitation.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if invitedBy.Valid {
+		invitation.InvitedBy = &invitedBy.UUID
+	}
+	if userID.Valid {
+		invitation.UserID = &userID.UUID
+	}
+	if acceptedAt.Valid {
+		invitation.AcceptedAt = &acceptedAt.Time
+	}
+	if revokedAt.Valid {
+		invitation.RevokedAt = &revokedAt.Time
+	}
+
+	return invitation, nil
+}
+
+// loadInvitationRoles fills the roles of the invitations with a single query
+func (r *authRepository) loadInvitationRoles(ctx context.Context, invitations []domain.Invitation) error {
+	if len(invitations) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(invitations))
+	args := make([]interface{}, len(invitations))
+	index := make(map[uuid.UUID]int, len(invitations))
+	for i := range invitations {
+		placeholders[i] = "?"
+		args[i] = invitations[i].ID
+		index[invitations[i].ID] = i
+		invitations[i].Roles = []domain.Role{}
+	}
+
+	query := fmt.Sprintf(`
+		SELECT ir.invitation_id, r.id, r.name, r.description, r.mfa_required
+		FROM invitation_roles ir
+		INNER JOIN roles r ON r.id = ir.role_id
+		WHERE ir.invitation_id IN (%s) AND r.deleted_at IS NULL
+		ORDER BY r.name ASC
+	`, strings.Join(placeholders, ","))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var invitationID uuid.UUID
+		var role domain.Role
+		if err := rows.Scan(&invitationID, &role.ID, &role.Name, &role.Description, &role.MFARequired); err != nil {
+			return err
+		}
+		i := index[invitationID]
+		invitations[i].Roles = append(invitations[i].Roles, role)
+	}
+
+	return rows.Err()
+}
+
+// GetUserIdentity returns the user identity of a provider account, nil when it is not linked
+func (r *authRepository) GetUserIdentity(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, email, last_login_at, created_at
+		FROM user_identities
+		WHERE provider = ? AND subject = ?`
+
+	identity := &domain.UserIdentity{}
+	var email sql.NullString
+	var lastLoginAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, provider, subject).Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.Subject,
+		&email,
+		&lastLoginAt,
+		&identity.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	identity.Email = email.String
+	if lastLoginAt.Valid {
+		identity.LastLoginAt = &lastLoginAt.Time
+	}
+
+	return identity, nil
+}
+
+func (r *authRepository) CreateUserIdentity(ctx context.Context, identity *domain.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (id, user_id, provider, subject, email, last_login_at, created_at)
+		VALUES (?, ?, ?, ?, ?, NOW(), NOW())`
+
+	identity.ID = uuid.New()
+
+	_, err := r.db.ExecContext(ctx, query,
+		identity.ID,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		sql.NullString{String: identity.Email, Valid: identity.Email != ""},
+	)
+	return err
+}
+
+// TouchUserIdentity records a login through the identity and the email the provider reported
+func (r *authRepository) TouchUserIdentity(ctx context.Context, id uuid.UUID, email string) error {
+	query := `
+		UPDATE user_identities
+		SET email = ?, last_login_at = NOW()
+		WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, query, sql.NullString{String: email, Valid: email != ""}, id)
+	return err
+}
+
+func (r *authRepository) GetUserMFA(ctx context.Context, userID uuid.UUID) (*domain.UserMFA, error) {
+	query := `
+		SELECT user_id, secret, last_used_step, enabled_at, created_at, updated_at
+		FROM user_mfa
+		WHERE user_id = ?`
+
+	mfa := &domain.UserMFA{}
+	var lastUsedStep sql.NullInt64
+	var enabledAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&mfa.UserID,
+		&mfa.Secret,
+		&lastUsedStep,
+		&enabledAt,
+		&mfa.CreatedAt,
+		&mfa.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if lastUsedStep.Valid {
+		mfa.LastUsedStep = &lastUsedStep.Int64
+	}
+	if enabledAt.Valid {
+		mfa.EnabledAt = &enabledAt.Time
+	}
+
+	return mfa, nil
+}
+
+// UpsertUserMFA stores a new secret waiting for confirmation, replacing an unconfirmed one
+func (r *authRepository) UpsertUserMFA(ctx context.Context, mfa *domain.UserMFA) error {
+	query := `
+		INSERT INTO user_mfa (user_id, secret, last_used_step, enabled_at, created_at, updated_at)
+		VALUES (?, ?, NULL, NULL, NOW(), NOW())
+		ON DUPLICATE KEY UPDATE
+			secret = VALUES(secret),
+			last_used_step = NULL,
+			enabled_at = NULL,
+			updated_at = NOW()`
+
+	_, err := r.db.ExecContext(ctx, query, mfa.UserID, mfa.Secret)
+	return err
+}
+
+func (r *authRepository) EnableUserMFA(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE user_mfa
+		SET enabled_at = NOW(), updated_at = NOW()
+		WHERE user_id = ? AND enabled_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
+
+// ClaimMFAStep records the time step of an accepted code, reporting false when the
+// step or a later one was already used so a code cannot be replayed
+func (r *authRepository) ClaimMFAStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	query := `
+		UPDATE user_mfa
+		SET last_used_step = ?, updated_at = NOW()
+		WHERE user_id = ? AND (last_used_step IS NULL OR last_used_step < ?)`
+
+	result, err := r.db.ExecContext(ctx, query, step, userID, step)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+// DeleteUserMFA removes the second factor of a user together with its recovery codes
+func (r *authRepository) DeleteUserMFA(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_mfa WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ReplaceRecoveryCodes swaps the recovery codes of a user for new code digests
+func (r *authRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+
+	if len(codes) > 0 {
+		placeholders := make([]string, len(codes))
+		args := make([]interface{}, 0, len(codes)*3)
+		for i, code := range codes {
+			placeholders[i] = "(?, ?, ?, NOW())"
+			args = append(args, uuid.New(), userID, code)
+		}
+
+		query := "INSERT INTO mfa_recovery_codes (id, user_id, code, created_at) VALUES " + strings.Join(placeholders, ",")
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UseLoginRecoveryCode claims a pending login token and consumes a recovery code digest in one
+// transaction, so the code is only spent by the submission that completes the login. Nothing
+// changes unless both succeed: claimed is false when the token was already used or revoked,
+// used is false when the code is unknown or used.
+func (r *authRepository) UseLoginRecoveryCode(ctx context.Context, tokenID, userID uuid.UUID, code string) (bool, bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, false, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE user_tokens
+		SET used_at = NOW()
+		WHERE id = ? AND used_at IS NULL AND deleted_at IS NULL`
+
+	result, err := tx.ExecContext(ctx, query, tokenID)
+	if err != nil {
+		return false, false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, false, err
+	}
+	if rows != 1 {
+		return false, false, nil
+	}
+
+	query = `
+		UPDATE mfa_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = ? AND code = ? AND used_at IS NULL`
+
+	result, err = tx.ExecContext(ctx, query, userID, code)
+	if err != nil {
+		return false, false, err
+	}
+
+	rows, err = result.RowsAffected()
+	if err != nil {
+		return false, false, err
+	}
+	if rows != 1 {
+		// The token stays pending so the user can try another code
+		return true, false, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, false, err
+	}
+
+	return true, true, nil
+}
+
+func (r *authRepository) CreateAuditLog(ctx context.Context, log *domain.AuditLog) error {
+	query := `
+		INSERT INTO auth_audit_logs (id, event, user_id, actor_id, email, ip_address, metadata, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, NOW())`
+
+	if log.ID == uuid.Nil {
+		log.ID = uuid.New()
+	}
+
+	var metadata []byte
+	if len(log.Metadata) > 0 {
+		var err error
+		if metadata, err = json.Marshal(log.Metadata); err != nil {
+			return fmt.Errorf("failed to marshal audit metadata: %w", err)
+		}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		log.ID,
+		log.Event,
+		log.UserID,
+		log.ActorID,
+		sql.NullString{String: log.Email, Valid: log.Email != ""},
+		sql.NullString{String: log.IPAddress, Valid: log.IPAddress != ""},
+		metadata,
+	)
+	return err
+}
+
+func (r *authRepository) ListAuditLogs(ctx context.Context, filter *domain.AuditLogFilter, page, limit int) ([]domain.AuditLog, int64, error) {
+	where := []string{"1 = 1"}
+	args := []interface{}{}
+
+	if filter != nil && filter.Event != "" {
+		where = append(where, "event = ?")
+		args = append(args, filter.Event)
+	}
+	if filter != nil && filter.UserID != nil {
+		where = append(where, "user_id = ?")
+		args = append(args, *filter.UserID)
+	}
+	whereClause := strings.Join(where, " AND ")
+
+	var total int64
+	countQuery := "SELECT COUNT(*) FROM auth_audit_logs WHERE " + whereClause
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, event, user_id, actor_id, email, ip_address, metadata, created_at
+		FROM auth_audit_logs
+		WHERE ` + whereClause + `
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?`
+
+	offset := (page - 1) * limit
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	logs := []domain.AuditLog{}
+	for rows.Next() {
+		var log domain.AuditLog
+		var userID, actorID, email, ipAddress sql.NullString
+		var metadata []byte
+
+		if err := rows.Scan(
+			&log.ID,
+			&log.Event,
+			&userID,
+			&actorID,
+			&email,
+			&ipAddress,
+			&metadata,
+			&log.CreatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+
+		if userID.Valid {
+			id, err := uuid.Parse(userID.String)
+			if err != nil {
+				return nil, 0, err
+			}
+			log.UserID = &id
+		}
+		if actorID.Valid {
+			id, err := uuid.Parse(actorID.String)
+			if err != nil {
+				return nil, 0, err
+			}
+			log.ActorID = &id
+		}
+		log.Email = email.String
+		log.IPAddress = ipAddress.String
+
+		if len(metadata) > 0 {
+			if err := json.Unmarshal(metadata, &log.Metadata); err != nil {
+				return nil, 0, err
+			}
+		}
+
+		logs = append(logs, log)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/pkg/db/redis"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	loginFailuresKeyPrefix = "auth:login_failures:"
+	loginDelayKeyPrefix    = "auth:login_delay:"
+)
+
+type loginAttemptRepository struct {
+	redis *redis.Redis
+}
+
+// NewLoginAttemptRepository creates a Redis backed counter of failed login attempts
+func NewLoginAttemptRepository(redis *redis.Redis) domain.LoginAttemptRepository {
+	return &loginAttemptRepository{
+		redis: redis,
+	}
+}
+
+func (r *loginAttemptRepository) IncrementFailures(ctx context.Context, key string, window time.Duration) (int64, error) {
+	key = loginFailuresKeyPrefix + key
+
+	pipe := r.redis.Client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	// NX keeps the window fixed from the first failure instead of sliding with every attempt
+	pipe.ExpireNX(ctx, key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+func (r *loginAttemptRepository) GetFailures(ctx context.Context, key string) (int64, time.Duration, error) {
+	key = loginFailuresKeyPrefix + key
+
+	pipe := r.redis.Client.Pipeline()
+	get := pipe.Get(ctx, key)
+	ttl := pipe.TTL(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil && err != goredis.Nil {
+		return 0, 0, err
+	}
+
+	count, err := get.Int64()
+	if err == goredis.Nil {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return count, ttl.Val(), nil
+}
+
+func (r *loginAttemptRepository) ResetFailures(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	redisKeys := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		redisKeys = append(redisKeys, loginFailuresKeyPrefix+key, loginDelayKeyPrefix+key)
+	}
+
+	return r.redis.Client.Del(ctx, redisKeys...).Err()
+}
+
+func (r *loginAttemptRepository) SetDelay(ctx context.Context, key string, delay time.Duration) error {
+	return r.redis.Client.Set(ctx, loginDelayKeyPrefix+key, 1, delay).Err()
+}
+
+func (r *loginAttemptRepository) GetDelay(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.redis.Client.PTTL(ctx, loginDelayKeyPrefix+key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// Negative values mean the key is missing or has no expiry
+	if ttl < 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
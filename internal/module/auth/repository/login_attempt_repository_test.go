@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/pkg/db/redis"
+)
+
+func newTestLoginAttemptRepository(t *testing.T) (*miniredis.Miniredis, domain.LoginAttemptRepository) {
+	server := miniredis.RunT(t)
+	client := &redis.Redis{Client: goredis.NewClient(&goredis.Options{Addr: server.Addr()})}
+
+	return server, NewLoginAttemptRepository(client)
+}
+
+func TestLoginAttemptRepository_Failures(t *testing.T) {
+	server, repo := newTestLoginAttemptRepository(t)
+	ctx := context.Background()
+
+	for want := int64(1); want <= 3; want++ {
+		got, err := repo.IncrementFailures(ctx, "email:patient@example.com", 15*time.Minute)
+		if err != nil {
+			t.Fatalf("IncrementFailures() unexpected error = %v", err)
+		}
+		if got != want {
+			t.Errorf("IncrementFailures() = %d, want %d", got, want)
+		}
+		server.FastForward(time.Minute)
+	}
+
+	// The window starts at the first failure and is not extended by later ones
+	count, ttl, err := repo.GetFailures(ctx, "email:patient@example.com")
+	if err != nil {
+		t.Fatalf("GetFailures() unexpected error = %v", err)
+	}
+	if count != 3 || ttl != 12*time.Minute {
+		t.Errorf("GetFailures() = %d, %v, want 3, 12m", count, ttl)
+	}
+
+	server.FastForward(12 * time.Minute)
+	if count, _, _ := repo.GetFailures(ctx, "email:patient@example.com"); count != 0 {
+		t.Errorf("GetFailures() after the window = %d, want 0", count)
+	}
+}
+
+func TestLoginAttemptRepository_Delay(t *testing.T) {
+	server, repo := newTestLoginAttemptRepository(t)
+	ctx := context.Background()
+
+	if delay, err := repo.GetDelay(ctx, "email:patient@example.com"); err != nil || delay != 0 {
+		t.Errorf("GetDelay() without a delay = %v, %v, want 0", delay, err)
+	}
+
+	if err := repo.SetDelay(ctx, "email:patient@example.com", 4*time.Second); err != nil {
+		t.Fatalf("SetDelay() unexpected error = %v", err)
+	}
+	if _, err := repo.IncrementFailures(ctx, "email:patient@example.com", time.Minute); err != nil {
+		t.Fatalf("IncrementFailures() unexpected error = %v", err)
+	}
+
+	if delay, err := repo.GetDelay(ctx, "email:patient@example.com"); err != nil || delay != 4*time.Second {
+		t.Errorf("GetDelay() = %v, %v, want 4s", delay, err)
+	}
+
+	if err := repo.ResetFailures(ctx, "email:patient@example.com"); err != nil {
+		t.Fatalf("ResetFailures() unexpected error = %v", err)
+	}
+	if len(server.Keys()) != 0 {
+		t.Errorf("ResetFailures() left keys %v", server.Keys())
+	}
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/pkg/db/redis"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const oidcStateKeyPrefix = "auth:oidc_state:"
+
+type oidcStateRepository struct {
+	redis *redis.Redis
+}
+
+// NewOIDCStateRepository creates a Redis backed store of pending OIDC logins
+func NewOIDCStateRepository(redis *redis.Redis) domain.OIDCStateRepository {
+	return &oidcStateRepository{
+		redis: redis,
+	}
+}
+
+func (r *oidcStateRepository) SaveState(ctx context.Context, state string, value *domain.OIDCState, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return r.redis.Client.Set(ctx, oidcStateKeyPrefix+state, data, ttl).Err()
+}
+
+func (r *oidcStateRepository) ConsumeState(ctx context.Context, state string) (*domain.OIDCState, error) {
+	// GETDEL makes a state usable by a single callback even when requests race
+	data, err := r.redis.Client.GetDel(ctx, oidcStateKeyPrefix+state).Bytes()
+	if err == goredis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	value := &domain.OIDCState{}
+	if err := json.Unmarshal(data, value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
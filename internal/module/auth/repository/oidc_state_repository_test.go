@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/pkg/db/redis"
+)
+
+func TestOIDCStateRepository_ConsumeOnce(t *testing.T) {
+	server := miniredis.RunT(t)
+	repo := NewOIDCStateRepository(&redis.Redis{Client: goredis.NewClient(&goredis.Options{Addr: server.Addr()})})
+	ctx := context.Background()
+
+	want := &domain.OIDCState{Provider: "hospital", Nonce: "nonce", CodeVerifier: "verifier"}
+	if err := repo.SaveState(ctx, "state", want, 10*time.Minute); err != nil {
+		t.Fatalf("SaveState() unexpected error = %v", err)
+	}
+
+	got, err := repo.ConsumeState(ctx, "state")
+	if err != nil {
+		t.Fatalf("ConsumeState() unexpected error = %v", err)
+	}
+	if got == nil || *got != *want {
+		t.Errorf("ConsumeState() = %+v, want %+v", got, want)
+	}
+
+	// A state cannot be replayed
+	if got, err := repo.ConsumeState(ctx, "state"); err != nil || got != nil {
+		t.Errorf("ConsumeState() second call = %+v, %v, want nil", got, err)
+	}
+}
+
+func TestOIDCStateRepository_Expired(t *testing.T) {
+	server := miniredis.RunT(t)
+	repo := NewOIDCStateRepository(&redis.Redis{Client: goredis.NewClient(&goredis.Options{Addr: server.Addr()})})
+	ctx := context.Background()
+
+	if err := repo.SaveState(ctx, "state", &domain.OIDCState{Provider: "hospital"}, 10*time.Minute); err != nil {
+		t.Fatalf("SaveState() unexpected error = %v", err)
+	}
+	server.FastForward(11 * time.Minute)
+
+	if got, err := repo.ConsumeState(ctx, "state"); err != nil || got != nil {
+		t.Errorf("ConsumeState() = %+v, %v, want nil after expiry", got, err)
+	}
+}
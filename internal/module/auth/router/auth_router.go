@@ -1,29 +1,100 @@
 package router
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
 	"github.com/gomajido/hospital-cms-golang/internal/middleware"
 	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
 )
 
+// credentialsRateLimit throttles the public endpoints that check credentials or tokens
+var credentialsRateLimit = middleware.RateLimitPolicy{Name: "auth:credentials", Limit: 10, Window: time.Minute}
+
+// emailRateLimit throttles the public endpoints that send emails
+var emailRateLimit = middleware.RateLimitPolicy{Name: "auth:email", Limit: 5, Window: time.Minute * 15}
+
 // RegisterAuthRoutes registers all auth routes
-func RegisterAuthRoutes(router fiber.Router, handler domain.AuthHandler, authMiddleware *middleware.AuthMiddleware) {
+func RegisterAuthRoutes(router fiber.Router, handler domain.AuthHandler, authMiddleware *middleware.AuthMiddleware, rateLimiter *middleware.RateLimiter) {
 	auth := router.Group("/auth")
 
 	// Public routes
-	auth.Post("/register", handler.Register)
-	auth.Post("/login", handler.Login)
+	limitCredentials := rateLimiter.Limit(credentialsRateLimit)
+	limitEmail := rateLimiter.Limit(emailRateLimit)
+	auth.Post("/register", limitEmail, handler.Register)
+	auth.Post("/login", limitCredentials, handler.Login)
+	auth.Post("/login/mfa", limitCredentials, handler.VerifyLoginMFA)
+	auth.Post("/login/mfa/enroll", limitCredentials, handler.EnrollLoginMFA)
+	auth.Get("/oidc/:provider/authorize", limitCredentials, handler.StartOIDCLogin)
+	auth.Post("/oidc/:provider/callback", limitCredentials, handler.OIDCCallback)
+	auth.Post("/refresh", limitCredentials, handler.RefreshToken)
+	auth.Get("/verify-email", limitCredentials, handler.VerifyEmail)
+	auth.Post("/verify-email/resend", limitEmail, handler.ResendVerificationEmail)
+	auth.Post("/password/forgot", limitEmail, handler.ForgotPassword)
+	auth.Post("/password/reset", limitCredentials, handler.ResetPassword)
+	auth.Get("/email/confirm", limitCredentials, handler.ConfirmEmailChange)
+	auth.Post("/invitations/accept", limitCredentials, handler.AcceptInvitation)
 
 	// Protected routes
 	auth.Use(authMiddleware.Protected())
 	auth.Post("/logout", handler.Logout)
 
+	// Session routes
+	sessions := auth.Group("/sessions")
+	sessions.Get("", handler.ListSessions)
+	sessions.Delete("", handler.RevokeAllSessions)
+	sessions.Delete("/:id", handler.RevokeSession)
+
+	// Two-factor authentication routes
+	mfa := auth.Group("/mfa")
+	mfa.Post("/enroll", handler.EnrollMFA)
+	mfa.Post("/confirm", handler.ConfirmMFA)
+	mfa.Post("/recovery-codes", handler.RegenerateRecoveryCodes)
+	mfa.Delete("", handler.DisableMFA)
+
 	// User management routes
 	users := auth.Group("/users")
 	users.Get("/me", handler.GetUserByID)
 	users.Put("/me", handler.UpdateUser)
+	users.Put("/me/password", handler.ChangePassword)
+	users.Post("/me/email", limitEmail, handler.ChangeEmail)
+	users.Get("", authMiddleware.HasAbility(constant.PERMISSION_USER_MANAGE), handler.ListUsers)
+	users.Get("/:id", authMiddleware.HasAbility(constant.PERMISSION_USER_MANAGE), handler.GetUser)
+	users.Delete("/:id", authMiddleware.HasAbility(constant.PERMISSION_USER_MANAGE), handler.DeleteUser)
+	users.Post("/:id/restore", authMiddleware.HasAbility(constant.PERMISSION_USER_MANAGE), handler.RestoreUser)
+	users.Post("/:id/suspend", authMiddleware.HasAbility(constant.PERMISSION_USER_MANAGE), handler.SuspendUser)
+	users.Post("/:id/reactivate", authMiddleware.HasAbility(constant.PERMISSION_USER_MANAGE), handler.ReactivateUser)
+	users.Post("/:id/unlock", authMiddleware.HasAbility(constant.PERMISSION_USER_MANAGE), handler.UnlockUser)
+	users.Get("/:id/sessions", authMiddleware.HasAbility(constant.PERMISSION_USER_MANAGE), handler.ListUserSessions)
+	users.Delete("/:id/sessions", authMiddleware.HasAbility(constant.PERMISSION_USER_MANAGE), handler.RevokeUserSessions)
+	users.Delete("/:id/sessions/:session_id", authMiddleware.HasAbility(constant.PERMISSION_USER_MANAGE), handler.RevokeUserSession)
+
+	// User role management routes
+	users.Get("/:id/roles", authMiddleware.HasAbility(constant.PERMISSION_ROLE_MANAGE), handler.GetUserRoles)
+	users.Post("/:id/roles", authMiddleware.HasAbility(constant.PERMISSION_ROLE_MANAGE), handler.AssignRoles)
+	users.Delete("/:id/roles", authMiddleware.HasAbility(constant.PERMISSION_ROLE_MANAGE), handler.RevokeRoles)
+
+	// Staff invitation routes, inviting assigns roles so it needs both permissions
+	invitations := auth.Group("/invitations", authMiddleware.HasAbility(constant.PERMISSION_USER_MANAGE))
+	invitations.Get("", handler.ListInvitations)
+	invitations.Post("", authMiddleware.HasAbility(constant.PERMISSION_ROLE_MANAGE), handler.CreateInvitation)
+	invitations.Delete("/:id", handler.RevokeInvitation)
+
+	// Permission routes
+	auth.Get("/permissions", authMiddleware.HasAbility(constant.PERMISSION_ROLE_MANAGE), handler.ListPermissions)
+
+	// Audit log routes
+	auth.Get("/audit-logs", authMiddleware.HasAbility(constant.PERMISSION_AUDIT_READ), handler.ListAuditLogs)
 
 	// Role management routes
-	// users.Post("/:id/roles", handler.AssignRoles)
-	// users.Get("/:id/roles", handler.GetUserRoles)
+	roles := auth.Group("/roles", authMiddleware.HasAbility(constant.PERMISSION_ROLE_MANAGE))
+	roles.Get("", handler.ListRoles)
+	roles.Post("", handler.CreateRole)
+	roles.Get("/:id", handler.GetRole)
+	roles.Put("/:id", handler.UpdateRole)
+	roles.Delete("/:id", handler.DeleteRole)
+	roles.Get("/:id/users", handler.GetUsersByRole)
+	roles.Get("/:id/permissions", handler.GetRolePermissions)
+	roles.Put("/:id/permissions", handler.SetRolePermissions)
 }
@@ -6,26 +6,40 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/gomajido/hospital-cms-golang/config"
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/tokenhash"
 	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
 	"github.com/gomajido/hospital-cms-golang/pkg/app_log"
+	"github.com/gomajido/hospital-cms-golang/pkg/mailer"
+	"github.com/gomajido/hospital-cms-golang/pkg/oidc"
 	"github.com/google/uuid"
 )
 
 type authUsecase struct {
-	repo domain.AuthRepository
-	cfg  *config.Config
+	repo      domain.AuthRepository
+	attempts  domain.LoginAttemptRepository
+	states    domain.OIDCStateRepository
+	mailer    mailer.IMailerProviderRepository
+	providers map[string]oidc.IOIDCProviderRepository
+	cfg       *config.Config
 }
 
-// NewAuthUsecase creates a new auth usecase instance
-func NewAuthUsecase(repo domain.AuthRepository, cfg *config.Config) domain.AuthUsecase {
+// NewAuthUsecase creates a new auth usecase instance. The OIDC providers are keyed by
+// the name used in the login routes.
+func NewAuthUsecase(repo domain.AuthRepository, attempts domain.LoginAttemptRepository, states domain.OIDCStateRepository, mailer mailer.IMailerProviderRepository, providers map[string]oidc.IOIDCProviderRepository, cfg *config.Config) domain.AuthUsecase {
 	return &authUsecase{
-		repo: repo,
-		cfg:  cfg,
+		repo:      repo,
+		attempts:  attempts,
+		states:    states,
+		mailer:    mailer,
+		providers: providers,
+		cfg:       cfg,
 	}
 }
 
@@ -74,26 +88,14 @@ func (a *authUsecase) Register(ctx context.Context, req *domain.RegisterRequest)
 		return nil, err
 	}
 
-	// Get the default role (member)
-	roles, err := a.repo.GetRolesByNames(ctx, []string{"member"})
-	if err != nil {
-		app_log.Errorf("Failed to get member role: %v", err)
+	if err := a.assignMemberRole(ctx, user.ID); err != nil {
 		return nil, err
 	}
 
-	if len(roles) == 0 {
-		app_log.Error("Default role 'member' not found")
-		return nil, errors.New("default role 'member' not found")
-	}
-
-	// Convert role to UUID slice
-	roleIDs := []uuid.UUID{roles[0].ID}
-
-	// Assign the member role to the user
-	err = a.repo.AssignRolesToUser(ctx, user.ID, roleIDs)
-	if err != nil {
-		app_log.Errorf("Failed to assign member role to user: %v", err)
-		return nil, err
+	// The account stays inactive until the emailed link is opened. A failed
+	// delivery does not undo the registration, the user can ask for a resend.
+	if err := a.sendVerificationEmail(ctx, user); err != nil {
+		app_log.Errorf("Failed to send verification email to user %s: %v", user.ID, err)
 	}
 
 	// Get the updated user with roles
@@ -108,69 +110,232 @@ func (a *authUsecase) Register(ctx context.Context, req *domain.RegisterRequest)
 	}, nil
 }
 
+// assignMemberRole gives a self registered account the default member role
+func (a *authUsecase) assignMemberRole(ctx context.Context, userID uuid.UUID) error {
+	roles, err := a.repo.GetRolesByNames(ctx, []string{"member"})
+	if err != nil {
+		app_log.Errorf("Failed to get member role: %v", err)
+		return err
+	}
+
+	if len(roles) == 0 {
+		app_log.Error("Default role 'member' not found")
+		return errors.New("default role 'member' not found")
+	}
+
+	if err := a.repo.AssignRolesToUser(ctx, userID, []uuid.UUID{roles[0].ID}); err != nil {
+		app_log.Errorf("Failed to assign member role to user: %v", err)
+		return err
+	}
+
+	return nil
+}
+
 // Login handles user authentication
 func (a *authUsecase) Login(ctx context.Context, req *domain.LoginRequest) (*domain.LoginResponse, error) {
+	if err := a.checkLoginThrottle(ctx, req); err != nil {
+		return nil, err
+	}
+
 	// Get user by email
 	user, err := a.repo.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		app_log.Errorf("Failed to get user by email: %v", err)
+		return nil, errors.New("invalid credentials")
+	}
 
-	if err != nil || user == nil {
-		app_log.Errorf("User not found: %v", err)
+	if user == nil {
+		app_log.Errorf("User not found: %s", req.Email)
+		a.recordLoginFailure(ctx, req, nil)
 		return nil, errors.New("invalid credentials")
 	}
 
+	if user.LockedUntil != nil && time.Now().Before(*user.LockedUntil) {
+		return nil, &domain.LoginThrottledError{RetryAfter: time.Until(*user.LockedUntil)}
+	}
+
 	// Compare passwords
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
 	if err != nil {
-		app_log.Errorf("User not found: %v", err)
+		app_log.Errorf("Invalid password for user %s", user.ID)
+		a.recordLoginFailure(ctx, req, user)
 		return nil, errors.New("invalid credentials")
 	}
 
-	// Generate token
-	token, err := a.generateToken()
+	a.resetLoginFailures(ctx, req.Email)
+
+	// Only checked once the password matched, so account state is not leaked
+	if user.EmailVerifiedAt == nil {
+		return nil, errors.New("email not verified")
+	}
+	if user.Status != constant.USER_STATUS_ACTIVE {
+		return nil, errors.New("account is not active")
+	}
+
+	return a.completeLogin(ctx, user, domain.ClientInfo{IPAddress: req.IP, UserAgent: req.UserAgent})
+}
+
+// completeLogin issues the tokens of an authenticated user. Users with a second factor,
+// or whose role requires one, get a pending token instead.
+func (a *authUsecase) completeLogin(ctx context.Context, user *domain.User, client domain.ClientInfo) (*domain.LoginResponse, error) {
+	mfa, err := a.repo.GetUserMFA(ctx, user.ID)
 	if err != nil {
-		app_log.Errorf("Failed to generate token: %v", err)
-		return nil, fmt.Errorf("failed to generate token: %w", err)
+		app_log.Errorf("Failed to get user MFA: %v", err)
+		return nil, err
+	}
+	mfaEnabled := mfa != nil && mfa.EnabledAt != nil
+	if mfaEnabled || roleRequiresMFA(user.Roles) {
+		return a.startMFALogin(ctx, user, !mfaEnabled, client)
+	}
+
+	tokens, err := a.issueTokenPair(ctx, user.ID, uuid.New(), client)
+	if err != nil {
+		return nil, err
+	}
+
+	return loginResponse(user, tokens), nil
+}
+
+// loginResponse builds the response of a completed login
+func loginResponse(user *domain.User, tokens *domain.TokenResponse) *domain.LoginResponse {
+	return &domain.LoginResponse{
+		ID:               user.ID,
+		Email:            user.Email,
+		Name:             user.Name,
+		Phone:            user.Phone,
+		Status:           user.Status,
+		Token:            tokens.Token,
+		ExpiredAt:        &tokens.ExpiredAt,
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiredAt: &tokens.RefreshExpiredAt,
+	}
+}
+
+// RefreshToken rotates a refresh token into a new access and refresh token pair.
+// Presenting a refresh token that was already rotated revokes its whole family.
+func (a *authUsecase) RefreshToken(ctx context.Context, req *domain.RefreshTokenRequest) (*domain.TokenResponse, error) {
+	tokenID, token, err := splitToken(req.RefreshToken)
+	if err != nil {
+		return nil, errors.New("invalid refresh token")
+	}
+
+	userToken, err := a.repo.GetUserTokenByID(ctx, tokenID)
+	if err != nil {
+		app_log.Errorf("Failed to get refresh token: %v", err)
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	if userToken == nil || userToken.Type != constant.AUTH_TOKEN_TYPE_REFRESH || !tokenhash.Equal(token, userToken.Token) {
+		return nil, errors.New("invalid refresh token")
+	}
+
+	if time.Now().After(userToken.ExpiredAt) {
+		return nil, errors.New("refresh token expired")
+	}
+
+	marked := false
+	if userToken.UsedAt == nil {
+		if marked, err = a.repo.MarkUserTokenUsed(ctx, userToken.ID); err != nil {
+			app_log.Errorf("Failed to mark refresh token as used: %v", err)
+			return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+		}
+	}
+
+	if !marked {
+		app_log.Errorf("Refresh token reuse detected for user %s, revoking family %s", userToken.UserID, userToken.FamilyID)
+		if err := a.repo.InvalidateTokenFamily(ctx, userToken.FamilyID); err != nil {
+			app_log.Errorf("Failed to revoke token family: %v", err)
+			return nil, fmt.Errorf("failed to revoke token family: %w", err)
+		}
+		return nil, errors.New("refresh token reused")
 	}
 
-	// Get user roles
-	roles, err := a.repo.GetUserRoles(ctx, user.ID)
+	return a.issueTokenPair(ctx, userToken.UserID, userToken.FamilyID, domain.ClientInfo{IPAddress: req.IP, UserAgent: req.UserAgent})
+}
+
+// issueTokenPair creates an access token and a refresh token belonging to the given family
+func (a *authUsecase) issueTokenPair(ctx context.Context, userID, familyID uuid.UUID, client domain.ClientInfo) (*domain.TokenResponse, error) {
+	// Resolve abilities from user roles
+	abilities, err := a.resolveAbilities(ctx, userID)
 	if err != nil {
 		app_log.Errorf("Failed to get user roles: %v", err)
 		return nil, fmt.Errorf("failed to get user roles: %w", err)
 	}
 
-	// Convert roles to abilities
-	var abilities []string
-	for _, role := range roles {
-		abilities = append(abilities, role.Name)
+	accessToken, rawAccessToken, err := a.createUserToken(ctx, userID, familyID, constant.AUTH_TOKEN_TYPE_ACCESS, abilities, a.accessTokenDuration(), client)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create user token
-	expiry := time.Now().Add(24 * time.Hour)
+	refreshToken, rawRefreshToken, err := a.createUserToken(ctx, userID, familyID, constant.AUTH_TOKEN_TYPE_REFRESH, []string{}, constant.REFRESH_TOKEN_DURATION, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TokenResponse{
+		Token:            accessToken.ID.String() + "|" + rawAccessToken,
+		ExpiredAt:        accessToken.ExpiredAt,
+		RefreshToken:     refreshToken.ID.String() + "|" + rawRefreshToken,
+		RefreshExpiredAt: refreshToken.ExpiredAt,
+	}, nil
+}
+
+// createUserToken generates and stores a single token of the given type.
+// Only the digest is persisted; the raw token is returned to hand to the client.
+func (a *authUsecase) createUserToken(ctx context.Context, userID, familyID uuid.UUID, tokenType string, abilities []string, lifetime time.Duration, client domain.ClientInfo) (*domain.UserToken, string, error) {
+	token, err := a.generateToken()
+	if err != nil {
+		app_log.Errorf("Failed to generate token: %v", err)
+		return nil, "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	client = sessionClient(client)
 	userToken := &domain.UserToken{
 		ID:        uuid.New(),
-		UserID:    user.ID,
-		Token:     token,
+		UserID:    userID,
+		Token:     tokenhash.Sum(token),
+		Type:      tokenType,
+		FamilyID:  familyID,
 		Ability:   abilities,
-		ExpiredAt: expiry,
+		UserAgent: client.UserAgent,
+		IPAddress: client.IPAddress,
+		ExpiredAt: time.Now().Add(lifetime),
 	}
 
-	// Save token
 	createdToken, err := a.repo.CreateUserToken(ctx, userToken)
 	if err != nil {
 		app_log.Errorf("Failed to create user token: %v", err)
-		return nil, fmt.Errorf("failed to create user token: %w", err)
+		return nil, "", fmt.Errorf("failed to create user token: %w", err)
 	}
 
-	return &domain.LoginResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		Name:      user.Name,
-		Phone:     user.Phone,
-		Status:    user.Status,
-		Token:     createdToken.ID.String() + "|" + token,
-		ExpiredAt: createdToken.ExpiredAt,
-	}, nil
+	return createdToken, token, nil
+}
+
+// accessTokenDuration returns the configured access token lifetime
+func (a *authUsecase) accessTokenDuration() time.Duration {
+	if a.cfg != nil && a.cfg.Token.TokenExpiration != "" {
+		duration, err := time.ParseDuration(a.cfg.Token.TokenExpiration)
+		if err == nil && duration > 0 {
+			return duration
+		}
+		app_log.Errorf("Invalid token expiration %q, using default", a.cfg.Token.TokenExpiration)
+	}
+	return constant.ACCESS_TOKEN_DURATION
+}
+
+// splitToken parses a "<id>|<token>" credential
+func splitToken(credential string) (uuid.UUID, string, error) {
+	parts := strings.Split(credential, "|")
+	if len(parts) != 2 || parts[1] == "" {
+		return uuid.Nil, "", errors.New("invalid token format")
+	}
+
+	tokenID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	return tokenID, parts[1], nil
 }
 
 // Logout invalidates the user's token
@@ -179,29 +344,137 @@ func (a *authUsecase) Logout(ctx context.Context, tokenIDStr string) error {
 	if err != nil {
 		return fmt.Errorf("invalid token ID format: %w", err)
 	}
-	return a.InvalidateUserToken(ctx, tokenID)
+
+	userToken, err := a.GetUserTokenByID(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+
+	// Revoke the refresh token issued alongside the access token as well
+	return a.repo.InvalidateTokenFamily(ctx, userToken.FamilyID)
+}
+
+// ListRoles retrieves all roles
+func (a *authUsecase) ListRoles(ctx context.Context) ([]domain.Role, error) {
+	return a.repo.ListRoles(ctx)
+}
+
+// GetRoleByID retrieves a role by its ID
+func (a *authUsecase) GetRoleByID(ctx context.Context, id uuid.UUID) (*domain.Role, error) {
+	return a.repo.GetRoleByID(ctx, id)
+}
+
+// CreateRole creates a new role
+func (a *authUsecase) CreateRole(ctx context.Context, req *domain.RoleRequest) (*domain.Role, error) {
+	existing, err := a.repo.GetRolesByNames(ctx, []string{req.Name})
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return nil, errors.New("role already exists")
+	}
+
+	role := &domain.Role{
+		Name:        req.Name,
+		Description: req.Description,
+		MFARequired: req.MFARequired,
+	}
+
+	if err := a.repo.CreateRole(ctx, role); err != nil {
+		app_log.Errorf("Failed to create role: %v", err)
+		return nil, err
+	}
+
+	return a.repo.GetRoleByID(ctx, role.ID)
+}
+
+// UpdateRole updates a role and refreshes the abilities of its users
+func (a *authUsecase) UpdateRole(ctx context.Context, id uuid.UUID, req *domain.RoleRequest) (*domain.Role, error) {
+	role, err := a.repo.GetRoleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if role.Name != req.Name {
+		existing, err := a.repo.GetRolesByNames(ctx, []string{req.Name})
+		if err != nil {
+			return nil, err
+		}
+		if len(existing) > 0 {
+			return nil, errors.New("role already exists")
+		}
+	}
+
+	role.Name = req.Name
+	role.Description = req.Description
+	role.MFARequired = req.MFARequired
+
+	if err := a.repo.UpdateRole(ctx, role); err != nil {
+		app_log.Errorf("Failed to update role: %v", err)
+		return nil, err
+	}
+
+	if err := a.refreshRoleAbilities(ctx, role.ID); err != nil {
+		return nil, err
+	}
+
+	return a.repo.GetRoleByID(ctx, role.ID)
 }
 
-// UpdateUser updates user information
-func (a *authUsecase) UpdateUser(ctx context.Context, user *domain.User) error {
-	return a.repo.UpdateUser(ctx, user)
+// DeleteRole deletes a role, removes it from its users and refreshes their abilities
+func (a *authUsecase) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	userIDs, err := a.repo.GetUserIDsByRoleID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := a.repo.DeleteRole(ctx, id); err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		if err := a.refreshUserAbilities(ctx, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // AssignRoles assigns roles to a user
 func (a *authUsecase) AssignRoles(ctx context.Context, userID uuid.UUID, roleNames []string) error {
-	// Get roles by names
-	roles, err := a.repo.GetRolesByNames(ctx, roleNames)
+	roleIDs, err := a.getRoleIDsByNames(ctx, roleNames)
 	if err != nil {
 		return err
 	}
 
-	// Convert role names to UUIDs
-	roleIDs := make([]uuid.UUID, len(roles))
-	for i, role := range roles {
-		roleIDs[i] = role.ID
+	if _, err := a.repo.GetUserByID(ctx, userID); err != nil {
+		return err
 	}
 
-	return a.repo.AssignRolesToUser(ctx, userID, roleIDs)
+	if err := a.repo.AssignRolesToUser(ctx, userID, roleIDs); err != nil {
+		return err
+	}
+
+	return a.refreshUserAbilities(ctx, userID)
+}
+
+// RevokeRoles removes roles from a user
+func (a *authUsecase) RevokeRoles(ctx context.Context, userID uuid.UUID, roleNames []string) error {
+	roleIDs, err := a.getRoleIDsByNames(ctx, roleNames)
+	if err != nil {
+		return err
+	}
+
+	if _, err := a.repo.GetUserByID(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := a.repo.RevokeRolesFromUser(ctx, userID, roleIDs); err != nil {
+		return err
+	}
+
+	return a.refreshUserAbilities(ctx, userID)
 }
 
 // GetUserRoles retrieves all roles assigned to a user
@@ -209,6 +482,137 @@ func (a *authUsecase) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]dom
 	return a.repo.GetUserRoles(ctx, userID)
 }
 
+// GetUsersByRole retrieves the users having a role
+func (a *authUsecase) GetUsersByRole(ctx context.Context, roleID uuid.UUID, page, limit int) ([]domain.User, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	if _, err := a.repo.GetRoleByID(ctx, roleID); err != nil {
+		return nil, 0, err
+	}
+
+	return a.repo.GetUsersByRoleID(ctx, roleID, page, limit)
+}
+
+// ListPermissions retrieves all permissions
+func (a *authUsecase) ListPermissions(ctx context.Context) ([]domain.Permission, error) {
+	return a.repo.ListPermissions(ctx)
+}
+
+// GetRolePermissions retrieves the permissions granted to a role
+func (a *authUsecase) GetRolePermissions(ctx context.Context, roleID uuid.UUID) ([]domain.Permission, error) {
+	if _, err := a.repo.GetRoleByID(ctx, roleID); err != nil {
+		return nil, err
+	}
+
+	return a.repo.GetRolePermissions(ctx, roleID)
+}
+
+// SetRolePermissions replaces the permissions of a role and refreshes the abilities of its users
+func (a *authUsecase) SetRolePermissions(ctx context.Context, roleID uuid.UUID, permissionNames []string) error {
+	if _, err := a.repo.GetRoleByID(ctx, roleID); err != nil {
+		return err
+	}
+
+	permissions, err := a.repo.GetPermissionsByNames(ctx, permissionNames)
+	if err != nil {
+		return err
+	}
+
+	found := make(map[string]bool, len(permissions))
+	permissionIDs := make([]uuid.UUID, len(permissions))
+	for i, permission := range permissions {
+		found[permission.Name] = true
+		permissionIDs[i] = permission.ID
+	}
+
+	for _, name := range permissionNames {
+		if !found[name] {
+			return errors.New("permission not found")
+		}
+	}
+
+	if err := a.repo.SetRolePermissions(ctx, roleID, permissionIDs); err != nil {
+		app_log.Errorf("Failed to set role permissions: %v", err)
+		return err
+	}
+
+	return a.refreshRoleAbilities(ctx, roleID)
+}
+
+// getRoleIDsByNames resolves role names to IDs, failing when one of them does not exist
+func (a *authUsecase) getRoleIDsByNames(ctx context.Context, roleNames []string) ([]uuid.UUID, error) {
+	roles, err := a.repo.GetRolesByNames(ctx, roleNames)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool, len(roles))
+	roleIDs := make([]uuid.UUID, len(roles))
+	for i, role := range roles {
+		found[role.Name] = true
+		roleIDs[i] = role.ID
+	}
+
+	for _, name := range roleNames {
+		if !found[name] {
+			return nil, errors.New("role not found")
+		}
+	}
+
+	return roleIDs, nil
+}
+
+// resolveAbilities builds the token abilities of a user from the permissions of their roles
+func (a *authUsecase) resolveAbilities(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	permissions, err := a.repo.GetUserPermissions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Always store a JSON array, even for users without any permission
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	return permissions, nil
+}
+
+// refreshUserAbilities rewrites the abilities of the active tokens of a user so role changes apply immediately
+func (a *authUsecase) refreshUserAbilities(ctx context.Context, userID uuid.UUID) error {
+	abilities, err := a.resolveAbilities(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.repo.UpdateUserTokensAbility(ctx, userID, abilities); err != nil {
+		app_log.Errorf("Failed to refresh user token abilities: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// refreshRoleAbilities refreshes the token abilities of every user having the role
+func (a *authUsecase) refreshRoleAbilities(ctx context.Context, roleID uuid.UUID) error {
+	userIDs, err := a.repo.GetUserIDsByRoleID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		if err := a.refreshUserAbilities(ctx, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetUserTokenByID retrieves a user token by ID
 func (a *authUsecase) GetUserTokenByID(ctx context.Context, tokenID uuid.UUID) (*domain.UserToken, error) {
 	userToken, err := a.repo.GetUserTokenByID(ctx, tokenID)
@@ -224,31 +628,36 @@ func (a *authUsecase) GetUserTokenByID(ctx context.Context, tokenID uuid.UUID) (
 	return userToken, nil
 }
 
-// ValidateUserToken validates a user token
-func (a *authUsecase) ValidateUserToken(ctx context.Context, tokenIDStr string, token string) error {
+// ValidateUserToken validates a user token and returns it
+func (a *authUsecase) ValidateUserToken(ctx context.Context, tokenIDStr string, token string) (*domain.UserToken, error) {
 	tokenID, err := uuid.Parse(tokenIDStr)
 	if err != nil {
-		return fmt.Errorf("invalid token ID format: %w", err)
+		return nil, fmt.Errorf("invalid token ID format: %w", err)
 	}
 
 	userToken, err := a.repo.GetUserTokenByID(ctx, tokenID)
 	if err != nil {
-		return fmt.Errorf("failed to get user token: %w", err)
+		return nil, fmt.Errorf("failed to get user token: %w", err)
 	}
 
 	if userToken == nil {
-		return errors.New("token not found")
+		return nil, errors.New("token not found")
 	}
 
-	if userToken.Token != token {
-		return errors.New("invalid token")
+	if !tokenhash.Equal(token, userToken.Token) {
+		return nil, errors.New("invalid token")
+	}
+
+	// Refresh tokens can only be exchanged at the refresh endpoint
+	if userToken.Type != constant.AUTH_TOKEN_TYPE_ACCESS {
+		return nil, errors.New("invalid token type")
 	}
 
 	if time.Now().After(userToken.ExpiredAt) {
-		return errors.New("token expired")
+		return nil, errors.New("token expired")
 	}
 
-	return nil
+	return userToken, nil
 }
 
 // InvalidateUserToken invalidates a user token
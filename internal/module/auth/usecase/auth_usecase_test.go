@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+
+	"github.com/gomajido/hospital-cms-golang/config"
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/tokenhash"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain/mocks"
+)
+
+func TestAuthUsecase_AssignRoles(t *testing.T) {
+	userID := uuid.New()
+	adminRole := domain.Role{ID: uuid.New(), Name: "admin"}
+
+	tests := []struct {
+		name      string
+		roleNames []string
+		mock      func(repo *mocks.MockAuthRepository)
+		wantErr   string
+	}{
+		{
+			name:      "Assign role and refresh token abilities",
+			roleNames: []string{"admin"},
+			mock: func(repo *mocks.MockAuthRepository) {
+				repo.EXPECT().GetRolesByNames(gomock.Any(), []string{"admin"}).Return([]domain.Role{adminRole}, nil)
+				repo.EXPECT().GetUserByID(gomock.Any(), userID).Return(&domain.User{ID: userID}, nil)
+				repo.EXPECT().AssignRolesToUser(gomock.Any(), userID, []uuid.UUID{adminRole.ID}).Return(nil)
+				repo.EXPECT().GetUserPermissions(gomock.Any(), userID).Return([]string{"article:write", "role:manage"}, nil)
+				repo.EXPECT().UpdateUserTokensAbility(gomock.Any(), userID, []string{"article:write", "role:manage"}).Return(nil)
+			},
+		},
+		{
+			name:      "Unknown role",
+			roleNames: []string{"admin", "unknown"},
+			mock: func(repo *mocks.MockAuthRepository) {
+				repo.EXPECT().GetRolesByNames(gomock.Any(), []string{"admin", "unknown"}).Return([]domain.Role{adminRole}, nil)
+			},
+			wantErr: "role not found",
+		},
+		{
+			name:      "Unknown user",
+			roleNames: []string{"admin"},
+			mock: func(repo *mocks.MockAuthRepository) {
+				repo.EXPECT().GetRolesByNames(gomock.Any(), []string{"admin"}).Return([]domain.Role{adminRole}, nil)
+				repo.EXPECT().GetUserByID(gomock.Any(), userID).Return(nil, errors.New("user not found"))
+			},
+			wantErr: "user not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAuthRepository(ctrl)
+			tt.mock(repo)
+
+			err := NewAuthUsecase(repo, nil, nil, nil, nil, &config.Config{}).AssignRoles(context.Background(), userID, tt.roleNames)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("AssignRoles() unexpected error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("AssignRoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_DeleteRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleID := uuid.New()
+	userIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	repo := mocks.NewMockAuthRepository(ctrl)
+	repo.EXPECT().GetUserIDsByRoleID(gomock.Any(), roleID).Return(userIDs, nil)
+	repo.EXPECT().DeleteRole(gomock.Any(), roleID).Return(nil)
+	for _, userID := range userIDs {
+		repo.EXPECT().GetUserPermissions(gomock.Any(), userID).Return(nil, nil)
+		repo.EXPECT().UpdateUserTokensAbility(gomock.Any(), userID, []string{}).Return(nil)
+	}
+
+	if err := NewAuthUsecase(repo, nil, nil, nil, nil, &config.Config{}).DeleteRole(context.Background(), roleID); err != nil {
+		t.Errorf("DeleteRole() unexpected error = %v", err)
+	}
+}
+
+func TestAuthUsecase_SetRolePermissions(t *testing.T) {
+	roleID := uuid.New()
+	userID := uuid.New()
+	articleWrite := domain.Permission{ID: uuid.New(), Name: "article:write"}
+
+	tests := []struct {
+		name            string
+		permissionNames []string
+		mock            func(repo *mocks.MockAuthRepository)
+		wantErr         string
+	}{
+		{
+			name:            "Replace permissions and refresh role users",
+			permissionNames: []string{"article:write"},
+			mock: func(repo *mocks.MockAuthRepository) {
+				repo.EXPECT().GetRoleByID(gomock.Any(), roleID).Return(&domain.Role{ID: roleID}, nil)
+				repo.EXPECT().GetPermissionsByNames(gomock.Any(), []string{"article:write"}).Return([]domain.Permission{articleWrite}, nil)
+				repo.EXPECT().SetRolePermissions(gomock.Any(), roleID, []uuid.UUID{articleWrite.ID}).Return(nil)
+				repo.EXPECT().GetUserIDsByRoleID(gomock.Any(), roleID).Return([]uuid.UUID{userID}, nil)
+				repo.EXPECT().GetUserPermissions(gomock.Any(), userID).Return([]string{"article:write"}, nil)
+				repo.EXPECT().UpdateUserTokensAbility(gomock.Any(), userID, []string{"article:write"}).Return(nil)
+			},
+		},
+		{
+			name:            "Unknown permission",
+			permissionNames: []string{"article:write", "unknown"},
+			mock: func(repo *mocks.MockAuthRepository) {
+				repo.EXPECT().GetRoleByID(gomock.Any(), roleID).Return(&domain.Role{ID: roleID}, nil)
+				repo.EXPECT().GetPermissionsByNames(gomock.Any(), []string{"article:write", "unknown"}).Return([]domain.Permission{articleWrite}, nil)
+			},
+			wantErr: "permission not found",
+		},
+		{
+			name:            "Unknown role",
+			permissionNames: []string{"article:write"},
+			mock: func(repo *mocks.MockAuthRepository) {
+				repo.EXPECT().GetRoleByID(gomock.Any(), roleID).Return(nil, errors.New("role not found"))
+			},
+			wantErr: "role not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAuthRepository(ctrl)
+			tt.mock(repo)
+
+			err := NewAuthUsecase(repo, nil, nil, nil, nil, &config.Config{}).SetRolePermissions(context.Background(), roleID, tt.permissionNames)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("SetRolePermissions() unexpected error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("SetRolePermissions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_RefreshToken(t *testing.T) {
+	userID := uuid.New()
+	familyID := uuid.New()
+	usedAt := time.Now().Add(-time.Minute)
+	refreshToken := func() *domain.UserToken {
+		return &domain.UserToken{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Token:     tokenhash.Sum("secret"),
+			Type:      constant.AUTH_TOKEN_TYPE_REFRESH,
+			FamilyID:  familyID,
+			ExpiredAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		token   func() *domain.UserToken
+		secret  string
+		mock    func(repo *mocks.MockAuthRepository, token *domain.UserToken)
+		wantErr string
+	}{
+		{
+			name:   "Rotate into a new pair of the same family",
+			token:  refreshToken,
+			secret: "secret",
+			mock: func(repo *mocks.MockAuthRepository, token *domain.UserToken) {
+				repo.EXPECT().GetUserTokenByID(gomock.Any(), token.ID).Return(token, nil)
+				repo.EXPECT().MarkUserTokenUsed(gomock.Any(), token.ID).Return(true, nil)
+				repo.EXPECT().GetUserPermissions(gomock.Any(), userID).Return([]string{"article:write"}, nil)
+				repo.EXPECT().CreateUserToken(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, created *domain.UserToken) (*domain.UserToken, error) {
+						if created.FamilyID != familyID {
+							t.Errorf("CreateUserToken() family = %v, want %v", created.FamilyID, familyID)
+						}
+						if len(created.Token) != 64 {
+							t.Errorf("CreateUserToken() token = %q, want a SHA-256 digest", created.Token)
+						}
+						if created.IPAddress != "10.0.0.1" || created.UserAgent != "curl/8.0" {
+							t.Errorf("CreateUserToken() client = %q %q, want the refreshing client", created.IPAddress, created.UserAgent)
+						}
+						return created, nil
+					}).Times(2)
+			},
+		},
+		{
+			name: "Reuse of a rotated token revokes the family",
+			token: func() *domain.UserToken {
+				token := refreshToken()
+				token.UsedAt = &usedAt
+				return token
+			},
+			secret: "secret",
+			mock: func(repo *mocks.MockAuthRepository, token *domain.UserToken) {
+				repo.EXPECT().GetUserTokenByID(gomock.Any(), token.ID).Return(token, nil)
+				repo.EXPECT().InvalidateTokenFamily(gomock.Any(), familyID).Return(nil)
+			},
+			wantErr: "refresh token reused",
+		},
+		{
+			name:   "Concurrent rotation revokes the family",
+			token:  refreshToken,
+			secret: "secret",
+			mock: func(repo *mocks.MockAuthRepository, token *domain.UserToken) {
+				repo.EXPECT().GetUserTokenByID(gomock.Any(), token.ID).Return(token, nil)
+				repo.EXPECT().MarkUserTokenUsed(gomock.Any(), token.ID).Return(false, nil)
+				repo.EXPECT().InvalidateTokenFamily(gomock.Any(), familyID).Return(nil)
+			},
+			wantErr: "refresh token reused",
+		},
+		{
+			name: "Access token cannot be used to refresh",
+			token: func() *domain.UserToken {
+				token := refreshToken()
+				token.Type = constant.AUTH_TOKEN_TYPE_ACCESS
+				return token
+			},
+			secret: "secret",
+			mock: func(repo *mocks.MockAuthRepository, token *domain.UserToken) {
+				repo.EXPECT().GetUserTokenByID(gomock.Any(), token.ID).Return(token, nil)
+			},
+			wantErr: "invalid refresh token",
+		},
+		{
+			name:   "Wrong secret",
+			token:  refreshToken,
+			secret: "other",
+			mock: func(repo *mocks.MockAuthRepository, token *domain.UserToken) {
+				repo.EXPECT().GetUserTokenByID(gomock.Any(), token.ID).Return(token, nil)
+			},
+			wantErr: "invalid refresh token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			token := tt.token()
+			repo := mocks.NewMockAuthRepository(ctrl)
+			tt.mock(repo, token)
+
+			cfg := &config.Config{Token: config.TokenConfig{TokenExpiration: "10m"}}
+			resp, err := NewAuthUsecase(repo, nil, nil, nil, nil, cfg).RefreshToken(context.Background(), &domain.RefreshTokenRequest{
+				RefreshToken: token.ID.String() + "|" + tt.secret,
+				IP:           "10.0.0.1",
+				UserAgent:    "curl/8.0",
+			})
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("RefreshToken() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RefreshToken() unexpected error = %v", err)
+			}
+			if lifetime := time.Until(resp.ExpiredAt); lifetime > 10*time.Minute || lifetime < 9*time.Minute {
+				t.Errorf("RefreshToken() access lifetime = %v, want 10m", lifetime)
+			}
+			if resp.RefreshToken == "" || resp.RefreshToken == resp.Token {
+				t.Errorf("RefreshToken() refresh token = %q, want a distinct token", resp.RefreshToken)
+			}
+			if _, raw, err := splitToken(resp.Token); err != nil || len(raw) == 64 {
+				t.Errorf("RefreshToken() token = %q, want the raw token rather than its digest", resp.Token)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/signedurl"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/tokenhash"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/pkg/app_log"
+	"github.com/gomajido/hospital-cms-golang/pkg/mailer"
+	"github.com/google/uuid"
+)
+
+const verifyEmailPath = "/api/v1/auth/verify-email"
+
+// VerifyEmail consumes a signed verification link and marks the user's email as verified
+func (a *authUsecase) VerifyEmail(ctx context.Context, req *domain.VerifyEmailRequest) error {
+	if err := signedurl.Verify(req.Values(), a.cfg.Token.SigningSecret, time.Now()); err != nil {
+		if errors.Is(err, signedurl.ErrExpired) {
+			return errors.New("verification link expired")
+		}
+		if errors.Is(err, signedurl.ErrMissingSecret) {
+			return err
+		}
+		return errors.New("invalid verification link")
+	}
+
+	tokenID, err := uuid.Parse(req.ID)
+	if err != nil {
+		return errors.New("invalid verification link")
+	}
+
+	token, err := a.repo.GetEmailVerificationToken(ctx, tokenID)
+	if err != nil {
+		app_log.Errorf("Failed to get email verification token: %v", err)
+		return err
+	}
+
+	if token == nil || token.UsedAt != nil || !tokenhash.Equal(req.Token, token.Token) {
+		return errors.New("invalid verification link")
+	}
+
+	if time.Now().After(token.ExpiredAt) {
+		return errors.New("verification link expired")
+	}
+
+	used, err := a.repo.MarkEmailVerificationTokenUsed(ctx, token.ID)
+	if err != nil {
+		app_log.Errorf("Failed to consume email verification token: %v", err)
+		return err
+	}
+	if !used {
+		return errors.New("invalid verification link")
+	}
+
+	if err := a.repo.MarkUserEmailVerified(ctx, token.UserID); err != nil {
+		app_log.Errorf("Failed to mark email as verified: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// ResendVerificationEmail sends a fresh verification link and revokes the previous ones.
+// Unknown or already verified addresses are ignored so the endpoint cannot be used to
+// find out which emails are registered.
+func (a *authUsecase) ResendVerificationEmail(ctx context.Context, email string) error {
+	user, err := a.repo.GetUserByEmail(ctx, email)
+	if err != nil {
+		app_log.Errorf("Failed to get user by email: %v", err)
+		return err
+	}
+
+	if user == nil || user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	if err := a.repo.InvalidateEmailVerificationTokens(ctx, user.ID); err != nil {
+		app_log.Errorf("Failed to invalidate email verification tokens: %v", err)
+		return err
+	}
+
+	return a.sendVerificationEmail(ctx, user)
+}
+
+// sendVerificationEmail stores a new verification token and mails its signed link
+func (a *authUsecase) sendVerificationEmail(ctx context.Context, user *domain.User) error {
+	raw, err := a.generateToken()
+	if err != nil {
+		app_log.Errorf("Failed to generate token: %v", err)
+		return fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	token := &domain.EmailVerificationToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Token:     tokenhash.Sum(raw),
+		ExpiredAt: time.Now().Add(constant.EMAIL_VERIFICATION_TOKEN_DURATION),
+	}
+
+	link, err := signedurl.Sign(
+		strings.TrimRight(a.cfg.Http.BaseURL, "/")+verifyEmailPath,
+		url.Values{"id": {token.ID.String()}, "token": {raw}},
+		token.ExpiredAt,
+		a.cfg.Token.SigningSecret,
+	)
+	if err != nil {
+		app_log.Errorf("Failed to sign verification link: %v", err)
+		return err
+	}
+
+	if err := a.repo.CreateEmailVerificationToken(ctx, token); err != nil {
+		app_log.Errorf("Failed to create email verification token: %v", err)
+		return err
+	}
+
+	if err := a.mailer.Send(ctx, verificationMessage(user, link)); err != nil {
+		app_log.Errorf("Failed to send verification email: %v", err)
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	return nil
+}
+
+func verificationMessage(user *domain.User, link string) *mailer.Message {
+	return &mailer.Message{
+		To:      []string{user.Email},
+		Subject: "Verify your email address",
+		TextBody: fmt.Sprintf(
+			"Hello %s,\n\nPlease confirm your email address by opening the link below:\n\n%s\n\nThe link expires in 24 hours.\n",
+			user.Name, link,
+		),
+		HTMLBody: fmt.Sprintf(
+			`<p>Hello %s,</p><p>Please confirm your email address by clicking the link below:</p><p><a href="%s">Verify email</a></p><p>The link expires in 24 hours.</p>`,
+			html.EscapeString(user.Name), html.EscapeString(link),
+		),
+	}
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gomajido/hospital-cms-golang/config"
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain/mocks"
+	"github.com/gomajido/hospital-cms-golang/pkg/mailer"
+	mailerMocks "github.com/gomajido/hospital-cms-golang/pkg/mailer/mocks"
+)
+
+var verificationLinkRegex = regexp.MustCompile(`https://hospital\.test/api/v1/auth/verify-email\?\S+`)
+
+func newVerificationConfig() *config.Config {
+	return &config.Config{
+		Http:  config.HttpConfig{BaseURL: "https://hospital.test/", FrontendURL: "https://app.hospital.test/"},
+		Token: config.TokenConfig{SigningSecret: "secret"},
+	}
+}
+
+// sendTestVerification runs a resend and returns the stored token with the request built from the emailed link
+func sendTestVerification(t *testing.T, repo *mocks.MockAuthRepository, m *mailerMocks.MockIMailerProviderRepository, user *domain.User) (*domain.EmailVerificationToken, *domain.VerifyEmailRequest) {
+	var stored *domain.EmailVerificationToken
+	var link string
+
+	repo.EXPECT().GetUserByEmail(gomock.Any(), user.Email).Return(user, nil)
+	repo.EXPECT().InvalidateEmailVerificationTokens(gomock.Any(), user.ID).Return(nil)
+	repo.EXPECT().CreateEmailVerificationToken(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, token *domain.EmailVerificationToken) error {
+			stored = token
+			return nil
+		})
+	m.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, message *mailer.Message) error {
+			if len(message.To) != 1 || message.To[0] != user.Email {
+				t.Errorf("Send() to = %v, want %v", message.To, user.Email)
+			}
+			link = verificationLinkRegex.FindString(message.TextBody)
+			return nil
+		})
+
+	uc := NewAuthUsecase(repo, nil, nil, m, nil, newVerificationConfig())
+	if err := uc.ResendVerificationEmail(context.Background(), user.Email); err != nil {
+		t.Fatalf("ResendVerificationEmail() error = %v", err)
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil || link == "" {
+		t.Fatalf("verification email has no link: %q", link)
+	}
+	query := parsed.Query()
+
+	return stored, &domain.VerifyEmailRequest{
+		ID:        query.Get("id"),
+		Token:     query.Get("token"),
+		Expires:   query.Get("expires"),
+		Signature: query.Get("signature"),
+	}
+}
+
+func TestAuthUsecase_VerifyEmail(t *testing.T) {
+	user := &domain.User{ID: uuid.New(), Email: "patient@example.com", Name: "Patient"}
+
+	tests := []struct {
+		name    string
+		tamper  func(req *domain.VerifyEmailRequest)
+		mock    func(repo *mocks.MockAuthRepository, token *domain.EmailVerificationToken)
+		wantErr string
+	}{
+		{
+			name: "Valid link verifies the email",
+			mock: func(repo *mocks.MockAuthRepository, token *domain.EmailVerificationToken) {
+				repo.EXPECT().GetEmailVerificationToken(gomock.Any(), token.ID).Return(token, nil)
+				repo.EXPECT().MarkEmailVerificationTokenUsed(gomock.Any(), token.ID).Return(true, nil)
+				repo.EXPECT().MarkUserEmailVerified(gomock.Any(), user.ID).Return(nil)
+			},
+		},
+		{
+			name:    "Tampered token is rejected before reaching the database",
+			tamper:  func(req *domain.VerifyEmailRequest) { req.Token += "x" },
+			mock:    func(repo *mocks.MockAuthRepository, token *domain.EmailVerificationToken) {},
+			wantErr: "invalid verification link",
+		},
+		{
+			name:    "Altered expiry",
+			tamper:  func(req *domain.VerifyEmailRequest) { req.Expires = "1" },
+			mock:    func(repo *mocks.MockAuthRepository, token *domain.EmailVerificationToken) {},
+			wantErr: "invalid verification link",
+		},
+		{
+			name: "Used token",
+			mock: func(repo *mocks.MockAuthRepository, token *domain.EmailVerificationToken) {
+				usedAt := time.Now()
+				token.UsedAt = &usedAt
+				repo.EXPECT().GetEmailVerificationToken(gomock.Any(), token.ID).Return(token, nil)
+			},
+			wantErr: "invalid verification link",
+		},
+		{
+			name: "Concurrent use",
+			mock: func(repo *mocks.MockAuthRepository, token *domain.EmailVerificationToken) {
+				repo.EXPECT().GetEmailVerificationToken(gomock.Any(), token.ID).Return(token, nil)
+				repo.EXPECT().MarkEmailVerificationTokenUsed(gomock.Any(), token.ID).Return(false, nil)
+			},
+			wantErr: "invalid verification link",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAuthRepository(ctrl)
+			m := mailerMocks.NewMockIMailerProviderRepository(ctrl)
+
+			token, req := sendTestVerification(t, repo, m, user)
+			if tt.tamper != nil {
+				tt.tamper(req)
+			}
+			tt.mock(repo, token)
+
+			err := NewAuthUsecase(repo, nil, nil, m, nil, newVerificationConfig()).VerifyEmail(context.Background(), req)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("VerifyEmail() unexpected error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("VerifyEmail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_ResendVerificationEmail_VerifiedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	verifiedAt := time.Now()
+	repo := mocks.NewMockAuthRepository(ctrl)
+	repo.EXPECT().GetUserByEmail(gomock.Any(), "patient@example.com").Return(&domain.User{EmailVerifiedAt: &verifiedAt}, nil)
+
+	// No token is created and nothing is mailed
+	uc := NewAuthUsecase(repo, nil, nil, mailerMocks.NewMockIMailerProviderRepository(ctrl), nil, newVerificationConfig())
+	if err := uc.ResendVerificationEmail(context.Background(), "patient@example.com"); err != nil {
+		t.Errorf("ResendVerificationEmail() unexpected error = %v", err)
+	}
+}
+
+func TestAuthUsecase_Login_RequiresVerifiedEmail(t *testing.T) {
+	password, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	verifiedAt := time.Now()
+
+	tests := []struct {
+		name    string
+		user    *domain.User
+		wantErr string
+	}{
+		{
+			name:    "Unverified email",
+			user:    &domain.User{Status: constant.USER_STATUS_INACTIVE},
+			wantErr: "email not verified",
+		},
+		{
+			name:    "Suspended account",
+			user:    &domain.User{Status: constant.USER_STATUS_SUSPENDED, EmailVerifiedAt: &verifiedAt},
+			wantErr: "account is not active",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tt.user.ID = uuid.New()
+			tt.user.Password = string(password)
+
+			repo := mocks.NewMockAuthRepository(ctrl)
+			repo.EXPECT().GetUserByEmail(gomock.Any(), "patient@example.com").Return(tt.user, nil)
+
+			attempts := mocks.NewMockLoginAttemptRepository(ctrl)
+			attempts.EXPECT().GetFailures(gomock.Any(), "email:patient@example.com").Return(int64(0), time.Duration(0), nil)
+			attempts.EXPECT().GetDelay(gomock.Any(), "email:patient@example.com").Return(time.Duration(0), nil)
+			attempts.EXPECT().ResetFailures(gomock.Any(), "email:patient@example.com").Return(nil)
+
+			_, err := NewAuthUsecase(repo, attempts, nil, nil, nil, &config.Config{}).Login(context.Background(), &domain.LoginRequest{
+				Email:    "patient@example.com",
+				Password: "password123",
+			})
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Login() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
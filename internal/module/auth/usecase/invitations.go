@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/tokenhash"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/pkg/app_log"
+	"github.com/gomajido/hospital-cms-golang/pkg/mailer"
+	"github.com/google/uuid"
+)
+
+// acceptInvitationPath is the page of the web app where an invitee chooses a password
+const acceptInvitationPath = "/accept-invitation"
+
+// CreateInvitation invites a staff member by email. The roles are granted once the
+// invitee sets a password through the emailed link.
+func (a *authUsecase) CreateInvitation(ctx context.Context, actorID uuid.UUID, req *domain.CreateInvitationRequest) (*domain.Invitation, error) {
+	existing, err := a.repo.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		app_log.Errorf("Failed to get user by email: %v", err)
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("user with this email already exists")
+	}
+
+	roleIDs, err := a.getRoleIDsByNames(ctx, req.RoleNames)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the most recent invitation to an address can be accepted
+	if err := a.repo.RevokePendingInvitations(ctx, req.Email); err != nil {
+		app_log.Errorf("Failed to revoke pending invitations: %v", err)
+		return nil, err
+	}
+
+	raw, err := a.generateToken()
+	if err != nil {
+		app_log.Errorf("Failed to generate token: %v", err)
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	invitation := &domain.Invitation{
+		ID:        uuid.New(),
+		Email:     req.Email,
+		Name:      req.Name,
+		Token:     tokenhash.Sum(raw),
+		Status:    constant.INVITATION_STATUS_PENDING,
+		InvitedBy: &actorID,
+		ExpiredAt: time.Now().Add(constant.INVITATION_TOKEN_DURATION),
+	}
+
+	if err := a.repo.CreateInvitation(ctx, invitation, roleIDs); err != nil {
+		app_log.Errorf("Failed to create invitation: %v", err)
+		return nil, err
+	}
+
+	if err := a.mailer.Send(ctx, invitationMessage(invitation, a.cfg.Http.FrontendURL, invitation.ID.String()+"|"+raw)); err != nil {
+		app_log.Errorf("Failed to send invitation email: %v", err)
+		return nil, fmt.Errorf("failed to send invitation email: %w", err)
+	}
+
+	a.audit(ctx, &domain.AuditLog{
+		Event:    constant.AUDIT_EVENT_INVITATION_SENT,
+		ActorID:  &actorID,
+		Email:    invitation.Email,
+		Metadata: map[string]interface{}{"invitation_id": invitation.ID, "roles": req.RoleNames},
+	})
+
+	return a.repo.GetInvitationByID(ctx, invitation.ID)
+}
+
+// ListInvitations pages through invitations, optionally narrowed to a status
+func (a *authUsecase) ListInvitations(ctx context.Context, status string, page, limit int) ([]domain.Invitation, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	return a.repo.ListInvitations(ctx, status, page, limit)
+}
+
+// RevokeInvitation cancels a pending invitation so its link stops working
+func (a *authUsecase) RevokeInvitation(ctx context.Context, actorID, invitationID uuid.UUID) error {
+	invitation, err := a.repo.GetInvitationByID(ctx, invitationID)
+	if err != nil {
+		app_log.Errorf("Failed to get invitation: %v", err)
+		return err
+	}
+	if invitation == nil {
+		return errors.New("invitation not found")
+	}
+
+	revoked, err := a.repo.RevokeInvitation(ctx, invitationID)
+	if err != nil {
+		app_log.Errorf("Failed to revoke invitation: %v", err)
+		return err
+	}
+	if !revoked {
+		return errors.New("invitation is no longer pending")
+	}
+
+	a.audit(ctx, &domain.AuditLog{
+		Event:    constant.AUDIT_EVENT_INVITATION_REVOKED,
+		ActorID:  &actorID,
+		Email:    invitation.Email,
+		Metadata: map[string]interface{}{"invitation_id": invitation.ID},
+	})
+
+	return nil
+}
+
+// AcceptInvitation creates the account of an invitee with the roles of the invitation.
+// Opening the emailed link proves the address, so the account is active right away.
+func (a *authUsecase) AcceptInvitation(ctx context.Context, req *domain.AcceptInvitationRequest) (*domain.User, error) {
+	invitationID, raw, err := splitToken(req.Token)
+	if err != nil {
+		return nil, errors.New("invalid invitation")
+	}
+
+	invitation, err := a.repo.GetInvitationByID(ctx, invitationID)
+	if err != nil {
+		app_log.Errorf("Failed to get invitation: %v", err)
+		return nil, err
+	}
+
+	if invitation == nil || !tokenhash.Equal(raw, invitation.Token) {
+		return nil, errors.New("invalid invitation")
+	}
+
+	switch invitation.Status {
+	case constant.INVITATION_STATUS_PENDING:
+	case constant.INVITATION_STATUS_EXPIRED:
+		return nil, errors.New("invitation expired")
+	default:
+		return nil, errors.New("invalid invitation")
+	}
+
+	existing, err := a.repo.GetUserByEmail(ctx, invitation.Email)
+	if err != nil {
+		app_log.Errorf("Failed to get user by email: %v", err)
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("user with this email already exists")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		app_log.Errorf("Failed to hash password: %v", err)
+		return nil, err
+	}
+
+	user := &domain.User{
+		Name:     invitation.Name,
+		Email:    invitation.Email,
+		Password: string(hashedPassword),
+		Phone:    req.Phone,
+	}
+
+	roleIDs := make([]uuid.UUID, len(invitation.Roles))
+	for i, role := range invitation.Roles {
+		roleIDs[i] = role.ID
+	}
+
+	// The invitation is only consumed together with the account and its roles
+	accepted, err := a.repo.AcceptInvitation(ctx, invitation.ID, user, roleIDs)
+	if err != nil {
+		app_log.Errorf("Failed to accept invitation %s: %v", invitation.ID, err)
+		return nil, err
+	}
+	if !accepted {
+		return nil, errors.New("invalid invitation")
+	}
+
+	a.audit(ctx, &domain.AuditLog{
+		Event:    constant.AUDIT_EVENT_INVITATION_ACCEPTED,
+		UserID:   &user.ID,
+		ActorID:  &user.ID,
+		Email:    user.Email,
+		Metadata: map[string]interface{}{"invitation_id": invitation.ID},
+	})
+
+	return a.repo.GetUserByID(ctx, user.ID)
+}
+
+func invitationMessage(invitation *domain.Invitation, frontendURL, token string) *mailer.Message {
+	link := strings.TrimRight(frontendURL, "/") + acceptInvitationPath + "?" + url.Values{"token": {token}}.Encode()
+
+	return &mailer.Message{
+		To:      []string{invitation.Email},
+		Subject: "You have been invited to join the staff",
+		TextBody: fmt.Sprintf(
+			"Hello %s,\n\nAn account has been prepared for you. Open the link below to choose a password and sign in:\n\n%s\n\nThe link expires in 3 days. If you were not expecting this invitation, you can ignore this email.\n",
+			invitation.Name, link,
+		),
+		HTMLBody: fmt.Sprintf(
+			`<p>Hello %s,</p><p>An account has been prepared for you. Click the link below to choose a password and sign in:</p><p><a href="%s">Accept invitation</a></p><p>The link expires in 3 days. If you were not expecting this invitation, you can ignore this email.</p>`,
+			html.EscapeString(invitation.Name), html.EscapeString(link),
+		),
+	}
+}
@@ -0,0 +1,278 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gomajido/hospital-cms-golang/config"
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain/mocks"
+	"github.com/gomajido/hospital-cms-golang/pkg/mailer"
+	mailerMocks "github.com/gomajido/hospital-cms-golang/pkg/mailer/mocks"
+)
+
+var invitationLinkRegex = regexp.MustCompile(`https://app\.hospital\.test/accept-invitation\?\S+`)
+
+// sendTestInvitation creates an invitation and returns what was stored along with the emailed token
+func sendTestInvitation(t *testing.T, repo *mocks.MockAuthRepository, m *mailerMocks.MockIMailerProviderRepository, role domain.Role) (*domain.Invitation, string) {
+	var stored *domain.Invitation
+	var link string
+	adminID := uuid.New()
+
+	repo.EXPECT().GetUserByEmail(gomock.Any(), "nurse@example.com").Return(nil, nil)
+	repo.EXPECT().GetRolesByNames(gomock.Any(), []string{role.Name}).Return([]domain.Role{role}, nil)
+	repo.EXPECT().RevokePendingInvitations(gomock.Any(), "nurse@example.com").Return(nil)
+	repo.EXPECT().CreateInvitation(gomock.Any(), gomock.Any(), []uuid.UUID{role.ID}).DoAndReturn(
+		func(_ context.Context, invitation *domain.Invitation, _ []uuid.UUID) error {
+			stored = invitation
+			return nil
+		})
+	m.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, message *mailer.Message) error {
+			if len(message.To) != 1 || message.To[0] != "nurse@example.com" {
+				t.Errorf("Send() to = %v, want the invitee", message.To)
+			}
+			link = invitationLinkRegex.FindString(message.TextBody)
+			return nil
+		})
+	repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, log *domain.AuditLog) error {
+			if log.Event != constant.AUDIT_EVENT_INVITATION_SENT || *log.ActorID != adminID {
+				t.Errorf("CreateAuditLog() = %+v, want an invitation_sent entry by the admin", log)
+			}
+			return nil
+		})
+	repo.EXPECT().GetInvitationByID(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, id uuid.UUID) (*domain.Invitation, error) {
+			return stored, nil
+		})
+
+	req := &domain.CreateInvitationRequest{Email: "nurse@example.com", Name: "Nurse", RoleNames: []string{role.Name}}
+	invitation, err := NewAuthUsecase(repo, nil, nil, m, nil, newVerificationConfig()).CreateInvitation(context.Background(), adminID, req)
+	if err != nil {
+		t.Fatalf("CreateInvitation() error = %v", err)
+	}
+	if invitation.Status != constant.INVITATION_STATUS_PENDING || *invitation.InvitedBy != adminID {
+		t.Errorf("CreateInvitation() = %+v, want a pending invitation by the admin", invitation)
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil || link == "" {
+		t.Fatalf("invitation email has no link: %q", link)
+	}
+
+	stored.Roles = []domain.Role{role}
+	return stored, parsed.Query().Get("token")
+}
+
+func TestAuthUsecase_AcceptInvitation(t *testing.T) {
+	role := domain.Role{ID: uuid.New(), Name: "nurse"}
+
+	tests := []struct {
+		name    string
+		tamper  func(token string) string
+		mock    func(repo *mocks.MockAuthRepository, invitation *domain.Invitation)
+		wantErr string
+	}{
+		{
+			name: "Invitee gets an active account with the invited roles",
+			mock: func(repo *mocks.MockAuthRepository, invitation *domain.Invitation) {
+				userID := uuid.New()
+				repo.EXPECT().GetInvitationByID(gomock.Any(), invitation.ID).Return(invitation, nil)
+				repo.EXPECT().GetUserByEmail(gomock.Any(), invitation.Email).Return(nil, nil)
+				repo.EXPECT().AcceptInvitation(gomock.Any(), invitation.ID, gomock.Any(), []uuid.UUID{role.ID}).DoAndReturn(
+					func(_ context.Context, _ uuid.UUID, user *domain.User, _ []uuid.UUID) (bool, error) {
+						if user.Email != invitation.Email || user.Name != invitation.Name {
+							t.Errorf("AcceptInvitation() user = %+v, want the invitee", user)
+						}
+						if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("new-password")) != nil {
+							t.Errorf("AcceptInvitation() stored %q, want a bcrypt hash of the chosen password", user.Password)
+						}
+						user.ID = userID
+						return true, nil
+					})
+				repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().GetUserByID(gomock.Any(), userID).Return(&domain.User{ID: userID, Email: invitation.Email}, nil)
+			},
+		},
+		{
+			name:   "Wrong token",
+			tamper: func(token string) string { return token + "x" },
+			mock: func(repo *mocks.MockAuthRepository, invitation *domain.Invitation) {
+				repo.EXPECT().GetInvitationByID(gomock.Any(), invitation.ID).Return(invitation, nil)
+			},
+			wantErr: "invalid invitation",
+		},
+		{
+			name:    "Malformed token",
+			tamper:  func(string) string { return "not-a-token" },
+			mock:    func(repo *mocks.MockAuthRepository, invitation *domain.Invitation) {},
+			wantErr: "invalid invitation",
+		},
+		{
+			name: "Expired invitation",
+			mock: func(repo *mocks.MockAuthRepository, invitation *domain.Invitation) {
+				invitation.Status = constant.INVITATION_STATUS_EXPIRED
+				repo.EXPECT().GetInvitationByID(gomock.Any(), invitation.ID).Return(invitation, nil)
+			},
+			wantErr: "invitation expired",
+		},
+		{
+			name: "Revoked invitation",
+			mock: func(repo *mocks.MockAuthRepository, invitation *domain.Invitation) {
+				invitation.Status = constant.INVITATION_STATUS_REVOKED
+				repo.EXPECT().GetInvitationByID(gomock.Any(), invitation.ID).Return(invitation, nil)
+			},
+			wantErr: "invalid invitation",
+		},
+		{
+			name: "Concurrent acceptance",
+			mock: func(repo *mocks.MockAuthRepository, invitation *domain.Invitation) {
+				repo.EXPECT().GetInvitationByID(gomock.Any(), invitation.ID).Return(invitation, nil)
+				repo.EXPECT().GetUserByEmail(gomock.Any(), invitation.Email).Return(nil, nil)
+				repo.EXPECT().AcceptInvitation(gomock.Any(), invitation.ID, gomock.Any(), []uuid.UUID{role.ID}).Return(false, nil)
+			},
+			wantErr: "invalid invitation",
+		},
+		{
+			name: "Failure creating the account",
+			mock: func(repo *mocks.MockAuthRepository, invitation *domain.Invitation) {
+				// The repository rolls the invitation back, so it can be accepted again
+				repo.EXPECT().GetInvitationByID(gomock.Any(), invitation.ID).Return(invitation, nil)
+				repo.EXPECT().GetUserByEmail(gomock.Any(), invitation.Email).Return(nil, nil)
+				repo.EXPECT().AcceptInvitation(gomock.Any(), invitation.ID, gomock.Any(), []uuid.UUID{role.ID}).Return(false, errors.New("duplicate entry"))
+			},
+			wantErr: "duplicate entry",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAuthRepository(ctrl)
+			m := mailerMocks.NewMockIMailerProviderRepository(ctrl)
+
+			invitation, token := sendTestInvitation(t, repo, m, role)
+			if tt.tamper != nil {
+				token = tt.tamper(token)
+			}
+			tt.mock(repo, invitation)
+
+			req := &domain.AcceptInvitationRequest{Token: token, Password: "new-password"}
+			_, err := NewAuthUsecase(repo, nil, nil, m, nil, newVerificationConfig()).AcceptInvitation(context.Background(), req)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("AcceptInvitation() unexpected error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("AcceptInvitation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_CreateInvitation_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(repo *mocks.MockAuthRepository)
+		wantErr string
+	}{
+		{
+			name: "Email already registered",
+			mock: func(repo *mocks.MockAuthRepository) {
+				repo.EXPECT().GetUserByEmail(gomock.Any(), "nurse@example.com").Return(&domain.User{ID: uuid.New()}, nil)
+			},
+			wantErr: "user with this email already exists",
+		},
+		{
+			name: "Unknown role",
+			mock: func(repo *mocks.MockAuthRepository) {
+				repo.EXPECT().GetUserByEmail(gomock.Any(), "nurse@example.com").Return(nil, nil)
+				repo.EXPECT().GetRolesByNames(gomock.Any(), []string{"nurse"}).Return(nil, nil)
+			},
+			wantErr: "role not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAuthRepository(ctrl)
+			tt.mock(repo)
+
+			req := &domain.CreateInvitationRequest{Email: "nurse@example.com", Name: "Nurse", RoleNames: []string{"nurse"}}
+			_, err := NewAuthUsecase(repo, nil, nil, nil, nil, &config.Config{}).CreateInvitation(context.Background(), uuid.New(), req)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("CreateInvitation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_RevokeInvitation(t *testing.T) {
+	invitation := &domain.Invitation{ID: uuid.New(), Email: "nurse@example.com", ExpiredAt: time.Now().Add(time.Hour)}
+
+	tests := []struct {
+		name    string
+		mock    func(repo *mocks.MockAuthRepository)
+		wantErr string
+	}{
+		{
+			name: "Pending invitation is revoked",
+			mock: func(repo *mocks.MockAuthRepository) {
+				repo.EXPECT().GetInvitationByID(gomock.Any(), invitation.ID).Return(invitation, nil)
+				repo.EXPECT().RevokeInvitation(gomock.Any(), invitation.ID).Return(true, nil)
+				repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "Unknown invitation",
+			mock: func(repo *mocks.MockAuthRepository) {
+				repo.EXPECT().GetInvitationByID(gomock.Any(), invitation.ID).Return(nil, nil)
+			},
+			wantErr: "invitation not found",
+		},
+		{
+			name: "Already accepted",
+			mock: func(repo *mocks.MockAuthRepository) {
+				repo.EXPECT().GetInvitationByID(gomock.Any(), invitation.ID).Return(invitation, nil)
+				repo.EXPECT().RevokeInvitation(gomock.Any(), invitation.ID).Return(false, nil)
+			},
+			wantErr: "invitation is no longer pending",
+		},
+		{
+			name: "Database error",
+			mock: func(repo *mocks.MockAuthRepository) {
+				repo.EXPECT().GetInvitationByID(gomock.Any(), invitation.ID).Return(nil, errors.New("connection refused"))
+			},
+			wantErr: "connection refused",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAuthRepository(ctrl)
+			tt.mock(repo)
+
+			err := NewAuthUsecase(repo, nil, nil, nil, nil, &config.Config{}).RevokeInvitation(context.Background(), uuid.New(), invitation.ID)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("RevokeInvitation() unexpected error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("RevokeInvitation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/pkg/app_log"
+	"github.com/google/uuid"
+)
+
+// The throttling helpers fail open: when Redis is unavailable logins keep working
+// and the error is only logged.
+
+func loginEmailKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func loginIPKey(ip string) string {
+	return "ip:" + ip
+}
+
+// loginDelay doubles the delay for every failure past the threshold, up to the maximum
+func loginDelay(failures int64) time.Duration {
+	steps := failures - constant.LOGIN_DELAY_AFTER_FAILURES
+	if steps < 0 {
+		return 0
+	}
+
+	delay := constant.LOGIN_DELAY_BASE
+	for i := int64(0); i < steps && delay < constant.LOGIN_DELAY_MAX; i++ {
+		delay *= 2
+	}
+	if delay > constant.LOGIN_DELAY_MAX {
+		delay = constant.LOGIN_DELAY_MAX
+	}
+
+	return delay
+}
+
+// checkLoginThrottle refuses a login while the IP address is blocked, the email has
+// reached the failure limit, or the progressive delay for the email has not passed
+func (a *authUsecase) checkLoginThrottle(ctx context.Context, req *domain.LoginRequest) error {
+	if req.IP != "" {
+		failures, ttl, err := a.attempts.GetFailures(ctx, loginIPKey(req.IP))
+		if err != nil {
+			app_log.Errorf("Failed to get login failures by IP: %v", err)
+		} else if failures >= constant.LOGIN_MAX_IP_FAILURES {
+			return &domain.LoginThrottledError{RetryAfter: ttl}
+		}
+	}
+
+	emailKey := loginEmailKey(req.Email)
+
+	// Only reached for unknown emails, known accounts are locked and their counter reset
+	failures, ttl, err := a.attempts.GetFailures(ctx, emailKey)
+	if err != nil {
+		app_log.Errorf("Failed to get login failures by email: %v", err)
+	} else if failures >= constant.LOGIN_MAX_EMAIL_FAILURES {
+		return &domain.LoginThrottledError{RetryAfter: ttl}
+	}
+
+	delay, err := a.attempts.GetDelay(ctx, emailKey)
+	if err != nil {
+		app_log.Errorf("Failed to get login delay: %v", err)
+	} else if delay > 0 {
+		return &domain.LoginThrottledError{RetryAfter: delay}
+	}
+
+	return nil
+}
+
+// recordLoginFailure counts a failed login, delays the next attempts and locks the
+// account once the email reaches the failure limit. user is nil for unknown emails.
+func (a *authUsecase) recordLoginFailure(ctx context.Context, req *domain.LoginRequest, user *domain.User) {
+	if req.IP != "" {
+		failures, err := a.attempts.IncrementFailures(ctx, loginIPKey(req.IP), constant.LOGIN_FAILURE_WINDOW)
+		if err != nil {
+			app_log.Errorf("Failed to record login failure by IP: %v", err)
+		} else if failures == constant.LOGIN_MAX_IP_FAILURES {
+			a.audit(ctx, &domain.AuditLog{
+				Event:     constant.AUDIT_EVENT_IP_BLOCKED,
+				Email:     req.Email,
+				IPAddress: req.IP,
+				Metadata:  map[string]interface{}{"failures": failures, "blocked_for": constant.LOGIN_FAILURE_WINDOW.String()},
+			})
+		}
+	}
+
+	emailKey := loginEmailKey(req.Email)
+	failures, err := a.attempts.IncrementFailures(ctx, emailKey, constant.LOGIN_FAILURE_WINDOW)
+	if err != nil {
+		app_log.Errorf("Failed to record login failure by email: %v", err)
+		return
+	}
+
+	if failures >= constant.LOGIN_MAX_EMAIL_FAILURES && user != nil {
+		a.lockUser(ctx, user, req.IP, failures)
+		return
+	}
+
+	if delay := loginDelay(failures); delay > 0 {
+		if err := a.attempts.SetDelay(ctx, emailKey, delay); err != nil {
+			app_log.Errorf("Failed to set login delay: %v", err)
+		}
+	}
+}
+
+// lockUser locks an account after too many failed logins and audits the lockout
+func (a *authUsecase) lockUser(ctx context.Context, user *domain.User, ip string, failures int64) {
+	until := time.Now().Add(constant.LOGIN_LOCKOUT_DURATION)
+	if err := a.repo.LockUser(ctx, user.ID, until); err != nil {
+		app_log.Errorf("Failed to lock user %s: %v", user.ID, err)
+		return
+	}
+
+	// The lockout takes over from the counter, the user starts afresh once it ends
+	if err := a.attempts.ResetFailures(ctx, loginEmailKey(user.Email)); err != nil {
+		app_log.Errorf("Failed to reset login failures: %v", err)
+	}
+
+	a.audit(ctx, &domain.AuditLog{
+		Event:     constant.AUDIT_EVENT_ACCOUNT_LOCKED,
+		UserID:    &user.ID,
+		Email:     user.Email,
+		IPAddress: ip,
+		Metadata:  map[string]interface{}{"failures": failures, "locked_until": until.UTC().Format(time.RFC3339)},
+	})
+}
+
+// resetLoginFailures clears the counter and delay of an email after a successful login
+func (a *authUsecase) resetLoginFailures(ctx context.Context, email string) {
+	if err := a.attempts.ResetFailures(ctx, loginEmailKey(email)); err != nil {
+		app_log.Errorf("Failed to reset login failures: %v", err)
+	}
+}
+
+// UnlockUser lifts a lockout before it runs out
+func (a *authUsecase) UnlockUser(ctx context.Context, actorID, userID uuid.UUID) error {
+	user, err := a.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		app_log.Errorf("Failed to get user: %v", err)
+		return err
+	}
+
+	if err := a.repo.UnlockUser(ctx, user.ID); err != nil {
+		app_log.Errorf("Failed to unlock user: %v", err)
+		return err
+	}
+
+	a.resetLoginFailures(ctx, user.Email)
+
+	a.audit(ctx, &domain.AuditLog{
+		Event:   constant.AUDIT_EVENT_ACCOUNT_UNLOCKED,
+		UserID:  &user.ID,
+		ActorID: &actorID,
+		Email:   user.Email,
+	})
+
+	return nil
+}
+
+// ListAuditLogs returns the authentication audit trail, newest first
+func (a *authUsecase) ListAuditLogs(ctx context.Context, filter *domain.AuditLogFilter, page, limit int) ([]domain.AuditLog, int64, error) {
+	logs, total, err := a.repo.ListAuditLogs(ctx, filter, page, limit)
+	if err != nil {
+		app_log.Errorf("Failed to list audit logs: %v", err)
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
+
+// audit writes an audit log entry. A failed write is logged and does not fail the caller.
+func (a *authUsecase) audit(ctx context.Context, log *domain.AuditLog) {
+	if err := a.repo.CreateAuditLog(ctx, log); err != nil {
+		app_log.Errorf("Failed to write %s audit log: %v", log.Event, err)
+	}
+}
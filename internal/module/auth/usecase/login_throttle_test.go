@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gomajido/hospital-cms-golang/config"
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain/mocks"
+)
+
+func TestLoginDelay(t *testing.T) {
+	tests := []struct {
+		failures int64
+		want     time.Duration
+	}{
+		{failures: 1, want: 0},
+		{failures: constant.LOGIN_DELAY_AFTER_FAILURES, want: constant.LOGIN_DELAY_BASE},
+		{failures: constant.LOGIN_DELAY_AFTER_FAILURES + 2, want: 4 * constant.LOGIN_DELAY_BASE},
+		{failures: 100, want: constant.LOGIN_DELAY_MAX},
+	}
+	for _, tt := range tests {
+		if got := loginDelay(tt.failures); got != tt.want {
+			t.Errorf("loginDelay(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestAuthUsecase_Login_Throttling(t *testing.T) {
+	password, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	verifiedAt := time.Now()
+	emailKey, ipKey := "email:patient@example.com", "ip:10.0.0.1"
+
+	newUser := func() *domain.User {
+		return &domain.User{
+			ID:              uuid.New(),
+			Email:           "patient@example.com",
+			Password:        string(password),
+			Status:          constant.USER_STATUS_ACTIVE,
+			EmailVerifiedAt: &verifiedAt,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		password  string
+		mock      func(repo *mocks.MockAuthRepository, attempts *mocks.MockLoginAttemptRepository)
+		wantErr   string
+		throttled bool
+	}{
+		{
+			name:     "Blocked IP address",
+			password: "password123",
+			mock: func(repo *mocks.MockAuthRepository, attempts *mocks.MockLoginAttemptRepository) {
+				attempts.EXPECT().GetFailures(gomock.Any(), ipKey).Return(int64(constant.LOGIN_MAX_IP_FAILURES), time.Minute, nil)
+			},
+			throttled: true,
+		},
+		{
+			name:     "Progressive delay has not passed",
+			password: "password123",
+			mock: func(repo *mocks.MockAuthRepository, attempts *mocks.MockLoginAttemptRepository) {
+				attempts.EXPECT().GetFailures(gomock.Any(), ipKey).Return(int64(3), time.Minute, nil)
+				attempts.EXPECT().GetFailures(gomock.Any(), emailKey).Return(int64(3), time.Minute, nil)
+				attempts.EXPECT().GetDelay(gomock.Any(), emailKey).Return(2*time.Second, nil)
+			},
+			throttled: true,
+		},
+		{
+			name:     "Locked account refuses the right password",
+			password: "password123",
+			mock: func(repo *mocks.MockAuthRepository, attempts *mocks.MockLoginAttemptRepository) {
+				attempts.EXPECT().GetFailures(gomock.Any(), gomock.Any()).Return(int64(0), time.Duration(0), nil).Times(2)
+				attempts.EXPECT().GetDelay(gomock.Any(), emailKey).Return(time.Duration(0), nil)
+				user := newUser()
+				lockedUntil := time.Now().Add(10 * time.Minute)
+				user.LockedUntil = &lockedUntil
+				repo.EXPECT().GetUserByEmail(gomock.Any(), "patient@example.com").Return(user, nil)
+			},
+			throttled: true,
+		},
+		{
+			name:     "Failure past the threshold sets a delay",
+			password: "wrong-password",
+			mock: func(repo *mocks.MockAuthRepository, attempts *mocks.MockLoginAttemptRepository) {
+				attempts.EXPECT().GetFailures(gomock.Any(), gomock.Any()).Return(int64(0), time.Duration(0), nil).Times(2)
+				attempts.EXPECT().GetDelay(gomock.Any(), emailKey).Return(time.Duration(0), nil)
+				repo.EXPECT().GetUserByEmail(gomock.Any(), "patient@example.com").Return(newUser(), nil)
+				attempts.EXPECT().IncrementFailures(gomock.Any(), ipKey, constant.LOGIN_FAILURE_WINDOW).Return(int64(4), nil)
+				attempts.EXPECT().IncrementFailures(gomock.Any(), emailKey, constant.LOGIN_FAILURE_WINDOW).Return(int64(4), nil)
+				attempts.EXPECT().SetDelay(gomock.Any(), emailKey, 2*constant.LOGIN_DELAY_BASE).Return(nil)
+			},
+			wantErr: "invalid credentials",
+		},
+		{
+			name:     "Reaching the limit locks the account and audits it",
+			password: "wrong-password",
+			mock: func(repo *mocks.MockAuthRepository, attempts *mocks.MockLoginAttemptRepository) {
+				user := newUser()
+				attempts.EXPECT().GetFailures(gomock.Any(), gomock.Any()).Return(int64(0), time.Duration(0), nil).Times(2)
+				attempts.EXPECT().GetDelay(gomock.Any(), emailKey).Return(time.Duration(0), nil)
+				repo.EXPECT().GetUserByEmail(gomock.Any(), "patient@example.com").Return(user, nil)
+				attempts.EXPECT().IncrementFailures(gomock.Any(), ipKey, constant.LOGIN_FAILURE_WINDOW).Return(int64(5), nil)
+				attempts.EXPECT().IncrementFailures(gomock.Any(), emailKey, constant.LOGIN_FAILURE_WINDOW).Return(int64(constant.LOGIN_MAX_EMAIL_FAILURES), nil)
+				repo.EXPECT().LockUser(gomock.Any(), user.ID, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ uuid.UUID, until time.Time) error {
+						if d := time.Until(until); d <= 0 || d > constant.LOGIN_LOCKOUT_DURATION {
+							t.Errorf("LockUser() until = %v, want within %v", until, constant.LOGIN_LOCKOUT_DURATION)
+						}
+						return nil
+					})
+				attempts.EXPECT().ResetFailures(gomock.Any(), emailKey).Return(nil)
+				repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, log *domain.AuditLog) error {
+						if log.Event != constant.AUDIT_EVENT_ACCOUNT_LOCKED || log.UserID == nil || *log.UserID != user.ID || log.IPAddress != "10.0.0.1" {
+							t.Errorf("CreateAuditLog() = %+v, want an account_locked entry for the user", log)
+						}
+						return nil
+					})
+			},
+			wantErr: "invalid credentials",
+		},
+		{
+			name:     "Unknown email is counted and audited when the IP gets blocked",
+			password: "password123",
+			mock: func(repo *mocks.MockAuthRepository, attempts *mocks.MockLoginAttemptRepository) {
+				attempts.EXPECT().GetFailures(gomock.Any(), gomock.Any()).Return(int64(0), time.Duration(0), nil).Times(2)
+				attempts.EXPECT().GetDelay(gomock.Any(), emailKey).Return(time.Duration(0), nil)
+				repo.EXPECT().GetUserByEmail(gomock.Any(), "patient@example.com").Return(nil, nil)
+				attempts.EXPECT().IncrementFailures(gomock.Any(), ipKey, constant.LOGIN_FAILURE_WINDOW).Return(int64(constant.LOGIN_MAX_IP_FAILURES), nil)
+				repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, log *domain.AuditLog) error {
+						if log.Event != constant.AUDIT_EVENT_IP_BLOCKED || log.UserID != nil {
+							t.Errorf("CreateAuditLog() = %+v, want an ip_blocked entry", log)
+						}
+						return nil
+					})
+				attempts.EXPECT().IncrementFailures(gomock.Any(), emailKey, constant.LOGIN_FAILURE_WINDOW).Return(int64(1), nil)
+			},
+			wantErr: "invalid credentials",
+		},
+		{
+			name:     "Redis errors do not block logins",
+			password: "password123",
+			mock: func(repo *mocks.MockAuthRepository, attempts *mocks.MockLoginAttemptRepository) {
+				redisErr := errors.New("connection refused")
+				attempts.EXPECT().GetFailures(gomock.Any(), gomock.Any()).Return(int64(0), time.Duration(0), redisErr).Times(2)
+				attempts.EXPECT().GetDelay(gomock.Any(), emailKey).Return(time.Duration(0), redisErr)
+				repo.EXPECT().GetUserByEmail(gomock.Any(), "patient@example.com").Return(newUser(), nil)
+				attempts.EXPECT().ResetFailures(gomock.Any(), emailKey).Return(redisErr)
+				repo.EXPECT().GetUserMFA(gomock.Any(), gomock.Any()).Return(nil, nil)
+				repo.EXPECT().CreateUserToken(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, token *domain.UserToken) (*domain.UserToken, error) {
+						return token, nil
+					}).Times(2)
+				repo.EXPECT().GetUserPermissions(gomock.Any(), gomock.Any()).Return([]string{}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAuthRepository(ctrl)
+			attempts := mocks.NewMockLoginAttemptRepository(ctrl)
+			tt.mock(repo, attempts)
+
+			_, err := NewAuthUsecase(repo, attempts, nil, nil, nil, &config.Config{}).Login(context.Background(), &domain.LoginRequest{
+				Email:    "patient@example.com",
+				Password: tt.password,
+				IP:       "10.0.0.1",
+			})
+
+			var throttled *domain.LoginThrottledError
+			switch {
+			case tt.throttled:
+				if !errors.As(err, &throttled) || throttled.RetryAfter <= 0 {
+					t.Errorf("Login() error = %v, want a throttled error with a retry delay", err)
+				}
+			case tt.wantErr != "":
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Login() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			default:
+				if err != nil {
+					t.Errorf("Login() unexpected error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_UnlockUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	actorID := uuid.New()
+	user := &domain.User{ID: uuid.New(), Email: "Patient@Example.com"}
+
+	repo := mocks.NewMockAuthRepository(ctrl)
+	attempts := mocks.NewMockLoginAttemptRepository(ctrl)
+	repo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+	repo.EXPECT().UnlockUser(gomock.Any(), user.ID).Return(nil)
+	attempts.EXPECT().ResetFailures(gomock.Any(), "email:patient@example.com").Return(nil)
+	repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, log *domain.AuditLog) error {
+			if log.Event != constant.AUDIT_EVENT_ACCOUNT_UNLOCKED || log.ActorID == nil || *log.ActorID != actorID {
+				t.Errorf("CreateAuditLog() = %+v, want an account_unlocked entry by the actor", log)
+			}
+			return nil
+		})
+
+	if err := NewAuthUsecase(repo, attempts, nil, nil, nil, &config.Config{}).UnlockUser(context.Background(), actorID, user.ID); err != nil {
+		t.Errorf("UnlockUser() unexpected error = %v", err)
+	}
+}
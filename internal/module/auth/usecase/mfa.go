@@ -0,0 +1,421 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/secretbox"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/tokenhash"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/totp"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/pkg/app_log"
+	"github.com/google/uuid"
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func mfaFailuresKey(userID uuid.UUID) string {
+	return "mfa:" + userID.String()
+}
+
+// roleRequiresMFA reports whether any of the roles requires a second factor
+func roleRequiresMFA(roles []domain.Role) bool {
+	for _, role := range roles {
+		if role.MFARequired {
+			return true
+		}
+	}
+	return false
+}
+
+// startMFALogin hands out a short-lived token that can only be exchanged for a
+// session at /auth/login/mfa once the second factor is verified
+func (a *authUsecase) startMFALogin(ctx context.Context, user *domain.User, enrollmentRequired bool, client domain.ClientInfo) (*domain.LoginResponse, error) {
+	token, raw, err := a.createUserToken(ctx, user.ID, uuid.New(), constant.AUTH_TOKEN_TYPE_MFA, []string{}, constant.MFA_TOKEN_DURATION, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.LoginResponse{
+		ID:                    user.ID,
+		Email:                 user.Email,
+		Name:                  user.Name,
+		Phone:                 user.Phone,
+		Status:                user.Status,
+		MFARequired:           true,
+		MFAEnrollmentRequired: enrollmentRequired,
+		MFAToken:              token.ID.String() + "|" + raw,
+		MFAExpiredAt:          &token.ExpiredAt,
+	}, nil
+}
+
+// pendingMFAToken resolves the token of a login waiting for the second factor
+func (a *authUsecase) pendingMFAToken(ctx context.Context, credential string) (*domain.UserToken, error) {
+	tokenID, raw, err := splitToken(credential)
+	if err != nil {
+		return nil, errors.New("invalid mfa token")
+	}
+
+	userToken, err := a.repo.GetUserTokenByID(ctx, tokenID)
+	if err != nil {
+		app_log.Errorf("Failed to get mfa token: %v", err)
+		return nil, err
+	}
+
+	if userToken == nil || userToken.Type != constant.AUTH_TOKEN_TYPE_MFA || userToken.UsedAt != nil || !tokenhash.Equal(raw, userToken.Token) {
+		return nil, errors.New("invalid mfa token")
+	}
+
+	if time.Now().After(userToken.ExpiredAt) {
+		return nil, errors.New("mfa token expired")
+	}
+
+	return userToken, nil
+}
+
+// VerifyLoginMFA exchanges a pending MFA token and a TOTP or recovery code for a session.
+// A user enrolling during login has the second factor enabled by the first valid code.
+func (a *authUsecase) VerifyLoginMFA(ctx context.Context, req *domain.MFALoginRequest) (*domain.LoginResponse, error) {
+	token, err := a.pendingMFAToken(ctx, req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	mfa, err := a.repo.GetUserMFA(ctx, token.UserID)
+	if err != nil {
+		app_log.Errorf("Failed to get user MFA: %v", err)
+		return nil, err
+	}
+	if mfa == nil || (mfa.EnabledAt == nil && req.RecoveryCode != "") {
+		return nil, errors.New("mfa not enrolled")
+	}
+
+	valid, err := a.verifyMFAChallenge(ctx, token, mfa, req)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		a.recordMFAFailure(ctx, token, req.IP)
+		return nil, errors.New("invalid mfa code")
+	}
+
+	if err := a.attempts.ResetFailures(ctx, mfaFailuresKey(token.UserID)); err != nil {
+		app_log.Errorf("Failed to reset mfa failures: %v", err)
+	}
+
+	var recoveryCodes []string
+	if mfa.EnabledAt == nil {
+		if recoveryCodes, err = a.enableMFA(ctx, token.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	user, err := a.repo.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		app_log.Errorf("Failed to get user: %v", err)
+		return nil, err
+	}
+
+	tokens, err := a.issueTokenPair(ctx, user.ID, uuid.New(), domain.ClientInfo{IPAddress: req.IP, UserAgent: req.UserAgent})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := loginResponse(user, tokens)
+	resp.RecoveryCodes = recoveryCodes
+
+	return resp, nil
+}
+
+// verifyMFAChallenge checks the submitted code and claims the pending token, which completes a
+// single login. A recovery code is spent in the same transaction that claims the token, so a
+// replayed or concurrent submission cannot burn a code without logging in.
+func (a *authUsecase) verifyMFAChallenge(ctx context.Context, token *domain.UserToken, mfa *domain.UserMFA, req *domain.MFALoginRequest) (bool, error) {
+	var claimed, valid bool
+	var err error
+	if req.RecoveryCode != "" {
+		claimed, valid, err = a.repo.UseLoginRecoveryCode(ctx, token.ID, token.UserID, tokenhash.Sum(normalizeRecoveryCode(req.RecoveryCode)))
+		if err != nil {
+			app_log.Errorf("Failed to verify recovery code: %v", err)
+			return false, err
+		}
+		if !claimed {
+			return false, errors.New("invalid mfa token")
+		}
+		return valid, nil
+	}
+
+	valid, err = a.checkTOTP(ctx, mfa, req.Code)
+	if err != nil {
+		app_log.Errorf("Failed to verify mfa code: %v", err)
+		return false, err
+	}
+	if !valid {
+		return false, nil
+	}
+
+	claimed, err = a.repo.MarkUserTokenUsed(ctx, token.ID)
+	if err != nil {
+		app_log.Errorf("Failed to consume mfa token: %v", err)
+		return false, err
+	}
+	if !claimed {
+		return false, errors.New("invalid mfa token")
+	}
+
+	return true, nil
+}
+
+// recordMFAFailure counts a wrong code. Too many of them mean the password is
+// likely known to someone else, so the pending login is revoked and the account locked.
+func (a *authUsecase) recordMFAFailure(ctx context.Context, token *domain.UserToken, ip string) {
+	failures, err := a.attempts.IncrementFailures(ctx, mfaFailuresKey(token.UserID), constant.LOGIN_FAILURE_WINDOW)
+	if err != nil {
+		app_log.Errorf("Failed to record mfa failure: %v", err)
+		return
+	}
+
+	if failures < constant.MFA_MAX_FAILURES {
+		return
+	}
+
+	if err := a.repo.InvalidateUserToken(ctx, token.ID); err != nil {
+		app_log.Errorf("Failed to revoke mfa token: %v", err)
+	}
+
+	user, err := a.repo.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		app_log.Errorf("Failed to get user: %v", err)
+		return
+	}
+
+	a.lockUser(ctx, user, ip, failures)
+
+	if err := a.attempts.ResetFailures(ctx, mfaFailuresKey(token.UserID)); err != nil {
+		app_log.Errorf("Failed to reset mfa failures: %v", err)
+	}
+}
+
+// EnrollLoginMFA starts the enrollment of a user whose role requires a second factor
+// but who has none yet, authenticated by the pending MFA token of their login
+func (a *authUsecase) EnrollLoginMFA(ctx context.Context, mfaToken string) (*domain.MFAEnrollmentResponse, error) {
+	token, err := a.pendingMFAToken(ctx, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.EnrollMFA(ctx, token.UserID)
+}
+
+// EnrollMFA generates a TOTP secret. It is only enabled once a code from it is confirmed.
+func (a *authUsecase) EnrollMFA(ctx context.Context, userID uuid.UUID) (*domain.MFAEnrollmentResponse, error) {
+	mfa, err := a.repo.GetUserMFA(ctx, userID)
+	if err != nil {
+		app_log.Errorf("Failed to get user MFA: %v", err)
+		return nil, err
+	}
+	if mfa != nil && mfa.EnabledAt != nil {
+		return nil, errors.New("mfa already enabled")
+	}
+
+	user, err := a.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		app_log.Errorf("Failed to get user: %v", err)
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		app_log.Errorf("Failed to generate totp secret: %v", err)
+		return nil, fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+
+	sealed, err := secretbox.Seal(secret, a.cfg.Token.EncryptionKey)
+	if err != nil {
+		app_log.Errorf("Failed to encrypt totp secret: %v", err)
+		return nil, err
+	}
+
+	if err := a.repo.UpsertUserMFA(ctx, &domain.UserMFA{UserID: userID, Secret: sealed}); err != nil {
+		app_log.Errorf("Failed to store user MFA: %v", err)
+		return nil, err
+	}
+
+	return &domain.MFAEnrollmentResponse{
+		Secret: secret,
+		URI:    totp.URI(constant.MFA_TOTP_ISSUER, user.Email, secret),
+	}, nil
+}
+
+// ConfirmMFA enables the enrolled second factor with a first code and returns the recovery codes
+func (a *authUsecase) ConfirmMFA(ctx context.Context, userID uuid.UUID, code string) (*domain.RecoveryCodesResponse, error) {
+	mfa, err := a.repo.GetUserMFA(ctx, userID)
+	if err != nil {
+		app_log.Errorf("Failed to get user MFA: %v", err)
+		return nil, err
+	}
+	if mfa == nil {
+		return nil, errors.New("mfa not enrolled")
+	}
+	if mfa.EnabledAt != nil {
+		return nil, errors.New("mfa already enabled")
+	}
+
+	if err := a.requireTOTP(ctx, mfa, code); err != nil {
+		return nil, err
+	}
+
+	codes, err := a.enableMFA(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// RegenerateRecoveryCodes replaces every recovery code of the user
+func (a *authUsecase) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (*domain.RecoveryCodesResponse, error) {
+	mfa, err := a.enabledMFA(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.requireTOTP(ctx, mfa, code); err != nil {
+		return nil, err
+	}
+
+	codes, err := a.replaceRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableMFA removes the second factor, unless one of the user's roles requires it
+func (a *authUsecase) DisableMFA(ctx context.Context, userID uuid.UUID, code string) error {
+	mfa, err := a.enabledMFA(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	roles, err := a.repo.GetUserRoles(ctx, userID)
+	if err != nil {
+		app_log.Errorf("Failed to get user roles: %v", err)
+		return err
+	}
+	if roleRequiresMFA(roles) {
+		return errors.New("mfa is required for your role")
+	}
+
+	if err := a.requireTOTP(ctx, mfa, code); err != nil {
+		return err
+	}
+
+	if err := a.repo.DeleteUserMFA(ctx, userID); err != nil {
+		app_log.Errorf("Failed to delete user MFA: %v", err)
+		return err
+	}
+
+	a.audit(ctx, &domain.AuditLog{Event: constant.AUDIT_EVENT_MFA_DISABLED, UserID: &userID, ActorID: &userID})
+
+	return nil
+}
+
+// enabledMFA returns the second factor of a user, failing when it is not enabled
+func (a *authUsecase) enabledMFA(ctx context.Context, userID uuid.UUID) (*domain.UserMFA, error) {
+	mfa, err := a.repo.GetUserMFA(ctx, userID)
+	if err != nil {
+		app_log.Errorf("Failed to get user MFA: %v", err)
+		return nil, err
+	}
+	if mfa == nil || mfa.EnabledAt == nil {
+		return nil, errors.New("mfa not enabled")
+	}
+
+	return mfa, nil
+}
+
+// enableMFA turns on a confirmed second factor and issues its first recovery codes
+func (a *authUsecase) enableMFA(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	if err := a.repo.EnableUserMFA(ctx, userID); err != nil {
+		app_log.Errorf("Failed to enable user MFA: %v", err)
+		return nil, err
+	}
+
+	codes, err := a.replaceRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.audit(ctx, &domain.AuditLog{Event: constant.AUDIT_EVENT_MFA_ENABLED, UserID: &userID, ActorID: &userID})
+
+	return codes, nil
+}
+
+// replaceRecoveryCodes generates new recovery codes, storing only their digests
+func (a *authUsecase) replaceRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	codes := make([]string, constant.MFA_RECOVERY_CODE_COUNT)
+	digests := make([]string, constant.MFA_RECOVERY_CODE_COUNT)
+
+	for i := range codes {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			app_log.Errorf("Failed to generate recovery code: %v", err)
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+
+		// 8 base32 characters, shown as xxxx-xxxx
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+		codes[i] = code[:4] + "-" + code[4:]
+		digests[i] = tokenhash.Sum(code)
+	}
+
+	if err := a.repo.ReplaceRecoveryCodes(ctx, userID, digests); err != nil {
+		app_log.Errorf("Failed to store recovery codes: %v", err)
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+// requireTOTP fails with "invalid mfa code" unless the code is valid
+func (a *authUsecase) requireTOTP(ctx context.Context, mfa *domain.UserMFA, code string) error {
+	valid, err := a.checkTOTP(ctx, mfa, code)
+	if err != nil {
+		app_log.Errorf("Failed to verify mfa code: %v", err)
+		return err
+	}
+	if !valid {
+		return errors.New("invalid mfa code")
+	}
+	return nil
+}
+
+// checkTOTP validates a code and claims its time step, so each code works only once
+func (a *authUsecase) checkTOTP(ctx context.Context, mfa *domain.UserMFA, code string) (bool, error) {
+	secret, err := secretbox.Open(mfa.Secret, a.cfg.Token.EncryptionKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to decrypt totp secret: %w", err)
+	}
+
+	step, ok := totp.Validate(secret, code, time.Now(), constant.MFA_TOTP_SKEW)
+	if !ok {
+		return false, nil
+	}
+	if mfa.LastUsedStep != nil && step <= *mfa.LastUsedStep {
+		return false, nil
+	}
+
+	return a.repo.ClaimMFAStep(ctx, mfa.UserID, step)
+}
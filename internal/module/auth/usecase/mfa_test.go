@@ -0,0 +1,338 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gomajido/hospital-cms-golang/config"
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/secretbox"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/tokenhash"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/totp"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain/mocks"
+)
+
+func newMFAConfig() *config.Config {
+	return &config.Config{Token: config.TokenConfig{EncryptionKey: "encryption-key"}}
+}
+
+// newTestMFA returns an enabled second factor with its plain secret
+func newTestMFA(t *testing.T, userID uuid.UUID) (*domain.UserMFA, string) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+	sealed, err := secretbox.Seal(secret, newMFAConfig().Token.EncryptionKey)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+
+	enabledAt := time.Now()
+	return &domain.UserMFA{UserID: userID, Secret: sealed, EnabledAt: &enabledAt}, secret
+}
+
+// newPendingMFAToken returns a stored pending token and the credential handed to the client
+func newPendingMFAToken(userID uuid.UUID) (*domain.UserToken, string) {
+	id := uuid.New()
+	return &domain.UserToken{
+		ID:        id,
+		UserID:    userID,
+		Token:     tokenhash.Sum("pending"),
+		Type:      constant.AUTH_TOKEN_TYPE_MFA,
+		FamilyID:  id,
+		ExpiredAt: time.Now().Add(constant.MFA_TOKEN_DURATION),
+	}, id.String() + "|pending"
+}
+
+func TestAuthUsecase_Login_MFA(t *testing.T) {
+	password, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	verifiedAt := time.Now()
+
+	tests := []struct {
+		name           string
+		roles          []domain.Role
+		enabled        bool
+		wantEnrollment bool
+	}{
+		{name: "User with MFA enabled", roles: []domain.Role{{Name: "member"}}, enabled: true},
+		{name: "Role requires MFA that is not set up", roles: []domain.Role{{Name: "doctor", MFARequired: true}}, wantEnrollment: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := &domain.User{
+				ID:              uuid.New(),
+				Email:           "doctor@example.com",
+				Password:        string(password),
+				Status:          constant.USER_STATUS_ACTIVE,
+				EmailVerifiedAt: &verifiedAt,
+				Roles:           tt.roles,
+			}
+
+			repo := mocks.NewMockAuthRepository(ctrl)
+			attempts := mocks.NewMockLoginAttemptRepository(ctrl)
+			attempts.EXPECT().GetFailures(gomock.Any(), gomock.Any()).Return(int64(0), time.Duration(0), nil)
+			attempts.EXPECT().GetDelay(gomock.Any(), gomock.Any()).Return(time.Duration(0), nil)
+			attempts.EXPECT().ResetFailures(gomock.Any(), gomock.Any()).Return(nil)
+			repo.EXPECT().GetUserByEmail(gomock.Any(), user.Email).Return(user, nil)
+
+			var mfa *domain.UserMFA
+			if tt.enabled {
+				mfa, _ = newTestMFA(t, user.ID)
+			}
+			repo.EXPECT().GetUserMFA(gomock.Any(), user.ID).Return(mfa, nil)
+
+			// Only the pending token is created, no access or refresh token
+			repo.EXPECT().CreateUserToken(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, token *domain.UserToken) (*domain.UserToken, error) {
+					if token.Type != constant.AUTH_TOKEN_TYPE_MFA || len(token.Ability) != 0 {
+						t.Errorf("CreateUserToken() = %+v, want an mfa token without abilities", token)
+					}
+					return token, nil
+				})
+
+			resp, err := NewAuthUsecase(repo, attempts, nil, nil, nil, newMFAConfig()).Login(context.Background(), &domain.LoginRequest{
+				Email:    user.Email,
+				Password: "password123",
+			})
+			if err != nil {
+				t.Fatalf("Login() unexpected error = %v", err)
+			}
+
+			if !resp.MFARequired || resp.MFAToken == "" || resp.Token != "" || resp.RefreshToken != "" {
+				t.Errorf("Login() = %+v, want only a pending mfa token", resp)
+			}
+			if resp.MFAEnrollmentRequired != tt.wantEnrollment {
+				t.Errorf("Login() MFAEnrollmentRequired = %v, want %v", resp.MFAEnrollmentRequired, tt.wantEnrollment)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_VerifyLoginMFA(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		req     func(token string, secret string) *domain.MFALoginRequest
+		mock    func(repo *mocks.MockAuthRepository, attempts *mocks.MockLoginAttemptRepository, mfa *domain.UserMFA, token *domain.UserToken)
+		wantErr string
+	}{
+		{
+			name: "Valid code issues a session",
+			req: func(token, secret string) *domain.MFALoginRequest {
+				code, _ := totp.Code(secret, totp.Step(time.Now()))
+				return &domain.MFALoginRequest{MFAToken: token, Code: code}
+			},
+			mock: func(repo *mocks.MockAuthRepository, attempts *mocks.MockLoginAttemptRepository, mfa *domain.UserMFA, token *domain.UserToken) {
+				repo.EXPECT().ClaimMFAStep(gomock.Any(), userID, totp.Step(time.Now())).Return(true, nil)
+				repo.EXPECT().MarkUserTokenUsed(gomock.Any(), token.ID).Return(true, nil)
+				attempts.EXPECT().ResetFailures(gomock.Any(), mfaFailuresKey(userID)).Return(nil)
+				repo.EXPECT().GetUserByID(gomock.Any(), userID).Return(&domain.User{ID: userID}, nil)
+				repo.EXPECT().GetUserPermissions(gomock.Any(), userID).Return([]string{}, nil)
+				repo.EXPECT().CreateUserToken(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, token *domain.UserToken) (*domain.UserToken, error) {
+						return token, nil
+					}).Times(2)
+			},
+		},
+		{
+			name: "Replayed code",
+			req: func(token, secret string) *domain.MFALoginRequest {
+				code, _ := totp.Code(secret, totp.Step(time.Now()))
+				return &domain.MFALoginRequest{MFAToken: token, Code: code}
+			},
+			mock: func(repo *mocks.MockAuthRepository, attempts *mocks.MockLoginAttemptRepository, mfa *domain.UserMFA, token *domain.UserToken) {
+				step := totp.Step(time.Now()) + 1
+				mfa.LastUsedStep = &step
+				attempts.EXPECT().IncrementFailures(gomock.Any(), mfaFailuresKey(userID), constant.LOGIN_FAILURE_WINDOW).Return(int64(1), nil)
+			},
+			wantErr: "invalid mfa code",
+		},
+		{
+			name: "Recovery code",
+			req: func(token, secret string) *domain.MFALoginRequest {
+				return &domain.MFALoginRequest{MFAToken: token, RecoveryCode: "ABCD-EFGH"}
+			},
+			mock: func(repo *mocks.MockAuthRepository, attempts *mocks.MockLoginAttemptRepository, mfa *domain.UserMFA, token *domain.UserToken) {
+				repo.EXPECT().UseLoginRecoveryCode(gomock.Any(), token.ID, userID, tokenhash.Sum("abcdefgh")).Return(true, true, nil)
+				attempts.EXPECT().ResetFailures(gomock.Any(), mfaFailuresKey(userID)).Return(nil)
+				repo.EXPECT().GetUserByID(gomock.Any(), userID).Return(&domain.User{ID: userID}, nil)
+				repo.EXPECT().GetUserPermissions(gomock.Any(), userID).Return([]string{}, nil)
+				repo.EXPECT().CreateUserToken(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, token *domain.UserToken) (*domain.UserToken, error) {
+						return token, nil
+					}).Times(2)
+			},
+		},
+		{
+			name: "Recovery code with a token already used",
+			req: func(token, secret string) *domain.MFALoginRequest {
+				return &domain.MFALoginRequest{MFAToken: token, RecoveryCode: "ABCD-EFGH"}
+			},
+			mock: func(repo *mocks.MockAuthRepository, attempts *mocks.MockLoginAttemptRepository, mfa *domain.UserMFA, token *domain.UserToken) {
+				// The repository leaves the code unused when the token cannot be claimed
+				repo.EXPECT().UseLoginRecoveryCode(gomock.Any(), token.ID, userID, tokenhash.Sum("abcdefgh")).Return(false, false, nil)
+			},
+			wantErr: "invalid mfa token",
+		},
+		{
+			name: "Wrong recovery code",
+			req: func(token, secret string) *domain.MFALoginRequest {
+				return &domain.MFALoginRequest{MFAToken: token, RecoveryCode: "ABCD-EFGH"}
+			},
+			mock: func(repo *mocks.MockAuthRepository, attempts *mocks.MockLoginAttemptRepository, mfa *domain.UserMFA, token *domain.UserToken) {
+				repo.EXPECT().UseLoginRecoveryCode(gomock.Any(), token.ID, userID, tokenhash.Sum("abcdefgh")).Return(true, false, nil)
+				attempts.EXPECT().IncrementFailures(gomock.Any(), mfaFailuresKey(userID), constant.LOGIN_FAILURE_WINDOW).Return(int64(1), nil)
+			},
+			wantErr: "invalid mfa code",
+		},
+		{
+			name: "Too many wrong codes lock the account",
+			req: func(token, secret string) *domain.MFALoginRequest {
+				return &domain.MFALoginRequest{MFAToken: token, Code: "000000", IP: "10.0.0.1"}
+			},
+			mock: func(repo *mocks.MockAuthRepository, attempts *mocks.MockLoginAttemptRepository, mfa *domain.UserMFA, token *domain.UserToken) {
+				attempts.EXPECT().IncrementFailures(gomock.Any(), mfaFailuresKey(userID), constant.LOGIN_FAILURE_WINDOW).Return(int64(constant.MFA_MAX_FAILURES), nil)
+				repo.EXPECT().InvalidateUserToken(gomock.Any(), token.ID).Return(nil)
+				repo.EXPECT().GetUserByID(gomock.Any(), userID).Return(&domain.User{ID: userID, Email: "doctor@example.com"}, nil)
+				repo.EXPECT().LockUser(gomock.Any(), userID, gomock.Any()).Return(nil)
+				attempts.EXPECT().ResetFailures(gomock.Any(), "email:doctor@example.com").Return(nil)
+				repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, log *domain.AuditLog) error {
+						if log.Event != constant.AUDIT_EVENT_ACCOUNT_LOCKED || log.IPAddress != "10.0.0.1" {
+							t.Errorf("CreateAuditLog() = %+v, want an account_locked entry", log)
+						}
+						return nil
+					})
+				attempts.EXPECT().ResetFailures(gomock.Any(), mfaFailuresKey(userID)).Return(nil)
+			},
+			wantErr: "invalid mfa code",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// A code valid at the current step can be reused by another subtest, so every
+			// subtest gets its own secret
+			mfa, secret := newTestMFA(t, userID)
+			token, credential := newPendingMFAToken(userID)
+
+			repo := mocks.NewMockAuthRepository(ctrl)
+			attempts := mocks.NewMockLoginAttemptRepository(ctrl)
+			repo.EXPECT().GetUserTokenByID(gomock.Any(), token.ID).Return(token, nil)
+			repo.EXPECT().GetUserMFA(gomock.Any(), userID).Return(mfa, nil)
+			tt.mock(repo, attempts, mfa, token)
+
+			resp, err := NewAuthUsecase(repo, attempts, nil, nil, nil, newMFAConfig()).VerifyLoginMFA(context.Background(), tt.req(credential, secret))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("VerifyLoginMFA() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyLoginMFA() unexpected error = %v", err)
+			}
+			if resp.Token == "" || resp.RefreshToken == "" || resp.MFARequired {
+				t.Errorf("VerifyLoginMFA() = %+v, want a full session", resp)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_VerifyLoginMFA_WrongTokenType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// An access token cannot stand in for a pending MFA token
+	token, credential := newPendingMFAToken(uuid.New())
+	token.Type = constant.AUTH_TOKEN_TYPE_ACCESS
+
+	repo := mocks.NewMockAuthRepository(ctrl)
+	repo.EXPECT().GetUserTokenByID(gomock.Any(), token.ID).Return(token, nil)
+
+	_, err := NewAuthUsecase(repo, nil, nil, nil, nil, newMFAConfig()).VerifyLoginMFA(context.Background(), &domain.MFALoginRequest{MFAToken: credential, Code: "123456"})
+	if err == nil || err.Error() != "invalid mfa token" {
+		t.Errorf("VerifyLoginMFA() error = %v, wantErr invalid mfa token", err)
+	}
+}
+
+func TestAuthUsecase_EnrollAndConfirmMFA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	repo := mocks.NewMockAuthRepository(ctrl)
+	uc := NewAuthUsecase(repo, nil, nil, nil, nil, newMFAConfig())
+
+	var stored *domain.UserMFA
+	repo.EXPECT().GetUserMFA(gomock.Any(), userID).Return(nil, nil)
+	repo.EXPECT().GetUserByID(gomock.Any(), userID).Return(&domain.User{ID: userID, Email: "doctor@example.com"}, nil)
+	repo.EXPECT().UpsertUserMFA(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, mfa *domain.UserMFA) error {
+			stored = mfa
+			return nil
+		})
+
+	enrollment, err := uc.EnrollMFA(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("EnrollMFA() unexpected error = %v", err)
+	}
+	if stored.Secret == enrollment.Secret {
+		t.Error("EnrollMFA() stored the secret in plain text")
+	}
+	if !strings.HasPrefix(enrollment.URI, "otpauth://totp/Apexa:doctor@example.com?") {
+		t.Errorf("EnrollMFA() URI = %v", enrollment.URI)
+	}
+
+	code, _ := totp.Code(enrollment.Secret, totp.Step(time.Now()))
+	repo.EXPECT().GetUserMFA(gomock.Any(), userID).Return(stored, nil)
+	repo.EXPECT().ClaimMFAStep(gomock.Any(), userID, totp.Step(time.Now())).Return(true, nil)
+	repo.EXPECT().EnableUserMFA(gomock.Any(), userID).Return(nil)
+	repo.EXPECT().ReplaceRecoveryCodes(gomock.Any(), userID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ uuid.UUID, digests []string) error {
+			if len(digests) != constant.MFA_RECOVERY_CODE_COUNT {
+				t.Errorf("ReplaceRecoveryCodes() got %d codes, want %d", len(digests), constant.MFA_RECOVERY_CODE_COUNT)
+			}
+			return nil
+		})
+	repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+
+	resp, err := uc.ConfirmMFA(context.Background(), userID, code)
+	if err != nil {
+		t.Fatalf("ConfirmMFA() unexpected error = %v", err)
+	}
+	if len(resp.RecoveryCodes) != constant.MFA_RECOVERY_CODE_COUNT {
+		t.Errorf("ConfirmMFA() returned %d recovery codes, want %d", len(resp.RecoveryCodes), constant.MFA_RECOVERY_CODE_COUNT)
+	}
+}
+
+func TestAuthUsecase_DisableMFA_RequiredByRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	mfa, secret := newTestMFA(t, userID)
+	code, _ := totp.Code(secret, totp.Step(time.Now()))
+
+	repo := mocks.NewMockAuthRepository(ctrl)
+	repo.EXPECT().GetUserMFA(gomock.Any(), userID).Return(mfa, nil)
+	repo.EXPECT().GetUserRoles(gomock.Any(), userID).Return([]domain.Role{{Name: "admin", MFARequired: true}}, nil)
+
+	err := NewAuthUsecase(repo, nil, nil, nil, nil, newMFAConfig()).DisableMFA(context.Background(), userID, code)
+	if err == nil || err.Error() != "mfa is required for your role" {
+		t.Errorf("DisableMFA() error = %v, wantErr mfa is required for your role", err)
+	}
+}
@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/pkg/app_log"
+	"github.com/gomajido/hospital-cms-golang/pkg/oidc"
+)
+
+// StartOIDCLogin prepares an authorization code login with PKCE at a configured provider.
+// The state, nonce and code verifier stay server side until the callback.
+func (a *authUsecase) StartOIDCLogin(ctx context.Context, providerName string) (*domain.OIDCAuthorizationResponse, error) {
+	provider, ok := a.providers[providerName]
+	if !ok {
+		return nil, errors.New("unknown provider")
+	}
+
+	state, err := a.generateToken()
+	if err != nil {
+		app_log.Errorf("Failed to generate token: %v", err)
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	nonce, err := a.generateToken()
+	if err != nil {
+		app_log.Errorf("Failed to generate token: %v", err)
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	verifier, err := oidc.GenerateCodeVerifier()
+	if err != nil {
+		app_log.Errorf("Failed to generate code verifier: %v", err)
+		return nil, fmt.Errorf("failed to generate code verifier: %w", err)
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallenge(verifier))
+	if err != nil {
+		app_log.Errorf("Failed to build %s authorization URL: %v", providerName, err)
+		return nil, err
+	}
+
+	if err := a.states.SaveState(ctx, state, &domain.OIDCState{
+		Provider:     providerName,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+	}, constant.OIDC_STATE_DURATION); err != nil {
+		app_log.Errorf("Failed to save OIDC state: %v", err)
+		return nil, err
+	}
+
+	return &domain.OIDCAuthorizationResponse{AuthorizationURL: authURL}, nil
+}
+
+// OIDCLogin completes a login at a provider. The first login through an identity links it
+// to the account with the same verified email, or creates a member account.
+func (a *authUsecase) OIDCLogin(ctx context.Context, req *domain.OIDCCallbackRequest) (*domain.LoginResponse, error) {
+	provider, ok := a.providers[req.Provider]
+	if !ok {
+		return nil, errors.New("unknown provider")
+	}
+
+	state, err := a.states.ConsumeState(ctx, req.State)
+	if err != nil {
+		app_log.Errorf("Failed to consume OIDC state: %v", err)
+		return nil, err
+	}
+	if state == nil || state.Provider != req.Provider {
+		return nil, errors.New("invalid state")
+	}
+
+	claims, err := provider.Exchange(ctx, req.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		app_log.Errorf("Failed to exchange %s authorization code: %v", req.Provider, err)
+		return nil, errors.New("sso login failed")
+	}
+
+	user, err := a.userForIdentity(ctx, req.Provider, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.LockedUntil != nil && time.Now().Before(*user.LockedUntil) {
+		return nil, &domain.LoginThrottledError{RetryAfter: time.Until(*user.LockedUntil)}
+	}
+	if user.EmailVerifiedAt == nil {
+		return nil, errors.New("email not verified")
+	}
+	if user.Status != constant.USER_STATUS_ACTIVE {
+		return nil, errors.New("account is not active")
+	}
+
+	return a.completeLogin(ctx, user, domain.ClientInfo{IPAddress: req.IP, UserAgent: req.UserAgent})
+}
+
+// userForIdentity returns the user an identity belongs to, linking the identity on first use
+func (a *authUsecase) userForIdentity(ctx context.Context, providerName string, claims *oidc.Claims) (*domain.User, error) {
+	identity, err := a.repo.GetUserIdentity(ctx, providerName, claims.Subject)
+	if err != nil {
+		app_log.Errorf("Failed to get user identity: %v", err)
+		return nil, err
+	}
+
+	if identity != nil {
+		if err := a.repo.TouchUserIdentity(ctx, identity.ID, claims.Email); err != nil {
+			app_log.Errorf("Failed to update user identity %s: %v", identity.ID, err)
+		}
+
+		user, err := a.repo.GetUserByID(ctx, identity.UserID)
+		if err != nil {
+			app_log.Errorf("Failed to get user by ID: %v", err)
+			return nil, err
+		}
+		if user == nil {
+			return nil, errors.New("account is not active")
+		}
+		return user, nil
+	}
+
+	// An unverified address could belong to someone else, linking it would hand over the account
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, errors.New("email not verified")
+	}
+
+	user, err := a.repo.GetUserByEmail(ctx, claims.Email)
+	if err != nil {
+		app_log.Errorf("Failed to get user by email: %v", err)
+		return nil, err
+	}
+
+	if user == nil {
+		if user, err = a.createOIDCUser(ctx, providerName, claims); err != nil {
+			return nil, err
+		}
+	} else if user.EmailVerifiedAt == nil {
+		// Whoever registered an unverified address may not own it, their credentials must not
+		// survive the owner signing in
+		if err := a.resetUnverifiedCredentials(ctx, user.ID); err != nil {
+			return nil, err
+		}
+
+		// The provider vouched for the address, which is what the emailed link would prove
+		if err := a.repo.MarkUserEmailVerified(ctx, user.ID); err != nil {
+			app_log.Errorf("Failed to mark email verified: %v", err)
+			return nil, err
+		}
+	}
+
+	if err := a.repo.CreateUserIdentity(ctx, &domain.UserIdentity{
+		UserID:   user.ID,
+		Provider: providerName,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}); err != nil {
+		app_log.Errorf("Failed to create user identity: %v", err)
+		return nil, err
+	}
+
+	a.audit(ctx, &domain.AuditLog{
+		Event:    constant.AUDIT_EVENT_IDENTITY_LINKED,
+		UserID:   &user.ID,
+		Email:    user.Email,
+		Metadata: map[string]interface{}{"provider": providerName},
+	})
+
+	return a.repo.GetUserByID(ctx, user.ID)
+}
+
+// resetUnverifiedCredentials takes an account registered with an unverified address away from
+// whoever registered it before the identity is linked: the password is replaced with a random
+// one, every session is revoked and the second factor is removed. The owner can choose a
+// password through the password reset flow.
+func (a *authUsecase) resetUnverifiedCredentials(ctx context.Context, userID uuid.UUID) error {
+	hashedPassword, err := a.randomPasswordHash()
+	if err != nil {
+		return err
+	}
+
+	if err := a.repo.UpdateUserPassword(ctx, userID, hashedPassword); err != nil {
+		app_log.Errorf("Failed to replace password of user %s: %v", userID, err)
+		return err
+	}
+
+	if err := a.repo.InvalidateUserTokens(ctx, userID); err != nil {
+		app_log.Errorf("Failed to invalidate tokens of user %s: %v", userID, err)
+		return err
+	}
+
+	if err := a.repo.DeleteUserMFA(ctx, userID); err != nil {
+		app_log.Errorf("Failed to remove mfa of user %s: %v", userID, err)
+		return err
+	}
+
+	return nil
+}
+
+// randomPasswordHash hashes a random password nobody knows
+func (a *authUsecase) randomPasswordHash() (string, error) {
+	password, err := a.generateToken()
+	if err != nil {
+		app_log.Errorf("Failed to generate token: %v", err)
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		app_log.Errorf("Failed to hash password: %v", err)
+		return "", err
+	}
+
+	return string(hashedPassword), nil
+}
+
+// createOIDCUser creates the member account of a first time SSO user. The random
+// password can only be replaced through the password reset flow.
+func (a *authUsecase) createOIDCUser(ctx context.Context, providerName string, claims *oidc.Claims) (*domain.User, error) {
+	hashedPassword, err := a.randomPasswordHash()
+	if err != nil {
+		return nil, err
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = strings.SplitN(claims.Email, "@", 2)[0]
+	}
+
+	user := &domain.User{
+		Name:         name,
+		Email:        claims.Email,
+		Password:     hashedPassword,
+		RegisterFrom: providerName,
+	}
+
+	if err := a.repo.CreateUser(ctx, user); err != nil {
+		app_log.Errorf("Failed to create user: %v", err)
+		return nil, err
+	}
+
+	if err := a.repo.MarkUserEmailVerified(ctx, user.ID); err != nil {
+		app_log.Errorf("Failed to mark email verified: %v", err)
+		return nil, err
+	}
+
+	if err := a.assignMemberRole(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain/mocks"
+	"github.com/gomajido/hospital-cms-golang/pkg/oidc"
+	oidcMocks "github.com/gomajido/hospital-cms-golang/pkg/oidc/mocks"
+)
+
+func TestAuthUsecase_StartOIDCLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	states := mocks.NewMockOIDCStateRepository(ctrl)
+	provider := oidcMocks.NewMockIOIDCProviderRepository(ctrl)
+
+	var challenge, state, nonce string
+	provider.EXPECT().AuthCodeURL(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, s, n, c string) (string, error) {
+			state, nonce, challenge = s, n, c
+			return "https://sso.hospital.test/authorize?state=" + s, nil
+		})
+	states.EXPECT().SaveState(gomock.Any(), gomock.Any(), gomock.Any(), constant.OIDC_STATE_DURATION).DoAndReturn(
+		func(_ context.Context, s string, value *domain.OIDCState, _ time.Duration) error {
+			if s != state || value.Provider != "hospital" || value.Nonce != nonce {
+				t.Errorf("SaveState() = %s %+v, want the state and nonce sent to the provider", s, value)
+			}
+			if oidc.CodeChallenge(value.CodeVerifier) != challenge {
+				t.Error("SaveState() code verifier does not match the challenge sent to the provider")
+			}
+			return nil
+		})
+
+	uc := NewAuthUsecase(nil, nil, states, nil, map[string]oidc.IOIDCProviderRepository{"hospital": provider}, newMFAConfig())
+	resp, err := uc.StartOIDCLogin(context.Background(), "hospital")
+	if err != nil {
+		t.Fatalf("StartOIDCLogin() unexpected error = %v", err)
+	}
+	if resp.AuthorizationURL != "https://sso.hospital.test/authorize?state="+state {
+		t.Errorf("StartOIDCLogin() = %+v, want the provider authorization URL", resp)
+	}
+
+	if _, err := uc.StartOIDCLogin(context.Background(), "other"); err == nil || err.Error() != "unknown provider" {
+		t.Errorf("StartOIDCLogin() error = %v, want unknown provider", err)
+	}
+}
+
+func TestAuthUsecase_OIDCLogin(t *testing.T) {
+	verifiedAt := time.Now()
+	user := &domain.User{ID: uuid.New(), Email: "doctor@hospital.test", Status: constant.USER_STATUS_ACTIVE, EmailVerifiedAt: &verifiedAt}
+	claims := &oidc.Claims{Subject: "sub-1", Email: user.Email, EmailVerified: true, Name: "Doctor"}
+	pending := &domain.OIDCState{Provider: "hospital", Nonce: "nonce", CodeVerifier: "verifier"}
+
+	tests := []struct {
+		name    string
+		mock    func(repo *mocks.MockAuthRepository, states *mocks.MockOIDCStateRepository, provider *oidcMocks.MockIOIDCProviderRepository)
+		wantErr string
+	}{
+		{
+			name: "Linked identity",
+			mock: func(repo *mocks.MockAuthRepository, states *mocks.MockOIDCStateRepository, provider *oidcMocks.MockIOIDCProviderRepository) {
+				states.EXPECT().ConsumeState(gomock.Any(), "state").Return(pending, nil)
+				provider.EXPECT().Exchange(gomock.Any(), "code", "verifier", "nonce").Return(claims, nil)
+				identity := &domain.UserIdentity{ID: uuid.New(), UserID: user.ID, Provider: "hospital", Subject: "sub-1"}
+				repo.EXPECT().GetUserIdentity(gomock.Any(), "hospital", "sub-1").Return(identity, nil)
+				repo.EXPECT().TouchUserIdentity(gomock.Any(), identity.ID, user.Email).Return(nil)
+				repo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+			},
+		},
+		{
+			name: "First login links the account with the same email",
+			mock: func(repo *mocks.MockAuthRepository, states *mocks.MockOIDCStateRepository, provider *oidcMocks.MockIOIDCProviderRepository) {
+				states.EXPECT().ConsumeState(gomock.Any(), "state").Return(pending, nil)
+				provider.EXPECT().Exchange(gomock.Any(), "code", "verifier", "nonce").Return(claims, nil)
+				repo.EXPECT().GetUserIdentity(gomock.Any(), "hospital", "sub-1").Return(nil, nil)
+				repo.EXPECT().GetUserByEmail(gomock.Any(), user.Email).Return(user, nil)
+				repo.EXPECT().CreateUserIdentity(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, identity *domain.UserIdentity) error {
+						if identity.UserID != user.ID || identity.Provider != "hospital" || identity.Subject != "sub-1" {
+							t.Errorf("CreateUserIdentity() = %+v, want the provider account linked to the user", identity)
+						}
+						return nil
+					})
+				repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+			},
+		},
+		{
+			name: "Linking an unverified account replaces the credentials of whoever registered it",
+			mock: func(repo *mocks.MockAuthRepository, states *mocks.MockOIDCStateRepository, provider *oidcMocks.MockIOIDCProviderRepository) {
+				states.EXPECT().ConsumeState(gomock.Any(), "state").Return(pending, nil)
+				provider.EXPECT().Exchange(gomock.Any(), "code", "verifier", "nonce").Return(claims, nil)
+				repo.EXPECT().GetUserIdentity(gomock.Any(), "hospital", "sub-1").Return(nil, nil)
+
+				attackerHash, _ := bcrypt.GenerateFromPassword([]byte("attacker-password"), bcrypt.MinCost)
+				unverified := &domain.User{ID: user.ID, Email: user.Email, Password: string(attackerHash), Status: constant.USER_STATUS_INACTIVE}
+				repo.EXPECT().GetUserByEmail(gomock.Any(), user.Email).Return(unverified, nil)
+
+				gomock.InOrder(
+					repo.EXPECT().UpdateUserPassword(gomock.Any(), user.ID, gomock.Any()).DoAndReturn(
+						func(_ context.Context, _ uuid.UUID, password string) error {
+							if bcrypt.CompareHashAndPassword([]byte(password), []byte("attacker-password")) == nil {
+								t.Errorf("UpdateUserPassword() kept the registered password")
+							}
+							return nil
+						}),
+					repo.EXPECT().InvalidateUserTokens(gomock.Any(), user.ID).Return(nil),
+					repo.EXPECT().DeleteUserMFA(gomock.Any(), user.ID).Return(nil),
+					repo.EXPECT().MarkUserEmailVerified(gomock.Any(), user.ID).Return(nil),
+					repo.EXPECT().CreateUserIdentity(gomock.Any(), gomock.Any()).Return(nil),
+				)
+				repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+			},
+		},
+		{
+			name: "First login creates a member account",
+			mock: func(repo *mocks.MockAuthRepository, states *mocks.MockOIDCStateRepository, provider *oidcMocks.MockIOIDCProviderRepository) {
+				states.EXPECT().ConsumeState(gomock.Any(), "state").Return(pending, nil)
+				provider.EXPECT().Exchange(gomock.Any(), "code", "verifier", "nonce").Return(claims, nil)
+				repo.EXPECT().GetUserIdentity(gomock.Any(), "hospital", "sub-1").Return(nil, nil)
+				repo.EXPECT().GetUserByEmail(gomock.Any(), user.Email).Return(nil, nil)
+				repo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, created *domain.User) error {
+						if created.Email != user.Email || created.Name != "Doctor" || created.RegisterFrom != "hospital" || created.Password == "" {
+							t.Errorf("CreateUser() = %+v, want an account from the ID token claims", created)
+						}
+						created.ID = user.ID
+						return nil
+					})
+				repo.EXPECT().MarkUserEmailVerified(gomock.Any(), user.ID).Return(nil)
+				repo.EXPECT().GetRolesByNames(gomock.Any(), []string{"member"}).Return([]domain.Role{{ID: uuid.New(), Name: "member"}}, nil)
+				repo.EXPECT().AssignRolesToUser(gomock.Any(), user.ID, gomock.Any()).Return(nil)
+				repo.EXPECT().CreateUserIdentity(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+			},
+		},
+		{
+			name: "Unverified provider email is not linked",
+			mock: func(repo *mocks.MockAuthRepository, states *mocks.MockOIDCStateRepository, provider *oidcMocks.MockIOIDCProviderRepository) {
+				states.EXPECT().ConsumeState(gomock.Any(), "state").Return(pending, nil)
+				provider.EXPECT().Exchange(gomock.Any(), "code", "verifier", "nonce").Return(&oidc.Claims{Subject: "sub-1", Email: user.Email}, nil)
+				repo.EXPECT().GetUserIdentity(gomock.Any(), "hospital", "sub-1").Return(nil, nil)
+			},
+			wantErr: "email not verified",
+		},
+		{
+			name: "Unknown or replayed state",
+			mock: func(repo *mocks.MockAuthRepository, states *mocks.MockOIDCStateRepository, provider *oidcMocks.MockIOIDCProviderRepository) {
+				states.EXPECT().ConsumeState(gomock.Any(), "state").Return(nil, nil)
+			},
+			wantErr: "invalid state",
+		},
+		{
+			name: "State issued for another provider",
+			mock: func(repo *mocks.MockAuthRepository, states *mocks.MockOIDCStateRepository, provider *oidcMocks.MockIOIDCProviderRepository) {
+				states.EXPECT().ConsumeState(gomock.Any(), "state").Return(&domain.OIDCState{Provider: "google"}, nil)
+			},
+			wantErr: "invalid state",
+		},
+		{
+			name: "Rejected code exchange",
+			mock: func(repo *mocks.MockAuthRepository, states *mocks.MockOIDCStateRepository, provider *oidcMocks.MockIOIDCProviderRepository) {
+				states.EXPECT().ConsumeState(gomock.Any(), "state").Return(pending, nil)
+				provider.EXPECT().Exchange(gomock.Any(), "code", "verifier", "nonce").Return(nil, oidc.ErrNonceMismatch)
+			},
+			wantErr: "sso login failed",
+		},
+		{
+			name: "Suspended account",
+			mock: func(repo *mocks.MockAuthRepository, states *mocks.MockOIDCStateRepository, provider *oidcMocks.MockIOIDCProviderRepository) {
+				states.EXPECT().ConsumeState(gomock.Any(), "state").Return(pending, nil)
+				provider.EXPECT().Exchange(gomock.Any(), "code", "verifier", "nonce").Return(claims, nil)
+				identity := &domain.UserIdentity{ID: uuid.New(), UserID: user.ID}
+				repo.EXPECT().GetUserIdentity(gomock.Any(), "hospital", "sub-1").Return(identity, nil)
+				repo.EXPECT().TouchUserIdentity(gomock.Any(), identity.ID, user.Email).Return(nil)
+				suspended := *user
+				suspended.Status = constant.USER_STATUS_SUSPENDED
+				repo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(&suspended, nil)
+			},
+			wantErr: "account is not active",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAuthRepository(ctrl)
+			states := mocks.NewMockOIDCStateRepository(ctrl)
+			provider := oidcMocks.NewMockIOIDCProviderRepository(ctrl)
+			tt.mock(repo, states, provider)
+
+			if tt.wantErr == "" {
+				repo.EXPECT().GetUserMFA(gomock.Any(), user.ID).Return(nil, nil)
+				repo.EXPECT().GetUserPermissions(gomock.Any(), user.ID).Return(nil, nil)
+				repo.EXPECT().CreateUserToken(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, token *domain.UserToken) (*domain.UserToken, error) {
+						return token, nil
+					}).Times(2)
+			}
+
+			uc := NewAuthUsecase(repo, nil, states, nil, map[string]oidc.IOIDCProviderRepository{"hospital": provider}, newMFAConfig())
+			resp, err := uc.OIDCLogin(context.Background(), &domain.OIDCCallbackRequest{Provider: "hospital", Code: "code", State: "state"})
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("OIDCLogin() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OIDCLogin() unexpected error = %v", err)
+			}
+			if resp.ID != user.ID || resp.Token == "" || resp.RefreshToken == "" {
+				t.Errorf("OIDCLogin() = %+v, want a token pair for the user", resp)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_OIDCLogin_UnknownProvider(t *testing.T) {
+	_, err := NewAuthUsecase(nil, nil, nil, nil, nil, newMFAConfig()).OIDCLogin(context.Background(), &domain.OIDCCallbackRequest{Provider: "other"})
+	if err == nil || err.Error() != "unknown provider" {
+		t.Errorf("OIDCLogin() error = %v, want unknown provider", err)
+	}
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/tokenhash"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/pkg/app_log"
+	"github.com/gomajido/hospital-cms-golang/pkg/mailer"
+	"github.com/google/uuid"
+)
+
+// resetPasswordPath is the page of the web app choosing a new password
+const resetPasswordPath = "/reset-password"
+
+// ForgotPassword mails a single-use password reset token. The request is answered before
+// the address is even looked up, so neither the answer nor its timing tell whether the
+// email is registered. Failures are only logged.
+func (a *authUsecase) ForgotPassword(ctx context.Context, email string) error {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), constant.PASSWORD_RESET_SEND_TIMEOUT)
+		defer cancel()
+
+		if err := a.sendPasswordReset(ctx, email); err != nil {
+			app_log.Errorf("Failed to send password reset: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// sendPasswordReset mails a reset token to a registered address, unknown addresses are ignored
+func (a *authUsecase) sendPasswordReset(ctx context.Context, email string) error {
+	user, err := a.repo.GetUserByEmail(ctx, email)
+	if err != nil {
+		app_log.Errorf("Failed to get user by email: %v", err)
+		return err
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	// Only the most recent reset email can be used
+	if err := a.repo.InvalidatePasswordResetTokens(ctx, user.ID); err != nil {
+		app_log.Errorf("Failed to invalidate password reset tokens: %v", err)
+		return err
+	}
+
+	raw, err := a.generateToken()
+	if err != nil {
+		app_log.Errorf("Failed to generate token: %v", err)
+		return fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	token := &domain.PasswordResetToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Token:     tokenhash.Sum(raw),
+		ExpiredAt: time.Now().Add(constant.PASSWORD_RESET_TOKEN_DURATION),
+	}
+
+	if err := a.repo.CreatePasswordResetToken(ctx, token); err != nil {
+		app_log.Errorf("Failed to create password reset token: %v", err)
+		return err
+	}
+
+	if err := a.mailer.Send(ctx, passwordResetMessage(user, a.cfg.Http.FrontendURL, token.ID.String()+"|"+raw)); err != nil {
+		app_log.Errorf("Failed to send password reset email: %v", err)
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password with a reset token and signs the user out everywhere
+func (a *authUsecase) ResetPassword(ctx context.Context, req *domain.ResetPasswordRequest) error {
+	tokenID, raw, err := splitToken(req.Token)
+	if err != nil {
+		return errors.New("invalid reset token")
+	}
+
+	token, err := a.repo.GetPasswordResetToken(ctx, tokenID)
+	if err != nil {
+		app_log.Errorf("Failed to get password reset token: %v", err)
+		return err
+	}
+
+	if token == nil || token.UsedAt != nil || !tokenhash.Equal(raw, token.Token) {
+		return errors.New("invalid reset token")
+	}
+
+	if time.Now().After(token.ExpiredAt) {
+		return errors.New("reset token expired")
+	}
+
+	used, err := a.repo.MarkPasswordResetTokenUsed(ctx, token.ID)
+	if err != nil {
+		app_log.Errorf("Failed to consume password reset token: %v", err)
+		return err
+	}
+	if !used {
+		return errors.New("invalid reset token")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		app_log.Errorf("Failed to hash password: %v", err)
+		return err
+	}
+
+	if err := a.repo.UpdateUserPassword(ctx, token.UserID, string(hashedPassword)); err != nil {
+		app_log.Errorf("Failed to update password: %v", err)
+		return err
+	}
+
+	if err := a.InvalidateUserTokens(ctx, token.UserID); err != nil {
+		app_log.Errorf("Failed to invalidate user tokens: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func passwordResetMessage(user *domain.User, frontendURL, token string) *mailer.Message {
+	link := strings.TrimRight(frontendURL, "/") + resetPasswordPath + "?" + url.Values{"token": {token}}.Encode()
+
+	return &mailer.Message{
+		To:      []string{user.Email},
+		Subject: "Reset your password",
+		TextBody: fmt.Sprintf(
+			"Hello %s,\n\nWe received a request to reset your password. Open the link below to choose a new one:\n\n%s\n\nThe link expires in 1 hour. If you did not ask for a reset, you can ignore this email.\n",
+			user.Name, link,
+		),
+		HTMLBody: fmt.Sprintf(
+			`<p>Hello %s,</p><p>We received a request to reset your password. Click the link below to choose a new one:</p><p><a href="%s">Reset password</a></p><p>The link expires in 1 hour. If you did not ask for a reset, you can ignore this email.</p>`,
+			html.EscapeString(user.Name), html.EscapeString(link),
+		),
+	}
+}
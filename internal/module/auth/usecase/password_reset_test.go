@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gomajido/hospital-cms-golang/config"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain/mocks"
+	"github.com/gomajido/hospital-cms-golang/pkg/mailer"
+	mailerMocks "github.com/gomajido/hospital-cms-golang/pkg/mailer/mocks"
+)
+
+var resetLinkRegex = regexp.MustCompile(`https://app\.hospital\.test/reset-password\?\S+`)
+
+func TestAuthUsecase_ForgotAndResetPassword(t *testing.T) {
+	user := &domain.User{ID: uuid.New(), Email: "patient@example.com", Name: "Patient"}
+
+	tests := []struct {
+		name     string
+		password string
+		tamper   func(token string) string
+		mock     func(repo *mocks.MockAuthRepository, token *domain.PasswordResetToken)
+		wantErr  string
+	}{
+		{
+			name:     "Reset sets the password and signs out every session",
+			password: "new-password",
+			mock: func(repo *mocks.MockAuthRepository, token *domain.PasswordResetToken) {
+				repo.EXPECT().GetPasswordResetToken(gomock.Any(), token.ID).Return(token, nil)
+				repo.EXPECT().MarkPasswordResetTokenUsed(gomock.Any(), token.ID).Return(true, nil)
+				repo.EXPECT().UpdateUserPassword(gomock.Any(), user.ID, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ uuid.UUID, hashed string) error {
+						if bcrypt.CompareHashAndPassword([]byte(hashed), []byte("new-password")) != nil {
+							t.Errorf("UpdateUserPassword() stored %q, want a bcrypt hash of the new password", hashed)
+						}
+						return nil
+					})
+				repo.EXPECT().InvalidateUserTokens(gomock.Any(), user.ID).Return(nil)
+			},
+		},
+		{
+			name:     "Wrong token",
+			password: "new-password",
+			tamper:   func(token string) string { return token + "x" },
+			mock: func(repo *mocks.MockAuthRepository, token *domain.PasswordResetToken) {
+				repo.EXPECT().GetPasswordResetToken(gomock.Any(), token.ID).Return(token, nil)
+			},
+			wantErr: "invalid reset token",
+		},
+		{
+			name:     "Malformed token",
+			password: "new-password",
+			tamper:   func(string) string { return "not-a-token" },
+			mock:     func(repo *mocks.MockAuthRepository, token *domain.PasswordResetToken) {},
+			wantErr:  "invalid reset token",
+		},
+		{
+			name:     "Expired token",
+			password: "new-password",
+			mock: func(repo *mocks.MockAuthRepository, token *domain.PasswordResetToken) {
+				token.ExpiredAt = time.Now().Add(-time.Minute)
+				repo.EXPECT().GetPasswordResetToken(gomock.Any(), token.ID).Return(token, nil)
+			},
+			wantErr: "reset token expired",
+		},
+		{
+			name:     "Token already used",
+			password: "new-password",
+			mock: func(repo *mocks.MockAuthRepository, token *domain.PasswordResetToken) {
+				repo.EXPECT().GetPasswordResetToken(gomock.Any(), token.ID).Return(token, nil)
+				repo.EXPECT().MarkPasswordResetTokenUsed(gomock.Any(), token.ID).Return(false, nil)
+			},
+			wantErr: "invalid reset token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAuthRepository(ctrl)
+			m := mailerMocks.NewMockIMailerProviderRepository(ctrl)
+			uc := NewAuthUsecase(repo, nil, nil, m, nil, &config.Config{Http: config.HttpConfig{BaseURL: "https://api.hospital.test", FrontendURL: "https://app.hospital.test"}})
+
+			var stored *domain.PasswordResetToken
+			var link string
+			repo.EXPECT().GetUserByEmail(gomock.Any(), user.Email).Return(user, nil)
+			repo.EXPECT().InvalidatePasswordResetTokens(gomock.Any(), user.ID).Return(nil)
+			repo.EXPECT().CreatePasswordResetToken(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, token *domain.PasswordResetToken) error {
+					stored = token
+					return nil
+				})
+			m.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, message *mailer.Message) error {
+					link = resetLinkRegex.FindString(message.TextBody)
+					return nil
+				})
+
+			if err := uc.(*authUsecase).sendPasswordReset(context.Background(), user.Email); err != nil {
+				t.Fatalf("sendPasswordReset() error = %v", err)
+			}
+
+			parsed, err := url.Parse(link)
+			if err != nil || link == "" {
+				t.Fatalf("reset email has no link: %q", link)
+			}
+			token := parsed.Query().Get("token")
+			if tt.tamper != nil {
+				token = tt.tamper(token)
+			}
+			tt.mock(repo, stored)
+
+			err = uc.ResetPassword(context.Background(), &domain.ResetPasswordRequest{Token: token, Password: tt.password})
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("ResetPassword() unexpected error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("ResetPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_ForgotPassword_UnknownEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockAuthRepository(ctrl)
+	repo.EXPECT().GetUserByEmail(gomock.Any(), "nobody@example.com").Return(nil, nil)
+
+	uc := NewAuthUsecase(repo, nil, nil, mailerMocks.NewMockIMailerProviderRepository(ctrl), nil, &config.Config{})
+	if err := uc.(*authUsecase).sendPasswordReset(context.Background(), "nobody@example.com"); err != nil {
+		t.Errorf("sendPasswordReset() unexpected error = %v", err)
+	}
+}
+
+func TestAuthUsecase_ForgotPassword_AnswersBeforeSending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &domain.User{ID: uuid.New(), Email: "patient@example.com", Name: "Patient"}
+	release := make(chan struct{})
+	sent := make(chan struct{})
+
+	repo := mocks.NewMockAuthRepository(ctrl)
+	m := mailerMocks.NewMockIMailerProviderRepository(ctrl)
+	repo.EXPECT().GetUserByEmail(gomock.Any(), user.Email).DoAndReturn(
+		func(context.Context, string) (*domain.User, error) {
+			<-release
+			return user, nil
+		})
+	repo.EXPECT().InvalidatePasswordResetTokens(gomock.Any(), user.ID).Return(nil)
+	repo.EXPECT().CreatePasswordResetToken(gomock.Any(), gomock.Any()).Return(nil)
+	m.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, *mailer.Message) error {
+			close(sent)
+			return errors.New("mailer down")
+		})
+
+	uc := NewAuthUsecase(repo, nil, nil, m, nil, &config.Config{})
+
+	// The lookup is held back, a registered address must still be answered right away
+	if err := uc.ForgotPassword(context.Background(), user.Email); err != nil {
+		t.Errorf("ForgotPassword() error = %v, want nil even when the mail fails", err)
+	}
+	close(release)
+
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("ForgotPassword() never sent the reset email")
+	}
+}
@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/signedurl"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/tokenhash"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/pkg/app_log"
+	"github.com/gomajido/hospital-cms-golang/pkg/mailer"
+	"github.com/google/uuid"
+)
+
+const confirmEmailPath = "/api/v1/auth/email/confirm"
+
+// UpdateProfile updates the name and phone of a user. The status is left as stored.
+func (a *authUsecase) UpdateProfile(ctx context.Context, userID uuid.UUID, req *domain.UpdateUserRequest) (*domain.User, error) {
+	user, err := a.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Name = req.Name
+	user.Phone = req.Phone
+
+	if err := a.repo.UpdateUser(ctx, user); err != nil {
+		app_log.Errorf("Failed to update profile: %v", err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ChangePassword sets a new password after checking the current one, and signs every
+// other session out so a stolen password stops working
+func (a *authUsecase) ChangePassword(ctx context.Context, userID, currentTokenID uuid.UUID, req *domain.ChangePasswordRequest) error {
+	user, err := a.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword)) != nil {
+		return errors.New("invalid current password")
+	}
+
+	current, err := a.GetUserTokenByID(ctx, currentTokenID)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		app_log.Errorf("Failed to hash password: %v", err)
+		return err
+	}
+
+	if err := a.repo.UpdateUserPassword(ctx, userID, string(hashedPassword)); err != nil {
+		app_log.Errorf("Failed to update password: %v", err)
+		return err
+	}
+
+	if err := a.repo.InvalidateOtherUserTokens(ctx, userID, current.FamilyID); err != nil {
+		app_log.Errorf("Failed to revoke other sessions: %v", err)
+		return err
+	}
+
+	a.audit(ctx, &domain.AuditLog{Event: constant.AUDIT_EVENT_PASSWORD_CHANGED, UserID: &userID, ActorID: &userID})
+
+	return nil
+}
+
+// RequestEmailChange mails a confirmation link to the new address. The email of the
+// user only changes once the link is opened.
+func (a *authUsecase) RequestEmailChange(ctx context.Context, userID uuid.UUID, req *domain.ChangeEmailRequest) error {
+	user, err := a.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)) != nil {
+		return errors.New("invalid current password")
+	}
+
+	if strings.EqualFold(user.Email, req.Email) {
+		return errors.New("email unchanged")
+	}
+
+	if err := a.ensureEmailAvailable(ctx, req.Email); err != nil {
+		return err
+	}
+
+	// Only the most recent confirmation link can be used
+	if err := a.repo.InvalidateEmailChangeTokens(ctx, userID); err != nil {
+		app_log.Errorf("Failed to invalidate email change tokens: %v", err)
+		return err
+	}
+
+	raw, err := a.generateToken()
+	if err != nil {
+		app_log.Errorf("Failed to generate token: %v", err)
+		return fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	token := &domain.EmailChangeToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		NewEmail:  req.Email,
+		Token:     tokenhash.Sum(raw),
+		ExpiredAt: time.Now().Add(constant.EMAIL_CHANGE_TOKEN_DURATION),
+	}
+
+	link, err := signedurl.Sign(
+		strings.TrimRight(a.cfg.Http.BaseURL, "/")+confirmEmailPath,
+		url.Values{"id": {token.ID.String()}, "token": {raw}},
+		token.ExpiredAt,
+		a.cfg.Token.SigningSecret,
+	)
+	if err != nil {
+		app_log.Errorf("Failed to sign email change link: %v", err)
+		return err
+	}
+
+	if err := a.repo.CreateEmailChangeToken(ctx, token); err != nil {
+		app_log.Errorf("Failed to create email change token: %v", err)
+		return err
+	}
+
+	if err := a.mailer.Send(ctx, emailChangeMessage(user, req.Email, link)); err != nil {
+		app_log.Errorf("Failed to send email change confirmation: %v", err)
+		return fmt.Errorf("failed to send email change confirmation: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange consumes a signed confirmation link and moves the user to the new address
+func (a *authUsecase) ConfirmEmailChange(ctx context.Context, req *domain.VerifyEmailRequest) error {
+	if err := signedurl.Verify(req.Values(), a.cfg.Token.SigningSecret, time.Now()); err != nil {
+		if errors.Is(err, signedurl.ErrExpired) {
+			return errors.New("confirmation link expired")
+		}
+		if errors.Is(err, signedurl.ErrMissingSecret) {
+			return err
+		}
+		return errors.New("invalid confirmation link")
+	}
+
+	tokenID, err := uuid.Parse(req.ID)
+	if err != nil {
+		return errors.New("invalid confirmation link")
+	}
+
+	token, err := a.repo.GetEmailChangeToken(ctx, tokenID)
+	if err != nil {
+		app_log.Errorf("Failed to get email change token: %v", err)
+		return err
+	}
+
+	if token == nil || token.UsedAt != nil || !tokenhash.Equal(req.Token, token.Token) {
+		return errors.New("invalid confirmation link")
+	}
+
+	if time.Now().After(token.ExpiredAt) {
+		return errors.New("confirmation link expired")
+	}
+
+	// The address may have been taken since the link was sent
+	if err := a.ensureEmailAvailable(ctx, token.NewEmail); err != nil {
+		return err
+	}
+
+	used, err := a.repo.MarkEmailChangeTokenUsed(ctx, token.ID)
+	if err != nil {
+		app_log.Errorf("Failed to consume email change token: %v", err)
+		return err
+	}
+	if !used {
+		return errors.New("invalid confirmation link")
+	}
+
+	user, err := a.repo.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.repo.UpdateUserEmail(ctx, token.UserID, token.NewEmail); err != nil {
+		app_log.Errorf("Failed to update email: %v", err)
+		return err
+	}
+
+	a.audit(ctx, &domain.AuditLog{
+		Event:    constant.AUDIT_EVENT_EMAIL_CHANGED,
+		UserID:   &token.UserID,
+		ActorID:  &token.UserID,
+		Email:    token.NewEmail,
+		Metadata: map[string]interface{}{"previous_email": user.Email},
+	})
+
+	return nil
+}
+
+// ensureEmailAvailable fails when another account already uses the address
+func (a *authUsecase) ensureEmailAvailable(ctx context.Context, email string) error {
+	existing, err := a.repo.GetUserByEmail(ctx, email)
+	if err != nil {
+		app_log.Errorf("Failed to get user by email: %v", err)
+		return err
+	}
+	if existing != nil {
+		return errors.New("email already in use")
+	}
+	return nil
+}
+
+func emailChangeMessage(user *domain.User, email, link string) *mailer.Message {
+	return &mailer.Message{
+		To:      []string{email},
+		Subject: "Confirm your new email address",
+		TextBody: fmt.Sprintf(
+			"Hello %s,\n\nPlease confirm that you want to use this address for your account by opening the link below:\n\n%s\n\nThe link expires in 24 hours. If you did not ask for this change, you can ignore this email.\n",
+			user.Name, link,
+		),
+		HTMLBody: fmt.Sprintf(
+			`<p>Hello %s,</p><p>Please confirm that you want to use this address for your account by clicking the link below:</p><p><a href="%s">Confirm email</a></p><p>The link expires in 24 hours. If you did not ask for this change, you can ignore this email.</p>`,
+			html.EscapeString(user.Name), html.EscapeString(link),
+		),
+	}
+}
@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gomajido/hospital-cms-golang/config"
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain/mocks"
+	"github.com/gomajido/hospital-cms-golang/pkg/mailer"
+	mailerMocks "github.com/gomajido/hospital-cms-golang/pkg/mailer/mocks"
+)
+
+var emailChangeLinkRegex = regexp.MustCompile(`https://hospital\.test/api/v1/auth/email/confirm\?\S+`)
+
+func newTestProfileUser(t *testing.T) *domain.User {
+	hashed, err := bcrypt.GenerateFromPassword([]byte("current-password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword() error = %v", err)
+	}
+	return &domain.User{ID: uuid.New(), Email: "patient@example.com", Name: "Patient", Password: string(hashed), Status: constant.USER_STATUS_ACTIVE}
+}
+
+func TestAuthUsecase_UpdateProfile_KeepsStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := newTestProfileUser(t)
+	user.Status = constant.USER_STATUS_SUSPENDED
+
+	repo := mocks.NewMockAuthRepository(ctrl)
+	repo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+	repo.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, updated *domain.User) error {
+			if updated.Name != "New Name" || updated.Phone != "0800" {
+				t.Errorf("UpdateUser() = %+v, want the new name and phone", updated)
+			}
+			if updated.Status != constant.USER_STATUS_SUSPENDED || updated.Email != "patient@example.com" {
+				t.Errorf("UpdateUser() changed status or email: %+v", updated)
+			}
+			return nil
+		})
+
+	req := &domain.UpdateUserRequest{Name: "New Name", Phone: "0800"}
+	if _, err := NewAuthUsecase(repo, nil, nil, nil, nil, &config.Config{}).UpdateProfile(context.Background(), user.ID, req); err != nil {
+		t.Errorf("UpdateProfile() unexpected error = %v", err)
+	}
+}
+
+func TestAuthUsecase_ChangePassword(t *testing.T) {
+	tokenID := uuid.New()
+	familyID := uuid.New()
+
+	tests := []struct {
+		name            string
+		currentPassword string
+		mock            func(repo *mocks.MockAuthRepository, user *domain.User)
+		wantErr         string
+	}{
+		{
+			name:            "Change keeps the current session only",
+			currentPassword: "current-password",
+			mock: func(repo *mocks.MockAuthRepository, user *domain.User) {
+				repo.EXPECT().GetUserTokenByID(gomock.Any(), tokenID).Return(&domain.UserToken{ID: tokenID, UserID: user.ID, FamilyID: familyID}, nil)
+				repo.EXPECT().UpdateUserPassword(gomock.Any(), user.ID, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ uuid.UUID, hashed string) error {
+						if bcrypt.CompareHashAndPassword([]byte(hashed), []byte("new-password")) != nil {
+							t.Errorf("UpdateUserPassword() stored %q, want a bcrypt hash of the new password", hashed)
+						}
+						return nil
+					})
+				repo.EXPECT().InvalidateOtherUserTokens(gomock.Any(), user.ID, familyID).Return(nil)
+				repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, log *domain.AuditLog) error {
+						if log.Event != constant.AUDIT_EVENT_PASSWORD_CHANGED {
+							t.Errorf("CreateAuditLog() event = %v, want password_changed", log.Event)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name:            "Wrong current password",
+			currentPassword: "wrong-password",
+			mock:            func(repo *mocks.MockAuthRepository, user *domain.User) {},
+			wantErr:         "invalid current password",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := newTestProfileUser(t)
+			repo := mocks.NewMockAuthRepository(ctrl)
+			repo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+			tt.mock(repo, user)
+
+			req := &domain.ChangePasswordRequest{CurrentPassword: tt.currentPassword, Password: "new-password"}
+			err := NewAuthUsecase(repo, nil, nil, nil, nil, &config.Config{}).ChangePassword(context.Background(), user.ID, tokenID, req)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("ChangePassword() unexpected error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("ChangePassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_ChangeEmail(t *testing.T) {
+	const newEmail = "new@example.com"
+
+	tests := []struct {
+		name    string
+		mock    func(repo *mocks.MockAuthRepository, user *domain.User, token *domain.EmailChangeToken)
+		wantErr string
+	}{
+		{
+			name: "Confirmed link moves the user to the new address",
+			mock: func(repo *mocks.MockAuthRepository, user *domain.User, token *domain.EmailChangeToken) {
+				repo.EXPECT().GetEmailChangeToken(gomock.Any(), token.ID).Return(token, nil)
+				repo.EXPECT().GetUserByEmail(gomock.Any(), newEmail).Return(nil, nil)
+				repo.EXPECT().MarkEmailChangeTokenUsed(gomock.Any(), token.ID).Return(true, nil)
+				repo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+				repo.EXPECT().UpdateUserEmail(gomock.Any(), user.ID, newEmail).Return(nil)
+				repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, log *domain.AuditLog) error {
+						if log.Event != constant.AUDIT_EVENT_EMAIL_CHANGED || log.Metadata["previous_email"] != user.Email {
+							t.Errorf("CreateAuditLog() = %+v, want an email_changed entry with the previous email", log)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name: "Address taken while the link was pending",
+			mock: func(repo *mocks.MockAuthRepository, user *domain.User, token *domain.EmailChangeToken) {
+				repo.EXPECT().GetEmailChangeToken(gomock.Any(), token.ID).Return(token, nil)
+				repo.EXPECT().GetUserByEmail(gomock.Any(), newEmail).Return(&domain.User{ID: uuid.New(), Email: newEmail}, nil)
+			},
+			wantErr: "email already in use",
+		},
+		{
+			name: "Expired token",
+			mock: func(repo *mocks.MockAuthRepository, user *domain.User, token *domain.EmailChangeToken) {
+				token.ExpiredAt = time.Now().Add(-time.Minute)
+				repo.EXPECT().GetEmailChangeToken(gomock.Any(), token.ID).Return(token, nil)
+			},
+			wantErr: "confirmation link expired",
+		},
+		{
+			name: "Concurrent use",
+			mock: func(repo *mocks.MockAuthRepository, user *domain.User, token *domain.EmailChangeToken) {
+				repo.EXPECT().GetEmailChangeToken(gomock.Any(), token.ID).Return(token, nil)
+				repo.EXPECT().GetUserByEmail(gomock.Any(), newEmail).Return(nil, nil)
+				repo.EXPECT().MarkEmailChangeTokenUsed(gomock.Any(), token.ID).Return(false, nil)
+			},
+			wantErr: "invalid confirmation link",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := newTestProfileUser(t)
+			repo := mocks.NewMockAuthRepository(ctrl)
+			m := mailerMocks.NewMockIMailerProviderRepository(ctrl)
+			uc := NewAuthUsecase(repo, nil, nil, m, nil, newVerificationConfig())
+
+			var stored *domain.EmailChangeToken
+			var link string
+			repo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+			repo.EXPECT().GetUserByEmail(gomock.Any(), newEmail).Return(nil, nil)
+			repo.EXPECT().InvalidateEmailChangeTokens(gomock.Any(), user.ID).Return(nil)
+			repo.EXPECT().CreateEmailChangeToken(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, token *domain.EmailChangeToken) error {
+					stored = token
+					return nil
+				})
+			m.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, message *mailer.Message) error {
+					if len(message.To) != 1 || message.To[0] != newEmail {
+						t.Errorf("Send() to = %v, want the new address %v", message.To, newEmail)
+					}
+					link = emailChangeLinkRegex.FindString(message.TextBody)
+					return nil
+				})
+
+			if err := uc.RequestEmailChange(context.Background(), user.ID, &domain.ChangeEmailRequest{Email: newEmail, Password: "current-password"}); err != nil {
+				t.Fatalf("RequestEmailChange() error = %v", err)
+			}
+			if stored.NewEmail != newEmail {
+				t.Errorf("CreateEmailChangeToken() new email = %v, want %v", stored.NewEmail, newEmail)
+			}
+
+			parsed, err := url.Parse(link)
+			if err != nil || link == "" {
+				t.Fatalf("email change message has no link: %q", link)
+			}
+			query := parsed.Query()
+			req := &domain.VerifyEmailRequest{
+				ID:        query.Get("id"),
+				Token:     query.Get("token"),
+				Expires:   query.Get("expires"),
+				Signature: query.Get("signature"),
+			}
+
+			tt.mock(repo, user, stored)
+
+			err = uc.ConfirmEmailChange(context.Background(), req)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("ConfirmEmailChange() unexpected error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("ConfirmEmailChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_RequestEmailChange_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     domain.ChangeEmailRequest
+		mock    func(repo *mocks.MockAuthRepository)
+		wantErr string
+	}{
+		{
+			name:    "Wrong password",
+			req:     domain.ChangeEmailRequest{Email: "new@example.com", Password: "wrong-password"},
+			mock:    func(repo *mocks.MockAuthRepository) {},
+			wantErr: "invalid current password",
+		},
+		{
+			name:    "Same address",
+			req:     domain.ChangeEmailRequest{Email: "Patient@Example.com", Password: "current-password"},
+			mock:    func(repo *mocks.MockAuthRepository) {},
+			wantErr: "email unchanged",
+		},
+		{
+			name: "Address in use",
+			req:  domain.ChangeEmailRequest{Email: "taken@example.com", Password: "current-password"},
+			mock: func(repo *mocks.MockAuthRepository) {
+				repo.EXPECT().GetUserByEmail(gomock.Any(), "taken@example.com").Return(&domain.User{ID: uuid.New()}, nil)
+			},
+			wantErr: "email already in use",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := newTestProfileUser(t)
+			repo := mocks.NewMockAuthRepository(ctrl)
+			repo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+			tt.mock(repo)
+
+			err := NewAuthUsecase(repo, nil, nil, nil, nil, newVerificationConfig()).RequestEmailChange(context.Background(), user.ID, &tt.req)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("RequestEmailChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/pkg/app_log"
+	"github.com/google/uuid"
+)
+
+// sessionClient truncates the user agent to what user_tokens can store
+func sessionClient(client domain.ClientInfo) domain.ClientInfo {
+	if agent := []rune(client.UserAgent); len(agent) > constant.SESSION_USER_AGENT_LIMIT {
+		client.UserAgent = string(agent[:constant.SESSION_USER_AGENT_LIMIT])
+	}
+	return client
+}
+
+// TouchUserToken records the activity of a token. The write is skipped while the last one is
+// recent and the client did not change, and a failure is only logged so the request goes on.
+func (a *authUsecase) TouchUserToken(ctx context.Context, token *domain.UserToken, client domain.ClientInfo) {
+	client = sessionClient(client)
+	if token.LastUsedAt != nil && time.Since(*token.LastUsedAt) < constant.SESSION_TOUCH_INTERVAL &&
+		token.IPAddress == client.IPAddress && token.UserAgent == client.UserAgent {
+		return
+	}
+
+	if err := a.repo.TouchUserToken(ctx, token.ID, client); err != nil {
+		app_log.Errorf("Failed to record token activity: %v", err)
+	}
+}
+
+// ListSessions returns the active sessions of a user, flagging the one of the current token
+func (a *authUsecase) ListSessions(ctx context.Context, userID, currentTokenID uuid.UUID) ([]domain.Session, error) {
+	sessions, err := a.repo.ListUserSessions(ctx, userID)
+	if err != nil {
+		app_log.Errorf("Failed to list user sessions: %v", err)
+		return nil, err
+	}
+
+	current, err := a.repo.GetUserTokenByID(ctx, currentTokenID)
+	if err != nil {
+		app_log.Errorf("Failed to get user token: %v", err)
+		return nil, err
+	}
+
+	if current != nil {
+		for i := range sessions {
+			sessions[i].Current = sessions[i].ID == current.FamilyID
+		}
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession signs a session of a user out, revoking its access and refresh tokens
+func (a *authUsecase) RevokeSession(ctx context.Context, actorID, userID, sessionID uuid.UUID) error {
+	sessions, err := a.repo.ListUserSessions(ctx, userID)
+	if err != nil {
+		app_log.Errorf("Failed to list user sessions: %v", err)
+		return err
+	}
+
+	found := false
+	for _, session := range sessions {
+		if session.ID == sessionID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return errors.New("session not found")
+	}
+
+	if err := a.repo.InvalidateTokenFamily(ctx, sessionID); err != nil {
+		app_log.Errorf("Failed to revoke session: %v", err)
+		return err
+	}
+
+	a.audit(ctx, &domain.AuditLog{
+		Event:    constant.AUDIT_EVENT_SESSION_REVOKED,
+		UserID:   &userID,
+		ActorID:  &actorID,
+		Metadata: map[string]interface{}{"session_id": sessionID.String()},
+	})
+
+	return nil
+}
+
+// RevokeAllSessions signs a user out everywhere, including the session making the request
+func (a *authUsecase) RevokeAllSessions(ctx context.Context, actorID, userID uuid.UUID) error {
+	if err := a.repo.InvalidateUserTokens(ctx, userID); err != nil {
+		app_log.Errorf("Failed to revoke user sessions: %v", err)
+		return err
+	}
+
+	a.audit(ctx, &domain.AuditLog{Event: constant.AUDIT_EVENT_SESSIONS_REVOKED, UserID: &userID, ActorID: &actorID})
+
+	return nil
+}
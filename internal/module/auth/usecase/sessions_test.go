@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+
+	"github.com/gomajido/hospital-cms-golang/config"
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain/mocks"
+)
+
+func TestSessionClient(t *testing.T) {
+	agent := strings.Repeat("é", constant.SESSION_USER_AGENT_LIMIT+10)
+
+	got := sessionClient(domain.ClientInfo{IPAddress: "10.0.0.1", UserAgent: agent})
+	if n := len([]rune(got.UserAgent)); n != constant.SESSION_USER_AGENT_LIMIT {
+		t.Errorf("sessionClient() user agent has %d characters, want %d", n, constant.SESSION_USER_AGENT_LIMIT)
+	}
+	if got.IPAddress != "10.0.0.1" {
+		t.Errorf("sessionClient() IP = %q, want it unchanged", got.IPAddress)
+	}
+}
+
+func TestAuthUsecase_TouchUserToken(t *testing.T) {
+	recent := time.Now().Add(-10 * time.Second)
+	stale := time.Now().Add(-2 * constant.SESSION_TOUCH_INTERVAL)
+	client := domain.ClientInfo{IPAddress: "10.0.0.1", UserAgent: "curl/8.0"}
+
+	tests := []struct {
+		name      string
+		token     domain.UserToken
+		wantWrite bool
+	}{
+		{name: "Never used", token: domain.UserToken{}, wantWrite: true},
+		{name: "Used recently from the same client", token: domain.UserToken{LastUsedAt: &recent, IPAddress: "10.0.0.1", UserAgent: "curl/8.0"}},
+		{name: "Used recently from another address", token: domain.UserToken{LastUsedAt: &recent, IPAddress: "10.0.0.2", UserAgent: "curl/8.0"}, wantWrite: true},
+		{name: "Last use is stale", token: domain.UserToken{LastUsedAt: &stale, IPAddress: "10.0.0.1", UserAgent: "curl/8.0"}, wantWrite: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tt.token.ID = uuid.New()
+			repo := mocks.NewMockAuthRepository(ctrl)
+			if tt.wantWrite {
+				repo.EXPECT().TouchUserToken(gomock.Any(), tt.token.ID, client).Return(nil)
+			}
+
+			NewAuthUsecase(repo, nil, nil, nil, nil, &config.Config{}).TouchUserToken(context.Background(), &tt.token, client)
+		})
+	}
+}
+
+func TestAuthUsecase_ListSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	current := &domain.UserToken{ID: uuid.New(), UserID: userID, FamilyID: uuid.New()}
+	other := uuid.New()
+
+	repo := mocks.NewMockAuthRepository(ctrl)
+	repo.EXPECT().ListUserSessions(gomock.Any(), userID).Return([]domain.Session{{ID: other}, {ID: current.FamilyID}}, nil)
+	repo.EXPECT().GetUserTokenByID(gomock.Any(), current.ID).Return(current, nil)
+
+	sessions, err := NewAuthUsecase(repo, nil, nil, nil, nil, &config.Config{}).ListSessions(context.Background(), userID, current.ID)
+	if err != nil {
+		t.Fatalf("ListSessions() unexpected error = %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].Current || !sessions[1].Current {
+		t.Errorf("ListSessions() = %+v, want only the session of the current token flagged", sessions)
+	}
+}
+
+func TestAuthUsecase_RevokeSession(t *testing.T) {
+	userID := uuid.New()
+	adminID := uuid.New()
+	sessionID := uuid.New()
+
+	tests := []struct {
+		name      string
+		sessionID uuid.UUID
+		mock      func(repo *mocks.MockAuthRepository)
+		wantErr   string
+	}{
+		{
+			name:      "Revoke a session of the user",
+			sessionID: sessionID,
+			mock: func(repo *mocks.MockAuthRepository) {
+				repo.EXPECT().InvalidateTokenFamily(gomock.Any(), sessionID).Return(nil)
+				repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, log *domain.AuditLog) error {
+						if log.Event != constant.AUDIT_EVENT_SESSION_REVOKED || *log.UserID != userID || *log.ActorID != adminID {
+							t.Errorf("CreateAuditLog() = %+v, want a session_revoked entry by the admin", log)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name:      "Session of another user",
+			sessionID: uuid.New(),
+			mock:      func(repo *mocks.MockAuthRepository) {},
+			wantErr:   "session not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAuthRepository(ctrl)
+			repo.EXPECT().ListUserSessions(gomock.Any(), userID).Return([]domain.Session{{ID: sessionID}}, nil)
+			tt.mock(repo)
+
+			err := NewAuthUsecase(repo, nil, nil, nil, nil, &config.Config{}).RevokeSession(context.Background(), adminID, userID, tt.sessionID)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("RevokeSession() unexpected error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("RevokeSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_RevokeAllSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	repo := mocks.NewMockAuthRepository(ctrl)
+	repo.EXPECT().InvalidateUserTokens(gomock.Any(), userID).Return(nil)
+	repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+
+	if err := NewAuthUsecase(repo, nil, nil, nil, nil, &config.Config{}).RevokeAllSessions(context.Background(), userID, userID); err != nil {
+		t.Errorf("RevokeAllSessions() unexpected error = %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/pkg/app_log"
+	"github.com/google/uuid"
+)
+
+// ListUsers pages through the users matching the filter
+func (a *authUsecase) ListUsers(ctx context.Context, filter *domain.UserFilter, page, limit int) ([]domain.User, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	return a.repo.ListUsers(ctx, filter, page, limit)
+}
+
+// GetUser retrieves any user for administrators, including soft-deleted ones
+func (a *authUsecase) GetUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	return a.repo.GetUserByIDWithDeleted(ctx, userID)
+}
+
+// SuspendUser blocks a user from signing in and signs them out everywhere
+func (a *authUsecase) SuspendUser(ctx context.Context, actorID, userID uuid.UUID) error {
+	if actorID == userID {
+		return errors.New("cannot change your own account")
+	}
+
+	user, err := a.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Status == constant.USER_STATUS_SUSPENDED {
+		return errors.New("user already suspended")
+	}
+
+	user.Status = constant.USER_STATUS_SUSPENDED
+	if err := a.repo.UpdateUser(ctx, user); err != nil {
+		app_log.Errorf("Failed to suspend user: %v", err)
+		return err
+	}
+
+	if err := a.repo.InvalidateUserTokens(ctx, userID); err != nil {
+		app_log.Errorf("Failed to revoke tokens of suspended user %s: %v", userID, err)
+		return err
+	}
+
+	a.audit(ctx, &domain.AuditLog{Event: constant.AUDIT_EVENT_USER_SUSPENDED, UserID: &userID, ActorID: &actorID, Email: user.Email})
+
+	return nil
+}
+
+// ReactivateUser lifts a suspension. Users who never verified their email go back to inactive.
+func (a *authUsecase) ReactivateUser(ctx context.Context, actorID, userID uuid.UUID) error {
+	user, err := a.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Status != constant.USER_STATUS_SUSPENDED {
+		return errors.New("user is not suspended")
+	}
+
+	user.Status = constant.USER_STATUS_ACTIVE
+	if user.EmailVerifiedAt == nil {
+		user.Status = constant.USER_STATUS_INACTIVE
+	}
+
+	if err := a.repo.UpdateUser(ctx, user); err != nil {
+		app_log.Errorf("Failed to reactivate user: %v", err)
+		return err
+	}
+
+	a.audit(ctx, &domain.AuditLog{Event: constant.AUDIT_EVENT_USER_REACTIVATED, UserID: &userID, ActorID: &actorID, Email: user.Email})
+
+	return nil
+}
+
+// DeleteUser soft-deletes a user and signs them out everywhere
+func (a *authUsecase) DeleteUser(ctx context.Context, actorID, userID uuid.UUID) error {
+	if actorID == userID {
+		return errors.New("cannot change your own account")
+	}
+
+	if err := a.repo.DeleteUser(ctx, userID); err != nil {
+		if err.Error() != "user not found" {
+			app_log.Errorf("Failed to delete user: %v", err)
+		}
+		return err
+	}
+
+	if err := a.repo.InvalidateUserTokens(ctx, userID); err != nil {
+		app_log.Errorf("Failed to revoke tokens of deleted user %s: %v", userID, err)
+		return err
+	}
+
+	a.audit(ctx, &domain.AuditLog{Event: constant.AUDIT_EVENT_USER_DELETED, UserID: &userID, ActorID: &actorID})
+
+	return nil
+}
+
+// RestoreUser brings a soft-deleted user back with the status they had
+func (a *authUsecase) RestoreUser(ctx context.Context, actorID, userID uuid.UUID) error {
+	if err := a.repo.RestoreUser(ctx, userID); err != nil {
+		if err.Error() != "user not found" {
+			app_log.Errorf("Failed to restore user: %v", err)
+		}
+		return err
+	}
+
+	a.audit(ctx, &domain.AuditLog{Event: constant.AUDIT_EVENT_USER_RESTORED, UserID: &userID, ActorID: &actorID})
+
+	return nil
+}
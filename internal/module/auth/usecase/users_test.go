@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+
+	"github.com/gomajido/hospital-cms-golang/config"
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/module/auth/domain/mocks"
+)
+
+func TestAuthUsecase_SuspendUser(t *testing.T) {
+	adminID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		actorID uuid.UUID
+		mock    func(repo *mocks.MockAuthRepository)
+		wantErr string
+	}{
+		{
+			name:    "Suspend signs the user out everywhere",
+			actorID: adminID,
+			mock: func(repo *mocks.MockAuthRepository) {
+				repo.EXPECT().GetUserByID(gomock.Any(), userID).Return(&domain.User{ID: userID, Status: constant.USER_STATUS_ACTIVE}, nil)
+				repo.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, user *domain.User) error {
+						if user.Status != constant.USER_STATUS_SUSPENDED {
+							t.Errorf("UpdateUser() status = %v, want suspended", user.Status)
+						}
+						return nil
+					})
+				repo.EXPECT().InvalidateUserTokens(gomock.Any(), userID).Return(nil)
+				repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, log *domain.AuditLog) error {
+						if log.Event != constant.AUDIT_EVENT_USER_SUSPENDED || *log.ActorID != adminID {
+							t.Errorf("CreateAuditLog() = %+v, want a user_suspended entry by the admin", log)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name:    "Admins cannot suspend themselves",
+			actorID: userID,
+			mock:    func(repo *mocks.MockAuthRepository) {},
+			wantErr: "cannot change your own account",
+		},
+		{
+			name:    "Already suspended",
+			actorID: adminID,
+			mock: func(repo *mocks.MockAuthRepository) {
+				repo.EXPECT().GetUserByID(gomock.Any(), userID).Return(&domain.User{ID: userID, Status: constant.USER_STATUS_SUSPENDED}, nil)
+			},
+			wantErr: "user already suspended",
+		},
+		{
+			name:    "Deleted user",
+			actorID: adminID,
+			mock: func(repo *mocks.MockAuthRepository) {
+				repo.EXPECT().GetUserByID(gomock.Any(), userID).Return(nil, errors.New("user not found"))
+			},
+			wantErr: "user not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAuthRepository(ctrl)
+			tt.mock(repo)
+
+			err := NewAuthUsecase(repo, nil, nil, nil, nil, &config.Config{}).SuspendUser(context.Background(), tt.actorID, userID)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("SuspendUser() unexpected error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("SuspendUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_ReactivateUser(t *testing.T) {
+	verifiedAt := time.Now()
+
+	tests := []struct {
+		name       string
+		user       domain.User
+		wantStatus string
+		wantErr    string
+	}{
+		{
+			name:       "Verified user becomes active",
+			user:       domain.User{Status: constant.USER_STATUS_SUSPENDED, EmailVerifiedAt: &verifiedAt},
+			wantStatus: constant.USER_STATUS_ACTIVE,
+		},
+		{
+			name:       "Unverified user goes back to inactive",
+			user:       domain.User{Status: constant.USER_STATUS_SUSPENDED},
+			wantStatus: constant.USER_STATUS_INACTIVE,
+		},
+		{
+			name:    "User is not suspended",
+			user:    domain.User{Status: constant.USER_STATUS_ACTIVE, EmailVerifiedAt: &verifiedAt},
+			wantErr: "user is not suspended",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tt.user.ID = uuid.New()
+			repo := mocks.NewMockAuthRepository(ctrl)
+			repo.EXPECT().GetUserByID(gomock.Any(), tt.user.ID).Return(&tt.user, nil)
+			if tt.wantErr == "" {
+				repo.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, user *domain.User) error {
+						if user.Status != tt.wantStatus {
+							t.Errorf("UpdateUser() status = %v, want %v", user.Status, tt.wantStatus)
+						}
+						return nil
+					})
+				repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			err := NewAuthUsecase(repo, nil, nil, nil, nil, &config.Config{}).ReactivateUser(context.Background(), uuid.New(), tt.user.ID)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("ReactivateUser() unexpected error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("ReactivateUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_DeleteAndRestoreUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	adminID := uuid.New()
+	userID := uuid.New()
+
+	repo := mocks.NewMockAuthRepository(ctrl)
+	uc := NewAuthUsecase(repo, nil, nil, nil, nil, &config.Config{})
+
+	if err := uc.DeleteUser(context.Background(), adminID, adminID); err == nil || err.Error() != "cannot change your own account" {
+		t.Errorf("DeleteUser() of oneself error = %v, wantErr cannot change your own account", err)
+	}
+
+	gomock.InOrder(
+		repo.EXPECT().DeleteUser(gomock.Any(), userID).Return(nil),
+		repo.EXPECT().InvalidateUserTokens(gomock.Any(), userID).Return(nil),
+		repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil),
+		repo.EXPECT().RestoreUser(gomock.Any(), userID).Return(nil),
+		repo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil),
+	)
+
+	if err := uc.DeleteUser(context.Background(), adminID, userID); err != nil {
+		t.Errorf("DeleteUser() unexpected error = %v", err)
+	}
+	if err := uc.RestoreUser(context.Background(), adminID, userID); err != nil {
+		t.Errorf("RestoreUser() unexpected error = %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package constant
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	RescheduleStatusChanged   = "changed"
+	RescheduleStatusCancelled = "cancelled"
+
+	// DefaultSlotDuration is the slot length in minutes used when a schedule does not define one
+	DefaultSlotDuration = 30
+	MaxSlotDuration     = 240
+
+	// MaxAvailabilityRangeDays limits how many days can be requested in a single availability query
+	MaxAvailabilityRangeDays = 31
+
+	DateFormat      = "2006-01-02"
+	SlotTimeFormat  = "15:04"
+	ClockTimeFormat = "15:04:05"
+
+	// Sort orders accepted by the doctor list
+	DoctorSortName     = "name"
+	DoctorSortNameDesc = "-name"
+	DoctorSortService  = "service"
+	DoctorSortNewest   = "newest"
+
+	// Profile photos are kept in the storage provider under PhotoDirectory/<doctor id>/
+	PhotoDirectory = "doctors"
+	MaxPhotoSize   = 2 << 20
+	PhotoURLExpiry = 24 * time.Hour
+
+	// MaxProfileEntries limits the languages, education and certifications of a profile
+	MaxProfileEntries = 20
+)
+
+// Common errors for doctor module
+var (
+	ErrDoctorNotFound         = errors.New("doctor not found")
+	ErrDoctorAccountNotLinked = errors.New("your account is not linked to a doctor")
+	ErrDoctorAccountTaken     = errors.New("account is already linked to another doctor")
+	ErrScheduleNotFound       = errors.New("schedule not found")
+	ErrRescheduleNotFound     = errors.New("reschedule not found")
+	ErrDoctorSlugTaken        = errors.New("slug is already used by another doctor")
+	ErrInvalidPhoto           = errors.New("photo must be a JPEG, PNG or WebP image")
+	ErrPhotoTooLarge          = errors.New("photo cannot be larger than 2 MB")
+	ErrScheduleOverlap        = errors.New("schedule overlaps another schedule of the doctor")
+	ErrRescheduleDayMismatch  = errors.New("reschedule date does not fall on the schedule day")
+	ErrRescheduleExists       = errors.New("schedule already has a reschedule on this date")
+	ErrAppointmentsAffected   = errors.New("change would leave booked appointments outside the schedule")
+)
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,30 +12,54 @@ import (
 type Service struct {
 	ID          uuid.UUID `json:"id"`
 	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
 	Description string    `json:"description"`
 }
 
 // Doctor represents the doctor entity
 type Doctor struct {
-	ID             uuid.UUID        `json:"id"`
-	Name           string           `json:"name"`
-	ServiceID      uuid.UUID        `json:"service_id"`
-	Description    string           `json:"description"`
-	Specialization string           `json:"specialization"`
-	Degree         string           `json:"degree"`
-	Experience     string           `json:"experience"`
-	Service        *Service         `json:"service"`
-	Schedules      []DoctorSchedule `json:"schedules,omitempty"`
+	ID              uuid.UUID        `json:"id"`
+	UserID          *uuid.UUID       `json:"user_id,omitempty"` // Account of the doctor, used by the doctor portal
+	Name            string           `json:"name"`
+	Slug            string           `json:"slug"`
+	PhotoPath       string           `json:"-"`
+	PhotoURL        string           `json:"photo_url,omitempty"` // Temporary link to the photo in the storage provider
+	ServiceID       uuid.UUID        `json:"service_id"`
+	Description     string           `json:"description"`
+	Specialization  string           `json:"specialization"`
+	Degree          string           `json:"degree"`
+	Experience      string           `json:"experience"`
+	Languages       []string         `json:"languages"`
+	Education       []Education      `json:"education"`
+	Certifications  []Certification  `json:"certifications"`
+	ConsultationFee *int64           `json:"consultation_fee,omitempty"` // Whole currency units, nil when not published
+	Service         *Service         `json:"service"`
+	Schedules       []DoctorSchedule `json:"schedules,omitempty"`
+}
+
+// Education represents a degree in the education history of a doctor
+type Education struct {
+	Degree      string `json:"degree"`
+	Institution string `json:"institution"`
+	Year        int    `json:"year,omitempty"` // Year of graduation
+}
+
+// Certification represents a board certification or license held by a doctor
+type Certification struct {
+	Name   string `json:"name"`
+	Issuer string `json:"issuer,omitempty"`
+	Year   int    `json:"year,omitempty"`
 }
 
 // DoctorSchedule represents the doctor's regular schedule
 type DoctorSchedule struct {
-	ID          uuid.UUID          `json:"id"`
-	DoctorID    uuid.UUID          `json:"doctor_id"`
-	Day         string             `json:"day"`
-	StartTime   string             `json:"start_time"`
-	EndTime     string             `json:"end_time"`
-	Reschedules []DoctorReschedule `json:"reschedules,omitempty"`
+	ID           uuid.UUID          `json:"id"`
+	DoctorID     uuid.UUID          `json:"doctor_id"`
+	Day          string             `json:"day"`
+	StartTime    string             `json:"start_time"`
+	EndTime      string             `json:"end_time"`
+	SlotDuration int                `json:"slot_duration"`
+	Reschedules  []DoctorReschedule `json:"reschedules,omitempty"`
 }
 
 // DoctorReschedule represents schedule changes or cancellations
@@ -49,35 +74,83 @@ type DoctorReschedule struct {
 	Schedule         *DoctorSchedule `json:"schedule"`
 }
 
+// TimeSlot represents a single bookable slot generated from a doctor schedule
+type TimeSlot struct {
+	ScheduleID uuid.UUID `json:"doctor_schedule_id"`
+	StartTime  string    `json:"start_time"`
+	EndTime    string    `json:"end_time"`
+}
+
+// DailyAvailability represents the free slots of a doctor on a given date
+type DailyAvailability struct {
+	Date  string     `json:"date"`
+	Day   string     `json:"day"`
+	Slots []TimeSlot `json:"slots"`
+}
+
+// BookedSlot represents a slot that is already taken by a scheduled appointment.
+// Duration is the slot length in minutes of the schedule the appointment was booked on.
+type BookedSlot struct {
+	Date     time.Time
+	Time     string
+	Duration int
+}
+
+// ScheduledAppointment represents an upcoming appointment booked on a doctor schedule
+type ScheduledAppointment struct {
+	ID   uuid.UUID `json:"id"`
+	Date time.Time `json:"date"`
+	Time string    `json:"time"`
+}
+
+// ScheduleGuard decides whether a change to a schedule may be written, given the schedule as
+// stored, its upcoming appointments and its reschedules, read while it is locked against bookings
+type ScheduleGuard func(current *DoctorSchedule, appointments []ScheduledAppointment, reschedules []DoctorReschedule) error
+
 // DoctorRepository defines the interface for doctor data operations
 type DoctorRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*Doctor, error)
-	List(ctx context.Context, page, limit int) ([]Doctor, int64, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*Doctor, error)
+	GetBySlug(ctx context.Context, slug string) (*Doctor, error)
+	List(ctx context.Context, filter *DoctorFilter, page, limit int) ([]Doctor, int64, error)
 	Create(ctx context.Context, doctor *Doctor) error
 	Update(ctx context.Context, doctor *Doctor) error
+	UpdatePhoto(ctx context.Context, id uuid.UUID, photoPath string) error
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// Schedule operations
 	CreateSchedule(ctx context.Context, schedule *DoctorSchedule) error
+	GetScheduleByID(ctx context.Context, id uuid.UUID) (*DoctorSchedule, error)
 	GetSchedulesByDoctorID(ctx context.Context, doctorID uuid.UUID) ([]DoctorSchedule, error)
-	UpdateSchedule(ctx context.Context, schedule *DoctorSchedule) error
-	DeleteSchedule(ctx context.Context, id uuid.UUID) error
+	UpdateSchedule(ctx context.Context, schedule *DoctorSchedule, guard ScheduleGuard) error
+	DeleteSchedule(ctx context.Context, id uuid.UUID, guard ScheduleGuard) error
 
 	// Reschedule operations
-	CreateReschedule(ctx context.Context, reschedule *DoctorReschedule) error
+	CreateReschedule(ctx context.Context, reschedule *DoctorReschedule, guard ScheduleGuard) error
+	GetRescheduleByID(ctx context.Context, id uuid.UUID) (*DoctorReschedule, error)
 	GetReschedulesByScheduleID(ctx context.Context, scheduleID uuid.UUID) ([]DoctorReschedule, error)
-	UpdateReschedule(ctx context.Context, reschedule *DoctorReschedule) error
-	DeleteReschedule(ctx context.Context, id uuid.UUID) error
+	UpdateReschedule(ctx context.Context, reschedule *DoctorReschedule, guard ScheduleGuard) error
+	DeleteReschedule(ctx context.Context, reschedule *DoctorReschedule, guard ScheduleGuard) error
+	GetReschedulesByDoctorID(ctx context.Context, doctorID uuid.UUID, from, to time.Time) ([]DoctorReschedule, error)
+
+	// Availability operations
+	GetBookedSlots(ctx context.Context, doctorID uuid.UUID, from, to time.Time) ([]BookedSlot, error)
 }
 
 // DoctorUsecase defines the interface for doctor business logic
 type DoctorUsecase interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*Doctor, error)
-	List(ctx context.Context, page, limit int) ([]Doctor, int64, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*Doctor, error)
+	GetBySlug(ctx context.Context, slug string) (*Doctor, error)
+	List(ctx context.Context, filter *DoctorFilter, page, limit int) ([]Doctor, int64, error)
 	Create(ctx context.Context, req CreateDoctorRequest) (*Doctor, error)
 	Update(ctx context.Context, id uuid.UUID, req UpdateDoctorRequest) (*Doctor, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// Profile photo operations
+	UploadPhoto(ctx context.Context, id uuid.UUID, photo io.Reader) (*Doctor, error)
+	DeletePhoto(ctx context.Context, id uuid.UUID) (*Doctor, error)
+
 	// Schedule operations
 	CreateSchedule(ctx context.Context, doctorID uuid.UUID, req CreateScheduleRequest) (*DoctorSchedule, error)
 	GetSchedulesByDoctorID(ctx context.Context, doctorID uuid.UUID) ([]DoctorSchedule, error)
@@ -89,4 +162,12 @@ type DoctorUsecase interface {
 	GetReschedulesByScheduleID(ctx context.Context, scheduleID uuid.UUID) ([]DoctorReschedule, error)
 	UpdateReschedule(ctx context.Context, id uuid.UUID, req UpdateRescheduleRequest) (*DoctorReschedule, error)
 	DeleteReschedule(ctx context.Context, id uuid.UUID) error
+
+	// Ownership checks for the doctor portal
+	CheckScheduleOwner(ctx context.Context, doctorID, scheduleID uuid.UUID) error
+	CheckRescheduleOwner(ctx context.Context, doctorID, rescheduleID uuid.UUID) error
+
+	// Availability operations
+	GetAvailability(ctx context.Context, doctorID uuid.UUID, from, to time.Time) ([]DailyAvailability, error)
+	IsSlotAvailable(ctx context.Context, doctorID, scheduleID uuid.UUID, date time.Time, startTime string) (bool, error)
 }
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/constant"
+)
+
+// AppointmentsAffectedError is returned when a schedule change would leave booked appointments
+// outside the hours the doctor is available
+type AppointmentsAffectedError struct {
+	Appointments []ScheduledAppointment
+}
+
+func (e *AppointmentsAffectedError) Error() string {
+	return constant.ErrAppointmentsAffected.Error()
+}
+
+func (e *AppointmentsAffectedError) Unwrap() error {
+	return constant.ErrAppointmentsAffected
+}
@@ -0,0 +1,664 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/module/doctor/domain/doctor.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/gomajido/hospital-cms-golang/internal/module/doctor/domain"
+	uuid "github.com/google/uuid"
+)
+
+// MockDoctorRepository is a mock of DoctorRepository interface.
+type MockDoctorRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDoctorRepositoryMockRecorder
+}
+
+// MockDoctorRepositoryMockRecorder is the mock recorder for MockDoctorRepository.
+type MockDoctorRepositoryMockRecorder struct {
+	mock *MockDoctorRepository
+}
+
+// NewMockDoctorRepository creates a new mock instance.
+func NewMockDoctorRepository(ctrl *gomock.Controller) *MockDoctorRepository {
+	mock := &MockDoctorRepository{ctrl: ctrl}
+	mock.recorder = &MockDoctorRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDoctorRepository) EXPECT() *MockDoctorRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDoctorRepository) Create(ctx context.Context, doctor *domain.Doctor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, doctor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDoctorRepositoryMockRecorder) Create(ctx, doctor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDoctorRepository)(nil).Create), ctx, doctor)
+}
+
+// CreateReschedule mocks base method.
+func (m *MockDoctorRepository) CreateReschedule(ctx context.Context, reschedule *domain.DoctorReschedule, guard domain.ScheduleGuard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReschedule", ctx, reschedule, guard)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReschedule indicates an expected call of CreateReschedule.
+func (mr *MockDoctorRepositoryMockRecorder) CreateReschedule(ctx, reschedule, guard interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReschedule", reflect.TypeOf((*MockDoctorRepository)(nil).CreateReschedule), ctx, reschedule, guard)
+}
+
+// CreateSchedule mocks base method.
+func (m *MockDoctorRepository) CreateSchedule(ctx context.Context, schedule *domain.DoctorSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchedule", ctx, schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSchedule indicates an expected call of CreateSchedule.
+func (mr *MockDoctorRepositoryMockRecorder) CreateSchedule(ctx, schedule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*MockDoctorRepository)(nil).CreateSchedule), ctx, schedule)
+}
+
+// Delete mocks base method.
+func (m *MockDoctorRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDoctorRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDoctorRepository)(nil).Delete), ctx, id)
+}
+
+// DeleteReschedule mocks base method.
+func (m *MockDoctorRepository) DeleteReschedule(ctx context.Context, reschedule *domain.DoctorReschedule, guard domain.ScheduleGuard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReschedule", ctx, reschedule, guard)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReschedule indicates an expected call of DeleteReschedule.
+func (mr *MockDoctorRepositoryMockRecorder) DeleteReschedule(ctx, reschedule, guard interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReschedule", reflect.TypeOf((*MockDoctorRepository)(nil).DeleteReschedule), ctx, reschedule, guard)
+}
+
+// DeleteSchedule mocks base method.
+func (m *MockDoctorRepository) DeleteSchedule(ctx context.Context, id uuid.UUID, guard domain.ScheduleGuard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchedule", ctx, id, guard)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSchedule indicates an expected call of DeleteSchedule.
+func (mr *MockDoctorRepositoryMockRecorder) DeleteSchedule(ctx, id, guard interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockDoctorRepository)(nil).DeleteSchedule), ctx, id, guard)
+}
+
+// GetBookedSlots mocks base method.
+func (m *MockDoctorRepository) GetBookedSlots(ctx context.Context, doctorID uuid.UUID, from, to time.Time) ([]domain.BookedSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookedSlots", ctx, doctorID, from, to)
+	ret0, _ := ret[0].([]domain.BookedSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookedSlots indicates an expected call of GetBookedSlots.
+func (mr *MockDoctorRepositoryMockRecorder) GetBookedSlots(ctx, doctorID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookedSlots", reflect.TypeOf((*MockDoctorRepository)(nil).GetBookedSlots), ctx, doctorID, from, to)
+}
+
+// GetByID mocks base method.
+func (m *MockDoctorRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Doctor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Doctor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDoctorRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDoctorRepository)(nil).GetByID), ctx, id)
+}
+
+// GetBySlug mocks base method.
+func (m *MockDoctorRepository) GetBySlug(ctx context.Context, slug string) (*domain.Doctor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySlug", ctx, slug)
+	ret0, _ := ret[0].(*domain.Doctor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySlug indicates an expected call of GetBySlug.
+func (mr *MockDoctorRepositoryMockRecorder) GetBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlug", reflect.TypeOf((*MockDoctorRepository)(nil).GetBySlug), ctx, slug)
+}
+
+// GetByUserID mocks base method.
+func (m *MockDoctorRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.Doctor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID)
+	ret0, _ := ret[0].(*domain.Doctor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockDoctorRepositoryMockRecorder) GetByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockDoctorRepository)(nil).GetByUserID), ctx, userID)
+}
+
+// GetRescheduleByID mocks base method.
+func (m *MockDoctorRepository) GetRescheduleByID(ctx context.Context, id uuid.UUID) (*domain.DoctorReschedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRescheduleByID", ctx, id)
+	ret0, _ := ret[0].(*domain.DoctorReschedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRescheduleByID indicates an expected call of GetRescheduleByID.
+func (mr *MockDoctorRepositoryMockRecorder) GetRescheduleByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRescheduleByID", reflect.TypeOf((*MockDoctorRepository)(nil).GetRescheduleByID), ctx, id)
+}
+
+// GetReschedulesByDoctorID mocks base method.
+func (m *MockDoctorRepository) GetReschedulesByDoctorID(ctx context.Context, doctorID uuid.UUID, from, to time.Time) ([]domain.DoctorReschedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReschedulesByDoctorID", ctx, doctorID, from, to)
+	ret0, _ := ret[0].([]domain.DoctorReschedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReschedulesByDoctorID indicates an expected call of GetReschedulesByDoctorID.
+func (mr *MockDoctorRepositoryMockRecorder) GetReschedulesByDoctorID(ctx, doctorID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReschedulesByDoctorID", reflect.TypeOf((*MockDoctorRepository)(nil).GetReschedulesByDoctorID), ctx, doctorID, from, to)
+}
+
+// GetReschedulesByScheduleID mocks base method.
+func (m *MockDoctorRepository) GetReschedulesByScheduleID(ctx context.Context, scheduleID uuid.UUID) ([]domain.DoctorReschedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReschedulesByScheduleID", ctx, scheduleID)
+	ret0, _ := ret[0].([]domain.DoctorReschedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReschedulesByScheduleID indicates an expected call of GetReschedulesByScheduleID.
+func (mr *MockDoctorRepositoryMockRecorder) GetReschedulesByScheduleID(ctx, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReschedulesByScheduleID", reflect.TypeOf((*MockDoctorRepository)(nil).GetReschedulesByScheduleID), ctx, scheduleID)
+}
+
+// GetScheduleByID mocks base method.
+func (m *MockDoctorRepository) GetScheduleByID(ctx context.Context, id uuid.UUID) (*domain.DoctorSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduleByID", ctx, id)
+	ret0, _ := ret[0].(*domain.DoctorSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduleByID indicates an expected call of GetScheduleByID.
+func (mr *MockDoctorRepositoryMockRecorder) GetScheduleByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduleByID", reflect.TypeOf((*MockDoctorRepository)(nil).GetScheduleByID), ctx, id)
+}
+
+// GetSchedulesByDoctorID mocks base method.
+func (m *MockDoctorRepository) GetSchedulesByDoctorID(ctx context.Context, doctorID uuid.UUID) ([]domain.DoctorSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedulesByDoctorID", ctx, doctorID)
+	ret0, _ := ret[0].([]domain.DoctorSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedulesByDoctorID indicates an expected call of GetSchedulesByDoctorID.
+func (mr *MockDoctorRepositoryMockRecorder) GetSchedulesByDoctorID(ctx, doctorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedulesByDoctorID", reflect.TypeOf((*MockDoctorRepository)(nil).GetSchedulesByDoctorID), ctx, doctorID)
+}
+
+// List mocks base method.
+func (m *MockDoctorRepository) List(ctx context.Context, filter *domain.DoctorFilter, page, limit int) ([]domain.Doctor, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, page, limit)
+	ret0, _ := ret[0].([]domain.Doctor)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockDoctorRepositoryMockRecorder) List(ctx, filter, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDoctorRepository)(nil).List), ctx, filter, page, limit)
+}
+
+// Update mocks base method.
+func (m *MockDoctorRepository) Update(ctx context.Context, doctor *domain.Doctor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, doctor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDoctorRepositoryMockRecorder) Update(ctx, doctor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDoctorRepository)(nil).Update), ctx, doctor)
+}
+
+// UpdatePhoto mocks base method.
+func (m *MockDoctorRepository) UpdatePhoto(ctx context.Context, id uuid.UUID, photoPath string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePhoto", ctx, id, photoPath)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePhoto indicates an expected call of UpdatePhoto.
+func (mr *MockDoctorRepositoryMockRecorder) UpdatePhoto(ctx, id, photoPath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePhoto", reflect.TypeOf((*MockDoctorRepository)(nil).UpdatePhoto), ctx, id, photoPath)
+}
+
+// UpdateReschedule mocks base method.
+func (m *MockDoctorRepository) UpdateReschedule(ctx context.Context, reschedule *domain.DoctorReschedule, guard domain.ScheduleGuard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReschedule", ctx, reschedule, guard)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReschedule indicates an expected call of UpdateReschedule.
+func (mr *MockDoctorRepositoryMockRecorder) UpdateReschedule(ctx, reschedule, guard interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReschedule", reflect.TypeOf((*MockDoctorRepository)(nil).UpdateReschedule), ctx, reschedule, guard)
+}
+
+// UpdateSchedule mocks base method.
+func (m *MockDoctorRepository) UpdateSchedule(ctx context.Context, schedule *domain.DoctorSchedule, guard domain.ScheduleGuard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSchedule", ctx, schedule, guard)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSchedule indicates an expected call of UpdateSchedule.
+func (mr *MockDoctorRepositoryMockRecorder) UpdateSchedule(ctx, schedule, guard interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSchedule", reflect.TypeOf((*MockDoctorRepository)(nil).UpdateSchedule), ctx, schedule, guard)
+}
+
+// MockDoctorUsecase is a mock of DoctorUsecase interface.
+type MockDoctorUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockDoctorUsecaseMockRecorder
+}
+
+// MockDoctorUsecaseMockRecorder is the mock recorder for MockDoctorUsecase.
+type MockDoctorUsecaseMockRecorder struct {
+	mock *MockDoctorUsecase
+}
+
+// NewMockDoctorUsecase creates a new mock instance.
+func NewMockDoctorUsecase(ctrl *gomock.Controller) *MockDoctorUsecase {
+	mock := &MockDoctorUsecase{ctrl: ctrl}
+	mock.recorder = &MockDoctorUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDoctorUsecase) EXPECT() *MockDoctorUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CheckRescheduleOwner mocks base method.
+func (m *MockDoctorUsecase) CheckRescheduleOwner(ctx context.Context, doctorID, rescheduleID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckRescheduleOwner", ctx, doctorID, rescheduleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckRescheduleOwner indicates an expected call of CheckRescheduleOwner.
+func (mr *MockDoctorUsecaseMockRecorder) CheckRescheduleOwner(ctx, doctorID, rescheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckRescheduleOwner", reflect.TypeOf((*MockDoctorUsecase)(nil).CheckRescheduleOwner), ctx, doctorID, rescheduleID)
+}
+
+// CheckScheduleOwner mocks base method.
+func (m *MockDoctorUsecase) CheckScheduleOwner(ctx context.Context, doctorID, scheduleID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckScheduleOwner", ctx, doctorID, scheduleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckScheduleOwner indicates an expected call of CheckScheduleOwner.
+func (mr *MockDoctorUsecaseMockRecorder) CheckScheduleOwner(ctx, doctorID, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckScheduleOwner", reflect.TypeOf((*MockDoctorUsecase)(nil).CheckScheduleOwner), ctx, doctorID, scheduleID)
+}
+
+// Create mocks base method.
+func (m *MockDoctorUsecase) Create(ctx context.Context, req domain.CreateDoctorRequest) (*domain.Doctor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(*domain.Doctor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDoctorUsecaseMockRecorder) Create(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDoctorUsecase)(nil).Create), ctx, req)
+}
+
+// CreateReschedule mocks base method.
+func (m *MockDoctorUsecase) CreateReschedule(ctx context.Context, scheduleID uuid.UUID, req domain.CreateRescheduleRequest) (*domain.DoctorReschedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReschedule", ctx, scheduleID, req)
+	ret0, _ := ret[0].(*domain.DoctorReschedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReschedule indicates an expected call of CreateReschedule.
+func (mr *MockDoctorUsecaseMockRecorder) CreateReschedule(ctx, scheduleID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReschedule", reflect.TypeOf((*MockDoctorUsecase)(nil).CreateReschedule), ctx, scheduleID, req)
+}
+
+// CreateSchedule mocks base method.
+func (m *MockDoctorUsecase) CreateSchedule(ctx context.Context, doctorID uuid.UUID, req domain.CreateScheduleRequest) (*domain.DoctorSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchedule", ctx, doctorID, req)
+	ret0, _ := ret[0].(*domain.DoctorSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSchedule indicates an expected call of CreateSchedule.
+func (mr *MockDoctorUsecaseMockRecorder) CreateSchedule(ctx, doctorID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*MockDoctorUsecase)(nil).CreateSchedule), ctx, doctorID, req)
+}
+
+// Delete mocks base method.
+func (m *MockDoctorUsecase) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDoctorUsecaseMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDoctorUsecase)(nil).Delete), ctx, id)
+}
+
+// DeletePhoto mocks base method.
+func (m *MockDoctorUsecase) DeletePhoto(ctx context.Context, id uuid.UUID) (*domain.Doctor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePhoto", ctx, id)
+	ret0, _ := ret[0].(*domain.Doctor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePhoto indicates an expected call of DeletePhoto.
+func (mr *MockDoctorUsecaseMockRecorder) DeletePhoto(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePhoto", reflect.TypeOf((*MockDoctorUsecase)(nil).DeletePhoto), ctx, id)
+}
+
+// DeleteReschedule mocks base method.
+func (m *MockDoctorUsecase) DeleteReschedule(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReschedule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReschedule indicates an expected call of DeleteReschedule.
+func (mr *MockDoctorUsecaseMockRecorder) DeleteReschedule(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReschedule", reflect.TypeOf((*MockDoctorUsecase)(nil).DeleteReschedule), ctx, id)
+}
+
+// DeleteSchedule mocks base method.
+func (m *MockDoctorUsecase) DeleteSchedule(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchedule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSchedule indicates an expected call of DeleteSchedule.
+func (mr *MockDoctorUsecaseMockRecorder) DeleteSchedule(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockDoctorUsecase)(nil).DeleteSchedule), ctx, id)
+}
+
+// GetAvailability mocks base method.
+func (m *MockDoctorUsecase) GetAvailability(ctx context.Context, doctorID uuid.UUID, from, to time.Time) ([]domain.DailyAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailability", ctx, doctorID, from, to)
+	ret0, _ := ret[0].([]domain.DailyAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailability indicates an expected call of GetAvailability.
+func (mr *MockDoctorUsecaseMockRecorder) GetAvailability(ctx, doctorID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailability", reflect.TypeOf((*MockDoctorUsecase)(nil).GetAvailability), ctx, doctorID, from, to)
+}
+
+// GetByID mocks base method.
+func (m *MockDoctorUsecase) GetByID(ctx context.Context, id uuid.UUID) (*domain.Doctor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Doctor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDoctorUsecaseMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDoctorUsecase)(nil).GetByID), ctx, id)
+}
+
+// GetBySlug mocks base method.
+func (m *MockDoctorUsecase) GetBySlug(ctx context.Context, slug string) (*domain.Doctor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySlug", ctx, slug)
+	ret0, _ := ret[0].(*domain.Doctor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySlug indicates an expected call of GetBySlug.
+func (mr *MockDoctorUsecaseMockRecorder) GetBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlug", reflect.TypeOf((*MockDoctorUsecase)(nil).GetBySlug), ctx, slug)
+}
+
+// GetByUserID mocks base method.
+func (m *MockDoctorUsecase) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.Doctor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID)
+	ret0, _ := ret[0].(*domain.Doctor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockDoctorUsecaseMockRecorder) GetByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockDoctorUsecase)(nil).GetByUserID), ctx, userID)
+}
+
+// GetReschedulesByScheduleID mocks base method.
+func (m *MockDoctorUsecase) GetReschedulesByScheduleID(ctx context.Context, scheduleID uuid.UUID) ([]domain.DoctorReschedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReschedulesByScheduleID", ctx, scheduleID)
+	ret0, _ := ret[0].([]domain.DoctorReschedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReschedulesByScheduleID indicates an expected call of GetReschedulesByScheduleID.
+func (mr *MockDoctorUsecaseMockRecorder) GetReschedulesByScheduleID(ctx, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReschedulesByScheduleID", reflect.TypeOf((*MockDoctorUsecase)(nil).GetReschedulesByScheduleID), ctx, scheduleID)
+}
+
+// GetSchedulesByDoctorID mocks base method.
+func (m *MockDoctorUsecase) GetSchedulesByDoctorID(ctx context.Context, doctorID uuid.UUID) ([]domain.DoctorSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedulesByDoctorID", ctx, doctorID)
+	ret0, _ := ret[0].([]domain.DoctorSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedulesByDoctorID indicates an expected call of GetSchedulesByDoctorID.
+func (mr *MockDoctorUsecaseMockRecorder) GetSchedulesByDoctorID(ctx, doctorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedulesByDoctorID", reflect.TypeOf((*MockDoctorUsecase)(nil).GetSchedulesByDoctorID), ctx, doctorID)
+}
+
+// IsSlotAvailable mocks base method.
+func (m *MockDoctorUsecase) IsSlotAvailable(ctx context.Context, doctorID, scheduleID uuid.UUID, date time.Time, startTime string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSlotAvailable", ctx, doctorID, scheduleID, date, startTime)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSlotAvailable indicates an expected call of IsSlotAvailable.
+func (mr *MockDoctorUsecaseMockRecorder) IsSlotAvailable(ctx, doctorID, scheduleID, date, startTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSlotAvailable", reflect.TypeOf((*MockDoctorUsecase)(nil).IsSlotAvailable), ctx, doctorID, scheduleID, date, startTime)
+}
+
+// List mocks base method.
+func (m *MockDoctorUsecase) List(ctx context.Context, filter *domain.DoctorFilter, page, limit int) ([]domain.Doctor, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, page, limit)
+	ret0, _ := ret[0].([]domain.Doctor)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockDoctorUsecaseMockRecorder) List(ctx, filter, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDoctorUsecase)(nil).List), ctx, filter, page, limit)
+}
+
+// Update mocks base method.
+func (m *MockDoctorUsecase) Update(ctx context.Context, id uuid.UUID, req domain.UpdateDoctorRequest) (*domain.Doctor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, req)
+	ret0, _ := ret[0].(*domain.Doctor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDoctorUsecaseMockRecorder) Update(ctx, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDoctorUsecase)(nil).Update), ctx, id, req)
+}
+
+// UpdateReschedule mocks base method.
+func (m *MockDoctorUsecase) UpdateReschedule(ctx context.Context, id uuid.UUID, req domain.UpdateRescheduleRequest) (*domain.DoctorReschedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReschedule", ctx, id, req)
+	ret0, _ := ret[0].(*domain.DoctorReschedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateReschedule indicates an expected call of UpdateReschedule.
+func (mr *MockDoctorUsecaseMockRecorder) UpdateReschedule(ctx, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReschedule", reflect.TypeOf((*MockDoctorUsecase)(nil).UpdateReschedule), ctx, id, req)
+}
+
+// UpdateSchedule mocks base method.
+func (m *MockDoctorUsecase) UpdateSchedule(ctx context.Context, id uuid.UUID, req domain.UpdateScheduleRequest) (*domain.DoctorSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSchedule", ctx, id, req)
+	ret0, _ := ret[0].(*domain.DoctorSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSchedule indicates an expected call of UpdateSchedule.
+func (mr *MockDoctorUsecaseMockRecorder) UpdateSchedule(ctx, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSchedule", reflect.TypeOf((*MockDoctorUsecase)(nil).UpdateSchedule), ctx, id, req)
+}
+
+// UploadPhoto mocks base method.
+func (m *MockDoctorUsecase) UploadPhoto(ctx context.Context, id uuid.UUID, photo io.Reader) (*domain.Doctor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadPhoto", ctx, id, photo)
+	ret0, _ := ret[0].(*domain.Doctor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadPhoto indicates an expected call of UploadPhoto.
+func (mr *MockDoctorUsecaseMockRecorder) UploadPhoto(ctx, id, photo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPhoto", reflect.TypeOf((*MockDoctorUsecase)(nil).UploadPhoto), ctx, id, photo)
+}
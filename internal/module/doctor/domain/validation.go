@@ -5,7 +5,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gosimple/slug"
+
 	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	doctorConstant "github.com/gomajido/hospital-cms-golang/internal/module/doctor/constant"
 	"github.com/gomajido/hospital-cms-golang/internal/response"
 	"github.com/google/uuid"
 )
@@ -23,40 +26,92 @@ const (
 	END_TIME_FIELD      = "end_time"
 	DATE_FIELD          = "date"
 	STATUS_FIELD        = "status"
+	SLOT_DURATION_FIELD = "slot_duration"
+	FROM_FIELD          = "from"
+	TO_FIELD            = "to"
+	AVAILABLE_ON_FIELD  = "available_on"
+	SORT_FIELD          = "sort"
+)
+
+const (
+	// Field names of the public profile
+	SLUG_FIELD             = "slug"
+	PHOTO_FIELD            = "photo"
+	LANGUAGES_FIELD        = "languages"
+	EDUCATION_FIELD        = "education"
+	CERTIFICATIONS_FIELD   = "certifications"
+	CONSULTATION_FEE_FIELD = "consultation_fee"
+)
+
+const (
+	// Field names of the linked account
+	USER_ID_FIELD     = "user_id"
+	UNLINK_USER_FIELD = "unlink_user"
 )
 
 // CreateDoctorRequest represents the request to create a doctor
 type CreateDoctorRequest struct {
-	Name           string    `json:"name"`
-	ServiceID      uuid.UUID `json:"service_id"`
-	Description    string    `json:"description"`
-	Specialization string    `json:"specialization"`
-	Degree         string    `json:"degree"`
-	Experience     string    `json:"experience"`
+	UserID          *uuid.UUID      `json:"user_id"` // Optional account of the doctor
+	Name            string          `json:"name"`
+	Slug            string          `json:"slug"` // Generated from the name when empty
+	ServiceID       uuid.UUID       `json:"service_id"`
+	Description     string          `json:"description"`
+	Specialization  string          `json:"specialization"`
+	Degree          string          `json:"degree"`
+	Experience      string          `json:"experience"`
+	Languages       []string        `json:"languages"`
+	Education       []Education     `json:"education"`
+	Certifications  []Certification `json:"certifications"`
+	ConsultationFee *int64          `json:"consultation_fee"`
 }
 
 // UpdateDoctorRequest represents the request to update a doctor
 type UpdateDoctorRequest struct {
-	Name           string    `json:"name"`
-	ServiceID      uuid.UUID `json:"service_id"`
-	Description    string    `json:"description"`
-	Specialization string    `json:"specialization"`
-	Degree         string    `json:"degree"`
-	Experience     string    `json:"experience"`
+	UserID          *uuid.UUID      `json:"user_id"`     // The linked account is kept when omitted
+	UnlinkUser      bool            `json:"unlink_user"` // Removes the linked account
+	Name            string          `json:"name"`
+	Slug            string          `json:"slug"` // The current slug is kept when empty
+	ServiceID       uuid.UUID       `json:"service_id"`
+	Description     string          `json:"description"`
+	Specialization  string          `json:"specialization"`
+	Degree          string          `json:"degree"`
+	Experience      string          `json:"experience"`
+	Languages       []string        `json:"languages"`
+	Education       []Education     `json:"education"`
+	Certifications  []Certification `json:"certifications"`
+	ConsultationFee *int64          `json:"consultation_fee"`
 }
 
 // CreateScheduleRequest represents the request to create a doctor schedule
 type CreateScheduleRequest struct {
-	Day       string `json:"day"`
-	StartTime string `json:"start_time"`
-	EndTime   string `json:"end_time"`
+	Day          string `json:"day"`
+	StartTime    string `json:"start_time"`
+	EndTime      string `json:"end_time"`
+	SlotDuration int    `json:"slot_duration"`
 }
 
 // UpdateScheduleRequest represents the request to update a doctor schedule
 type UpdateScheduleRequest struct {
-	Day       string `json:"day"`
-	StartTime string `json:"start_time"`
-	EndTime   string `json:"end_time"`
+	Day          string `json:"day"`
+	StartTime    string `json:"start_time"`
+	EndTime      string `json:"end_time"`
+	SlotDuration int    `json:"slot_duration"`
+}
+
+// AvailabilityRequest represents the request to list a doctor's free slots
+type AvailabilityRequest struct {
+	From string `query:"from"`
+	To   string `query:"to"`
+}
+
+// DoctorFilter narrows down and orders the doctors listed to visitors
+type DoctorFilter struct {
+	ServiceID      string
+	Specialization string
+	Name           string // Matched against part of the doctor name
+	Day            string // Weekday the doctor holds a schedule on
+	AvailableOn    string // Date (YYYY-MM-DD) the doctor still has a free slot on
+	Sort           string
 }
 
 // CreateRescheduleRequest represents the request to create a schedule change
@@ -115,6 +170,8 @@ func (c *CreateDoctorRequest) Validate() []response.ErrorInfo {
 		})
 	}
 
+	errorInfo = append(errorInfo, validateProfile(c.Slug, c.Languages, c.Education, c.Certifications, c.ConsultationFee)...)
+
 	return errorInfo
 }
 
@@ -156,6 +213,15 @@ func (u *UpdateDoctorRequest) Validate() []response.ErrorInfo {
 		})
 	}
 
+	if u.UnlinkUser && u.UserID != nil {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        UNLINK_USER_FIELD,
+			ErrorMessage: fmt.Sprintf("%s cannot be set together with %s", UNLINK_USER_FIELD, USER_ID_FIELD),
+		})
+	}
+
+	errorInfo = append(errorInfo, validateProfile(u.Slug, u.Languages, u.Education, u.Certifications, u.ConsultationFee)...)
+
 	return errorInfo
 }
 
@@ -212,6 +278,9 @@ func (c *CreateScheduleRequest) Validate() []response.ErrorInfo {
 		})
 	}
 
+	errorInfo = append(errorInfo, validateTimeRange(c.StartTime, c.EndTime)...)
+	errorInfo = append(errorInfo, validateSlotDuration(c.SlotDuration)...)
+
 	return errorInfo
 }
 
@@ -268,6 +337,9 @@ func (u *UpdateScheduleRequest) Validate() []response.ErrorInfo {
 		})
 	}
 
+	errorInfo = append(errorInfo, validateTimeRange(u.StartTime, u.EndTime)...)
+	errorInfo = append(errorInfo, validateSlotDuration(u.SlotDuration)...)
+
 	return errorInfo
 }
 
@@ -315,6 +387,8 @@ func (c *CreateRescheduleRequest) Validate() []response.ErrorInfo {
 		})
 	}
 
+	errorInfo = append(errorInfo, validateTimeRange(c.StartTime, c.EndTime)...)
+
 	if c.Status == constant.EMPTY_STRING {
 		errorInfo = append(errorInfo, response.ErrorInfo{
 			Field:        STATUS_FIELD,
@@ -369,6 +443,8 @@ func (u *UpdateRescheduleRequest) Validate() []response.ErrorInfo {
 		})
 	}
 
+	errorInfo = append(errorInfo, validateTimeRange(u.StartTime, u.EndTime)...)
+
 	if u.Status == constant.EMPTY_STRING {
 		errorInfo = append(errorInfo, response.ErrorInfo{
 			Field:        STATUS_FIELD,
@@ -377,17 +453,256 @@ func (u *UpdateRescheduleRequest) Validate() []response.ErrorInfo {
 	} else if !isValidStatus(u.Status) {
 		errorInfo = append(errorInfo, response.ErrorInfo{
 			Field:        STATUS_FIELD,
-			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_VALUE, STATUS_FIELD, "active, inactive"),
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_VALUE, STATUS_FIELD, "changed, cancelled"),
+		})
+	}
+
+	return errorInfo
+}
+
+func (a *AvailabilityRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	from, fromErr := time.Parse(doctorConstant.DateFormat, a.From)
+	if a.From == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        FROM_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, FROM_FIELD),
+		})
+	} else if fromErr != nil {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        FROM_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_FORMAT, FROM_FIELD, "YYYY-MM-DD"),
+		})
+	}
+
+	to, toErr := time.Parse(doctorConstant.DateFormat, a.To)
+	if a.To == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        TO_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, TO_FIELD),
+		})
+	} else if toErr != nil {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        TO_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_FORMAT, TO_FIELD, "YYYY-MM-DD"),
+		})
+	}
+
+	if len(errorInfo) > 0 {
+		return errorInfo
+	}
+
+	if to.Before(from) {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        TO_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MIN_VALUE, TO_FIELD, a.From),
+		})
+	} else if to.Sub(from) >= doctorConstant.MaxAvailabilityRangeDays*24*time.Hour {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        TO_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MAX_VALUE, TO_FIELD, from.AddDate(0, 0, doctorConstant.MaxAvailabilityRangeDays-1).Format(doctorConstant.DateFormat)),
+		})
+	}
+
+	return errorInfo
+}
+
+// Dates returns the parsed from and to dates, it must be called after Validate
+func (a *AvailabilityRequest) Dates() (time.Time, time.Time) {
+	from, _ := time.Parse(doctorConstant.DateFormat, a.From)
+	to, _ := time.Parse(doctorConstant.DateFormat, a.To)
+	return from, to
+}
+
+func (f *DoctorFilter) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if f.ServiceID != constant.EMPTY_STRING {
+		if _, err := uuid.Parse(f.ServiceID); err != nil {
+			errorInfo = append(errorInfo, response.ErrorInfo{
+				Field:        SERVICE_ID_FIELD,
+				ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_FORMAT, SERVICE_ID_FIELD, "UUID"),
+			})
+		}
+	}
+
+	if f.Day != constant.EMPTY_STRING && !isValidDay(f.Day) {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        DAY_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_VALUE, DAY_FIELD, strings.Join(weekdays, ", ")),
+		})
+	}
+
+	if f.AvailableOn != constant.EMPTY_STRING {
+		if _, err := time.Parse(doctorConstant.DateFormat, f.AvailableOn); err != nil {
+			errorInfo = append(errorInfo, response.ErrorInfo{
+				Field:        AVAILABLE_ON_FIELD,
+				ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_FORMAT, AVAILABLE_ON_FIELD, "YYYY-MM-DD"),
+			})
+		} else if f.AvailableOn < time.Now().Format(doctorConstant.DateFormat) {
+			errorInfo = append(errorInfo, response.ErrorInfo{
+				Field:        AVAILABLE_ON_FIELD,
+				ErrorMessage: fmt.Sprintf(constant.VALIDATION_FUTURE_DATE, AVAILABLE_ON_FIELD),
+			})
+		}
+	}
+
+	switch f.Sort {
+	case constant.EMPTY_STRING, doctorConstant.DoctorSortName, doctorConstant.DoctorSortNameDesc,
+		doctorConstant.DoctorSortService, doctorConstant.DoctorSortNewest:
+	default:
+		sorts := strings.Join([]string{
+			doctorConstant.DoctorSortName, doctorConstant.DoctorSortNameDesc,
+			doctorConstant.DoctorSortService, doctorConstant.DoctorSortNewest,
+		}, ", ")
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        SORT_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_VALUE, SORT_FIELD, sorts),
+		})
+	}
+
+	return errorInfo
+}
+
+// validateProfile validates the public profile details shared by the doctor create and update requests
+func validateProfile(doctorSlug string, languages []string, education []Education, certifications []Certification, consultationFee *int64) []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if doctorSlug != constant.EMPTY_STRING && !slug.IsSlug(doctorSlug) {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        SLUG_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_FORMAT, SLUG_FIELD, "lowercase-words-with-dashes"),
+		})
+	}
+
+	entries := []struct {
+		field string
+		count int
+	}{
+		{LANGUAGES_FIELD, len(languages)},
+		{EDUCATION_FIELD, len(education)},
+		{CERTIFICATIONS_FIELD, len(certifications)},
+	}
+	for _, entry := range entries {
+		if entry.count > doctorConstant.MaxProfileEntries {
+			errorInfo = append(errorInfo, response.ErrorInfo{
+				Field:        entry.field,
+				ErrorMessage: fmt.Sprintf(constant.VALIDATION_MAX_VALUE, entry.field+" count", fmt.Sprint(doctorConstant.MaxProfileEntries)),
+			})
+		}
+	}
+
+	for i, entry := range education {
+		field := fmt.Sprintf("%s[%d]", EDUCATION_FIELD, i)
+		if strings.TrimSpace(entry.Degree) == constant.EMPTY_STRING || strings.TrimSpace(entry.Institution) == constant.EMPTY_STRING {
+			errorInfo = append(errorInfo, response.ErrorInfo{
+				Field:        field,
+				ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, field+" degree and institution"),
+			})
+		}
+		errorInfo = append(errorInfo, validateYear(field, entry.Year)...)
+	}
+
+	for i, entry := range certifications {
+		field := fmt.Sprintf("%s[%d]", CERTIFICATIONS_FIELD, i)
+		if strings.TrimSpace(entry.Name) == constant.EMPTY_STRING {
+			errorInfo = append(errorInfo, response.ErrorInfo{
+				Field:        field,
+				ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, field+" name"),
+			})
+		}
+		errorInfo = append(errorInfo, validateYear(field, entry.Year)...)
+	}
+
+	if consultationFee != nil && *consultationFee < 0 {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        CONSULTATION_FEE_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MIN_VALUE, CONSULTATION_FEE_FIELD, "0"),
+		})
+	}
+
+	return errorInfo
+}
+
+// validateYear accepts an unset year or one between 1900 and the current year
+func validateYear(field string, year int) []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if year == 0 {
+		return errorInfo
+	}
+
+	if year < 1900 {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        field,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MIN_VALUE, field+" year", "1900"),
+		})
+	} else if year > time.Now().Year() {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        field,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MAX_VALUE, field+" year", fmt.Sprint(time.Now().Year())),
+		})
+	}
+
+	return errorInfo
+}
+
+// validateTimeRange makes sure a time window ends after it starts, unparsable times are reported by their own checks
+func validateTimeRange(startTime, endTime string) []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	start, startErr := time.Parse(doctorConstant.ClockTimeFormat, startTime)
+	end, endErr := time.Parse(doctorConstant.ClockTimeFormat, endTime)
+	if startErr != nil || endErr != nil {
+		return errorInfo
+	}
+
+	if !end.After(start) {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        END_TIME_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MIN_VALUE, END_TIME_FIELD, start.Add(time.Minute).Format(doctorConstant.ClockTimeFormat)),
+		})
+	}
+
+	return errorInfo
+}
+
+func validateSlotDuration(slotDuration int) []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	// Zero means the default slot duration will be used
+	if slotDuration < 0 {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        SLOT_DURATION_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MIN_VALUE, SLOT_DURATION_FIELD, "1"),
+		})
+	} else if slotDuration > doctorConstant.MaxSlotDuration {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        SLOT_DURATION_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MAX_VALUE, SLOT_DURATION_FIELD, fmt.Sprint(doctorConstant.MaxSlotDuration)),
 		})
 	}
 
 	return errorInfo
 }
 
+// weekdays lists the days a doctor schedule can be held on
+var weekdays = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+func isValidDay(day string) bool {
+	for _, weekday := range weekdays {
+		if day == weekday {
+			return true
+		}
+	}
+	return false
+}
+
 func isValidStatus(status string) bool {
 	validStatuses := map[string]bool{
-		"active":   true,
-		"inactive": true,
+		doctorConstant.RescheduleStatusChanged:   true,
+		doctorConstant.RescheduleStatusCancelled: true,
 	}
-	return validStatuses[status]
+	return validStatuses[strings.ToLower(status)]
 }
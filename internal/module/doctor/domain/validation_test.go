@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/constant"
+)
+
+func TestDoctorFilter_Validate(t *testing.T) {
+	tomorrow := time.Now().AddDate(0, 0, 1).Format(constant.DateFormat)
+	yesterday := time.Now().AddDate(0, 0, -1).Format(constant.DateFormat)
+
+	tests := []struct {
+		name      string
+		filter    DoctorFilter
+		wantField string
+	}{
+		{
+			name: "No filter",
+		},
+		{
+			name: "All filters",
+			filter: DoctorFilter{
+				ServiceID:      "0b0e6d1c-7a52-4f3e-9d5c-3f1f4b1e2a10",
+				Specialization: "Cardiologist",
+				Name:           "budi",
+				Day:            "Monday",
+				AvailableOn:    tomorrow,
+				Sort:           constant.DoctorSortService,
+			},
+		},
+		{
+			name:      "Invalid service",
+			filter:    DoctorFilter{ServiceID: "cardiology"},
+			wantField: SERVICE_ID_FIELD,
+		},
+		{
+			name:      "Invalid day",
+			filter:    DoctorFilter{Day: "monday"},
+			wantField: DAY_FIELD,
+		},
+		{
+			name:      "Invalid date",
+			filter:    DoctorFilter{AvailableOn: "01-07-2030"},
+			wantField: AVAILABLE_ON_FIELD,
+		},
+		{
+			name:      "Past date",
+			filter:    DoctorFilter{AvailableOn: yesterday},
+			wantField: AVAILABLE_ON_FIELD,
+		},
+		{
+			name:      "Unknown sort",
+			filter:    DoctorFilter{Sort: "experience"},
+			wantField: SORT_FIELD,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.filter.Validate()
+			if tt.wantField == "" {
+				if len(errs) > 0 {
+					t.Errorf("Validate() = %+v, want no errors", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Field != tt.wantField {
+				t.Errorf("Validate() = %+v, want a %s error", errs, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestCreateDoctorRequest_Validate_Profile(t *testing.T) {
+	fee := int64(-1)
+	req := CreateDoctorRequest{
+		Name:            "Dr. John Smith",
+		ServiceID:       uuid.New(),
+		Specialization:  "General Medicine",
+		Degree:          "MD",
+		Experience:      "15 years",
+		Slug:            "Dr John",
+		Education:       []Education{{Degree: "MD", Year: time.Now().Year() + 1}},
+		Certifications:  []Certification{{Issuer: "Medical Council"}},
+		ConsultationFee: &fee,
+	}
+
+	errs := req.Validate()
+	want := []string{SLUG_FIELD, EDUCATION_FIELD + "[0]", EDUCATION_FIELD + "[0]", CERTIFICATIONS_FIELD + "[0]", CONSULTATION_FEE_FIELD}
+	if len(errs) != len(want) {
+		t.Fatalf("Validate() = %+v, want errors on %v", errs, want)
+	}
+	for i, field := range want {
+		if errs[i].Field != field {
+			t.Errorf("Validate() error %d on %s, want %s", i, errs[i].Field, field)
+		}
+	}
+}
+
+func TestScheduleRequests_Validate_TimeRange(t *testing.T) {
+	schedule := CreateScheduleRequest{Day: "Monday", StartTime: "12:00:00", EndTime: "12:00:00"}
+	if errs := schedule.Validate(); len(errs) != 1 || errs[0].Field != END_TIME_FIELD {
+		t.Errorf("CreateScheduleRequest.Validate() = %+v, want an end_time error", errs)
+	}
+
+	reschedule := UpdateRescheduleRequest{
+		Date:      time.Now().AddDate(0, 0, 7),
+		StartTime: "09:00:00",
+		EndTime:   "12:00:00",
+		Status:    constant.RescheduleStatusChanged,
+	}
+	if errs := reschedule.Validate(); len(errs) != 0 {
+		t.Errorf("UpdateRescheduleRequest.Validate() = %+v, want no errors", errs)
+	}
+
+	reschedule.StartTime, reschedule.EndTime = reschedule.EndTime, reschedule.StartTime
+	if errs := reschedule.Validate(); len(errs) != 1 || errs[0].Field != END_TIME_FIELD {
+		t.Errorf("UpdateRescheduleRequest.Validate() swapped times = %+v, want an end_time error", errs)
+	}
+}
+
+func TestUpdateDoctorRequest_Validate_UnlinkUser(t *testing.T) {
+	userID := uuid.New()
+	req := UpdateDoctorRequest{
+		UserID:         &userID,
+		UnlinkUser:     true,
+		Name:           "Dr. John Smith",
+		ServiceID:      uuid.New(),
+		Specialization: "General Medicine",
+		Degree:         "MD",
+		Experience:     "15 years",
+	}
+
+	if errs := req.Validate(); len(errs) != 1 || errs[0].Field != UNLINK_USER_FIELD {
+		t.Errorf("Validate() = %+v, want an unlink_user error", errs)
+	}
+
+	req.UserID = nil
+	if errs := req.Validate(); len(errs) != 0 {
+		t.Errorf("Validate() = %+v, want no errors", errs)
+	}
+}
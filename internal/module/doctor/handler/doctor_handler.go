@@ -1,10 +1,15 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	appConstant "github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/constant"
 	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/domain"
 	"github.com/gomajido/hospital-cms-golang/internal/response"
 	"github.com/google/uuid"
@@ -32,6 +37,9 @@ func (h *DoctorHandler) Create(c *fiber.Ctx) error {
 
 	doctor, err := h.doctorUsecase.Create(c.Context(), req)
 	if err != nil {
+		if errors.Is(err, constant.ErrDoctorAccountTaken) || errors.Is(err, constant.ErrDoctorSlugTaken) {
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer)
 	}
 
@@ -52,11 +60,44 @@ func (h *DoctorHandler) GetByID(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(doctor))
 }
 
+// GetBySlug gets the doctor shown on a public profile page
+func (h *DoctorHandler) GetBySlug(c *fiber.Ctx) error {
+	doctor, err := h.doctorUsecase.GetBySlug(c.Context(), c.Params("slug"))
+	if err != nil {
+		if errors.Is(err, constant.ErrDoctorNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(doctor))
+}
+
+// List pages through doctors, filtered by service, specialization, name, schedule day or a date with free slots
 func (h *DoctorHandler) List(c *fiber.Ctx) error {
+	filter := &domain.DoctorFilter{
+		ServiceID:      c.Query("service_id"),
+		Specialization: strings.TrimSpace(c.Query("specialization")),
+		Name:           strings.TrimSpace(c.Query("name")),
+		Day:            c.Query("day"),
+		AvailableOn:    c.Query("available_on"),
+		Sort:           c.Query("sort"),
+	}
+
+	if errors := filter.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
 
-	doctors, total, err := h.doctorUsecase.List(c.Context(), page, limit)
+	doctors, total, err := h.doctorUsecase.List(c.Context(), filter, page, limit)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
 	}
@@ -75,6 +116,33 @@ func (h *DoctorHandler) List(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(listResponse))
 }
 
+func (h *DoctorHandler) GetAvailability(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
+	}
+
+	var req domain.AvailabilityRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	from, to := req.Dates()
+	availability, err := h.doctorUsecase.GetAvailability(c.Context(), id, from, to)
+	if err != nil {
+		if errors.Is(err, constant.ErrDoctorNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(availability))
+}
+
 func (h *DoctorHandler) Update(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
@@ -92,6 +160,12 @@ func (h *DoctorHandler) Update(c *fiber.Ctx) error {
 
 	doctor, err := h.doctorUsecase.Update(c.Context(), id, req)
 	if err != nil {
+		if errors.Is(err, constant.ErrDoctorNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		if errors.Is(err, constant.ErrDoctorAccountTaken) || errors.Is(err, constant.ErrDoctorSlugTaken) {
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer)
 	}
 
@@ -111,8 +185,85 @@ func (h *DoctorHandler) Delete(c *fiber.Ctx) error {
 
 }
 
+// UploadPhoto replaces the profile photo with the image sent in the photo form field
+func (h *DoctorHandler) UploadPhoto(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
+	}
+
+	fileHeader, err := c.FormFile(domain.PHOTO_FIELD)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo([]response.ErrorInfo{{
+			Field:        domain.PHOTO_FIELD,
+			ErrorMessage: fmt.Sprintf(appConstant.VALIDATION_REQUIRED, domain.PHOTO_FIELD),
+		}}))
+	}
+	if fileHeader.Size > constant.MaxPhotoSize {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(constant.ErrPhotoTooLarge))
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+	defer file.Close()
+
+	doctor, err := h.doctorUsecase.UploadPhoto(c.Context(), id, file)
+	if err != nil {
+		switch {
+		case errors.Is(err, constant.ErrInvalidPhoto), errors.Is(err, constant.ErrPhotoTooLarge):
+			return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
+		case errors.Is(err, constant.ErrDoctorNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(doctor))
+}
+
+// DeletePhoto removes the profile photo
+func (h *DoctorHandler) DeletePhoto(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
+	}
+
+	doctor, err := h.doctorUsecase.DeletePhoto(c.Context(), id)
+	if err != nil {
+		if errors.Is(err, constant.ErrDoctorNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(doctor))
+}
+
+// scheduleError writes the response for an error of a schedule or reschedule request.
+// A change refused because of booked appointments lists them in the response data.
+func scheduleError(c *fiber.Ctx, err error) error {
+	var affected *domain.AppointmentsAffectedError
+	switch {
+	case errors.As(err, &affected):
+		conflict := response.ErrConflict.WithError(err)
+		conflict.Data = affected.Appointments
+		return c.Status(fiber.StatusConflict).JSON(conflict)
+	case errors.Is(err, constant.ErrScheduleOverlap), errors.Is(err, constant.ErrRescheduleExists):
+		return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+	case errors.Is(err, constant.ErrRescheduleDayMismatch):
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
+	case errors.Is(err, constant.ErrDoctorNotFound), errors.Is(err, constant.ErrScheduleNotFound), errors.Is(err, constant.ErrRescheduleNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer)
+	}
+}
+
 func (h *DoctorHandler) CreateSchedule(c *fiber.Ctx) error {
-	doctorID, err := uuid.Parse(c.Params("doctor_id"))
+	doctorID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
 	}
@@ -128,14 +279,14 @@ func (h *DoctorHandler) CreateSchedule(c *fiber.Ctx) error {
 
 	schedule, err := h.doctorUsecase.CreateSchedule(c.Context(), doctorID, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer)
+		return scheduleError(c, err)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(schedule))
 }
 
 func (h *DoctorHandler) GetSchedules(c *fiber.Ctx) error {
-	doctorID, err := uuid.Parse(c.Params("doctor_id"))
+	doctorID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
 	}
@@ -165,7 +316,7 @@ func (h *DoctorHandler) UpdateSchedule(c *fiber.Ctx) error {
 
 	schedule, err := h.doctorUsecase.UpdateSchedule(c.Context(), id, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer)
+		return scheduleError(c, err)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(schedule))
@@ -178,14 +329,14 @@ func (h *DoctorHandler) DeleteSchedule(c *fiber.Ctx) error {
 	}
 
 	if err := h.doctorUsecase.DeleteSchedule(c.Context(), id); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer)
+		return scheduleError(c, err)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response.Ok)
 }
 
 func (h *DoctorHandler) CreateReschedule(c *fiber.Ctx) error {
-	scheduleID, err := uuid.Parse(c.Params("schedule_id"))
+	scheduleID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
 	}
@@ -201,14 +352,14 @@ func (h *DoctorHandler) CreateReschedule(c *fiber.Ctx) error {
 
 	reschedule, err := h.doctorUsecase.CreateReschedule(c.Context(), scheduleID, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer)
+		return scheduleError(c, err)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(reschedule))
 }
 
 func (h *DoctorHandler) GetReschedules(c *fiber.Ctx) error {
-	scheduleID, err := uuid.Parse(c.Params("schedule_id"))
+	scheduleID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
 	}
@@ -238,7 +389,7 @@ func (h *DoctorHandler) UpdateReschedule(c *fiber.Ctx) error {
 
 	reschedule, err := h.doctorUsecase.UpdateReschedule(c.Context(), id, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer)
+		return scheduleError(c, err)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(reschedule))
@@ -251,7 +402,7 @@ func (h *DoctorHandler) DeleteReschedule(c *fiber.Ctx) error {
 	}
 
 	if err := h.doctorUsecase.DeleteReschedule(c.Context(), id); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer)
+		return scheduleError(c, err)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response.Ok)
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/identity"
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/response"
+	"github.com/google/uuid"
+)
+
+// currentDoctor gets the doctor linked to the authenticated user
+func (h *DoctorHandler) currentDoctor(c *fiber.Ctx) (*domain.Doctor, error) {
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.doctorUsecase.GetByUserID(c.Context(), userIdentity.UserID)
+}
+
+// portalError writes the response for an error of a doctor portal request
+func portalError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, identity.ErrMissingIdentity):
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	case errors.Is(err, constant.ErrDoctorAccountNotLinked):
+		return c.Status(fiber.StatusForbidden).JSON(response.ErrForbidden.WithError(err))
+	default:
+		return scheduleError(c, err)
+	}
+}
+
+func (h *DoctorHandler) GetMyProfile(c *fiber.Ctx) error {
+	doctor, err := h.currentDoctor(c)
+	if err != nil {
+		return portalError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(doctor))
+}
+
+func (h *DoctorHandler) GetMySchedules(c *fiber.Ctx) error {
+	doctor, err := h.currentDoctor(c)
+	if err != nil {
+		return portalError(c, err)
+	}
+
+	schedules, err := h.doctorUsecase.GetSchedulesByDoctorID(c.Context(), doctor.ID)
+	if err != nil {
+		return portalError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(schedules))
+}
+
+func (h *DoctorHandler) CreateMySchedule(c *fiber.Ctx) error {
+	var req domain.CreateScheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	doctor, err := h.currentDoctor(c)
+	if err != nil {
+		return portalError(c, err)
+	}
+
+	schedule, err := h.doctorUsecase.CreateSchedule(c.Context(), doctor.ID, req)
+	if err != nil {
+		return portalError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(schedule))
+}
+
+func (h *DoctorHandler) UpdateMySchedule(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
+	}
+
+	var req domain.UpdateScheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	doctor, err := h.currentDoctor(c)
+	if err != nil {
+		return portalError(c, err)
+	}
+	if err := h.doctorUsecase.CheckScheduleOwner(c.Context(), doctor.ID, id); err != nil {
+		return portalError(c, err)
+	}
+
+	schedule, err := h.doctorUsecase.UpdateSchedule(c.Context(), id, req)
+	if err != nil {
+		return portalError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(schedule))
+}
+
+func (h *DoctorHandler) DeleteMySchedule(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
+	}
+
+	doctor, err := h.currentDoctor(c)
+	if err != nil {
+		return portalError(c, err)
+	}
+	if err := h.doctorUsecase.CheckScheduleOwner(c.Context(), doctor.ID, id); err != nil {
+		return portalError(c, err)
+	}
+
+	if err := h.doctorUsecase.DeleteSchedule(c.Context(), id); err != nil {
+		return portalError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
+
+func (h *DoctorHandler) GetMyReschedules(c *fiber.Ctx) error {
+	scheduleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
+	}
+
+	doctor, err := h.currentDoctor(c)
+	if err != nil {
+		return portalError(c, err)
+	}
+	if err := h.doctorUsecase.CheckScheduleOwner(c.Context(), doctor.ID, scheduleID); err != nil {
+		return portalError(c, err)
+	}
+
+	reschedules, err := h.doctorUsecase.GetReschedulesByScheduleID(c.Context(), scheduleID)
+	if err != nil {
+		return portalError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(reschedules))
+}
+
+func (h *DoctorHandler) CreateMyReschedule(c *fiber.Ctx) error {
+	scheduleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
+	}
+
+	var req domain.CreateRescheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	doctor, err := h.currentDoctor(c)
+	if err != nil {
+		return portalError(c, err)
+	}
+	if err := h.doctorUsecase.CheckScheduleOwner(c.Context(), doctor.ID, scheduleID); err != nil {
+		return portalError(c, err)
+	}
+
+	reschedule, err := h.doctorUsecase.CreateReschedule(c.Context(), scheduleID, req)
+	if err != nil {
+		return portalError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(reschedule))
+}
+
+func (h *DoctorHandler) UpdateMyReschedule(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
+	}
+
+	var req domain.UpdateRescheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	doctor, err := h.currentDoctor(c)
+	if err != nil {
+		return portalError(c, err)
+	}
+	if err := h.doctorUsecase.CheckRescheduleOwner(c.Context(), doctor.ID, id); err != nil {
+		return portalError(c, err)
+	}
+
+	reschedule, err := h.doctorUsecase.UpdateReschedule(c.Context(), id, req)
+	if err != nil {
+		return portalError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(reschedule))
+}
+
+func (h *DoctorHandler) DeleteMyReschedule(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
+	}
+
+	doctor, err := h.currentDoctor(c)
+	if err != nil {
+		return portalError(c, err)
+	}
+	if err := h.doctorUsecase.CheckRescheduleOwner(c.Context(), doctor.ID, id); err != nil {
+		return portalError(c, err)
+	}
+
+	if err := h.doctorUsecase.DeleteReschedule(c.Context(), id); err != nil {
+		return portalError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
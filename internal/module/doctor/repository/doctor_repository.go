@@ -3,12 +3,38 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/go-sql-driver/mysql"
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/constant"
 	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/domain"
 	"github.com/google/uuid"
 )
 
+// mysqlErrDuplicateEntry is returned by MySQL when a unique index is violated
+const mysqlErrDuplicateEntry = 1062
+
+// slugUniqueKey is the unique index named in duplicate slug errors
+const slugUniqueKey = "uk_doctors_slug"
+
+// doctorColumns selects a doctor together with its service, it is read by scanDoctor
+const doctorColumns = `
+	d.id, d.user_id, d.name, d.slug, d.photo_path, d.service_id, d.description, d.specialization,
+	d.degree, d.experience, d.languages, d.education, d.certifications, d.consultation_fee,
+	s.id, s.name, s.slug, s.description`
+
+// queryer runs queries on the database or inside a transaction
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 type doctorRepository struct {
 	db *sql.DB
 }
@@ -20,34 +46,21 @@ func NewDoctorRepository(db *sql.DB) domain.DoctorRepository {
 }
 
 func (r *doctorRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Doctor, error) {
-	doctor := &domain.Doctor{}
-	service := &domain.Service{}
-
 	// Get doctor and service data
 	query := `
-		SELECT 
-			d.id, d.name, d.service_id, d.description, d.specialization,
-			d.degree, d.experience,
-			s.id, s.name, s.description
+		SELECT ` + doctorColumns + `
 		FROM doctors d
-		LEFT JOIN services s ON d.service_id = s.id
+		INNER JOIN services s ON d.service_id = s.id
 		WHERE d.id = ?`
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&doctor.ID, &doctor.Name, &doctor.ServiceID, &doctor.Description,
-		&doctor.Specialization, &doctor.Degree, &doctor.Experience,
-		&service.ID, &service.Name, &service.Description,
-	)
-
+	doctor, err := scanDoctor(r.db.QueryRowContext(ctx, query, id))
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("doctor not found")
+		return nil, constant.ErrDoctorNotFound
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	doctor.Service = service
-
 	// Get doctor schedules
 	schedules, err := r.GetSchedulesByDoctorID(ctx, id)
 	if err != nil {
@@ -97,48 +110,78 @@ func (r *doctorRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.D
 	return doctor, nil
 }
 
-func (r *doctorRepository) List(ctx context.Context, page, limit int) ([]domain.Doctor, int64, error) {
+// GetByUserID gets the doctor linked to a user account
+func (r *doctorRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.Doctor, error) {
+	var id uuid.UUID
+	err := r.db.QueryRowContext(ctx, "SELECT id FROM doctors WHERE user_id = ?", userID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, constant.ErrDoctorNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+// GetBySlug gets the doctor shown on a public profile page
+func (r *doctorRepository) GetBySlug(ctx context.Context, slug string) (*domain.Doctor, error) {
+	var id uuid.UUID
+	err := r.db.QueryRowContext(ctx, "SELECT id FROM doctors WHERE slug = ?", slug).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, constant.ErrDoctorNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+// List pages through doctors matching the filter, each with its service
+func (r *doctorRepository) List(ctx context.Context, filter *domain.DoctorFilter, page, limit int) ([]domain.Doctor, int64, error) {
+	where, args := doctorFilterClause(filter, time.Now())
+	whereClause := ""
+	if len(where) > 0 {
+		whereClause = "WHERE " + strings.Join(where, " AND ")
+	}
+
 	var total int64
-	var doctors []domain.Doctor
+	doctors := []domain.Doctor{}
 
 	// Get total count
-	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM doctors").Scan(&total)
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM doctors d "+whereClause, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
 
+	sort := ""
+	if filter != nil {
+		sort = filter.Sort
+	}
+
 	// Get doctors with services
 	offset := (page - 1) * limit
 	query := `
-		SELECT 
-			d.id, d.name, d.service_id, d.description, d.specialization,
-			d.degree, d.experience,
-			s.id, s.name, s.description
+		SELECT ` + doctorColumns + `
 		FROM doctors d
-		LEFT JOIN services s ON d.service_id = s.id
+		INNER JOIN services s ON d.service_id = s.id
+		` + whereClause + `
+		ORDER BY ` + doctorOrderBy(sort) + `
 		LIMIT ? OFFSET ?`
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
 	if err != nil {
 		return nil, 0, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		doctor := domain.Doctor{}
-		service := domain.Service{}
-
-		err := rows.Scan(
-			&doctor.ID, &doctor.Name, &doctor.ServiceID, &doctor.Description,
-			&doctor.Specialization, &doctor.Degree, &doctor.Experience,
-			&service.ID, &service.Name, &service.Description,
-		)
+		doctor, err := scanDoctor(rows)
 		if err != nil {
 			return nil, 0, err
 		}
-
-		doctor.Service = &service
-		doctors = append(doctors, doctor)
+		doctors = append(doctors, *doctor)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -148,37 +191,159 @@ func (r *doctorRepository) List(ctx context.Context, page, limit int) ([]domain.
 	return doctors, total, nil
 }
 
+// freeSlotDoctorsQuery selects the doctors with a slot left on a date, the way the availability of
+// a doctor lists them. A schedule held that day and not cancelled by a reschedule is split into
+// slots over its effective window, the rescheduled hours when changed. A slot is left when it has
+// not started and no scheduled appointment of the doctor that date overlaps it, each appointment
+// holding one slot of its own schedule from its start time. Rather than every slot, only the
+// first slot not started and the first slot after each appointment are checked: the first free
+// slot is always one of them, as the slot before it is either started or taken by an appointment
+// ending before it.
+const freeSlotDoctorsQuery = `
+	SELECT f.doctor_id
+	FROM (
+		SELECT w.doctor_id, w.start_sec, w.slot_sec, w.slots,
+			GREATEST(w.started, CEIL((c.free_from - w.start_sec) / w.slot_sec)) AS slot
+		FROM (
+			SELECT s.doctor_id, s.start_sec, s.slot_sec,
+				FLOOR((s.end_sec - s.start_sec) / s.slot_sec) AS slots,
+				GREATEST(0, FLOOR((? - s.start_sec) / s.slot_sec) + 1) AS started
+			FROM (
+				SELECT ds.doctor_id,
+					TIME_TO_SEC(COALESCE(dr.start_time, ds.start_time)) AS start_sec,
+					TIME_TO_SEC(COALESCE(dr.end_time, ds.end_time)) AS end_sec,
+					IF(ds.slot_duration > 0, ds.slot_duration, ?) * 60 AS slot_sec
+				FROM doctor_schedules ds
+				LEFT JOIN doctor_reschedules dr ON dr.doctor_schedule_id = ds.id AND dr.date = ?
+				WHERE ds.day = ? AND (dr.id IS NULL OR dr.status = 'changed')
+			) s
+		) w
+		INNER JOIN (
+			SELECT doc.id AS doctor_id, 0 AS free_from FROM doctors doc
+			UNION ALL
+			SELECT a.doctor_id, TIME_TO_SEC(a.appointment_time) + IF(ads.slot_duration > 0, ads.slot_duration, ?) * 60
+			FROM appointments a
+			INNER JOIN doctor_schedules ads ON ads.id = a.doctor_schedule_id
+			WHERE a.appointment_date = ? AND a.status = 'scheduled'
+		) c ON c.doctor_id = w.doctor_id
+	) f
+	WHERE f.slot < f.slots AND NOT EXISTS (
+		SELECT 1 FROM appointments a
+		INNER JOIN doctor_schedules ads ON ads.id = a.doctor_schedule_id
+		WHERE a.doctor_id = f.doctor_id AND a.appointment_date = ? AND a.status = 'scheduled'
+		AND TIME_TO_SEC(a.appointment_time) < f.start_sec + (f.slot + 1) * f.slot_sec
+		AND f.start_sec + f.slot * f.slot_sec < TIME_TO_SEC(a.appointment_time) + IF(ads.slot_duration > 0, ads.slot_duration, ?) * 60
+	)`
+
+// startedUntil is the second of the day up to which slots on date have started at now, a slot
+// starting at that second included. It is -1 on any other day than today, so no slot is dropped.
+func startedUntil(date, now time.Time) int {
+	if date.Format(constant.DateFormat) != now.Format(constant.DateFormat) {
+		return -1
+	}
+
+	return now.Hour()*3600 + now.Minute()*60 + now.Second()
+}
+
+// doctorFilterClause builds the conditions on the doctors table matching the filter at now
+func doctorFilterClause(filter *domain.DoctorFilter, now time.Time) ([]string, []interface{}) {
+	where := []string{}
+	args := []interface{}{}
+	if filter == nil {
+		return where, args
+	}
+
+	if filter.ServiceID != "" {
+		where = append(where, "d.service_id = ?")
+		args = append(args, filter.ServiceID)
+	}
+	if filter.Specialization != "" {
+		where = append(where, "d.specialization = ?")
+		args = append(args, filter.Specialization)
+	}
+	if filter.Name != "" {
+		where = append(where, "d.name LIKE ?")
+		args = append(args, "%"+likeEscaper.Replace(filter.Name)+"%")
+	}
+	if filter.Day != "" {
+		where = append(where, "EXISTS (SELECT 1 FROM doctor_schedules ds WHERE ds.doctor_id = d.id AND ds.day = ?)")
+		args = append(args, filter.Day)
+	}
+	if filter.AvailableOn != "" {
+		date, err := time.Parse(constant.DateFormat, filter.AvailableOn)
+		if err == nil {
+			day := date.Format(constant.DateFormat)
+			where = append(where, "d.id IN ("+freeSlotDoctorsQuery+")")
+			args = append(args,
+				startedUntil(date, now), constant.DefaultSlotDuration, day, date.Weekday().String(),
+				constant.DefaultSlotDuration, day,
+				day, constant.DefaultSlotDuration,
+			)
+		}
+	}
+
+	return where, args
+}
+
+// doctorOrderBy maps a sort option of the doctor list to its ORDER BY clause
+func doctorOrderBy(sort string) string {
+	switch sort {
+	case constant.DoctorSortNameDesc:
+		return "d.name DESC, d.id"
+	case constant.DoctorSortService:
+		return "s.name ASC, d.name ASC, d.id"
+	case constant.DoctorSortNewest:
+		return "d.created_at DESC, d.id"
+	default:
+		return "d.name ASC, d.id"
+	}
+}
+
 func (r *doctorRepository) Create(ctx context.Context, doctor *domain.Doctor) error {
 	query := `
 		INSERT INTO doctors (
-			id, name, service_id, description, specialization,
-			degree, experience, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, NOW(), NOW())`
+			id, user_id, name, slug, service_id, description, specialization,
+			degree, experience, languages, education, certifications, consultation_fee,
+			created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())`
 
 	doctor.ID = uuid.New()
 
-	_, err := r.db.ExecContext(ctx, query,
-		doctor.ID, doctor.Name, doctor.ServiceID, doctor.Description,
+	profile, err := marshalProfile(doctor)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		doctor.ID, uuidOrNull(doctor.UserID), doctor.Name, doctor.Slug, doctor.ServiceID, doctor.Description,
 		doctor.Specialization, doctor.Degree, doctor.Experience,
+		profile.languages, profile.education, profile.certifications, nullInt64(doctor.ConsultationFee),
 	)
 
-	return err
+	return mapDuplicateError(err)
 }
 
 func (r *doctorRepository) Update(ctx context.Context, doctor *domain.Doctor) error {
 	query := `
 		UPDATE doctors SET
-			name = ?, service_id = ?, description = ?, specialization = ?,
-			degree = ?, experience = ?, updated_at = NOW()
+			user_id = ?, name = ?, slug = ?, service_id = ?, description = ?, specialization = ?,
+			degree = ?, experience = ?, languages = ?, education = ?, certifications = ?,
+			consultation_fee = ?, updated_at = NOW()
 		WHERE id = ?`
 
+	profile, err := marshalProfile(doctor)
+	if err != nil {
+		return err
+	}
+
 	result, err := r.db.ExecContext(ctx, query,
-		doctor.Name, doctor.ServiceID, doctor.Description,
+		uuidOrNull(doctor.UserID), doctor.Name, doctor.Slug, doctor.ServiceID, doctor.Description,
 		doctor.Specialization, doctor.Degree, doctor.Experience,
+		profile.languages, profile.education, profile.certifications, nullInt64(doctor.ConsultationFee),
 		doctor.ID,
 	)
 	if err != nil {
-		return err
+		return mapDuplicateError(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -193,6 +358,26 @@ func (r *doctorRepository) Update(ctx context.Context, doctor *domain.Doctor) er
 	return nil
 }
 
+// UpdatePhoto sets the storage path of the profile photo, an empty path removes it
+func (r *doctorRepository) UpdatePhoto(ctx context.Context, id uuid.UUID, photoPath string) error {
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE doctors SET photo_path = ?, updated_at = NOW() WHERE id = ?", nullString(photoPath), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return constant.ErrDoctorNotFound
+	}
+
+	return nil
+}
+
 func (r *doctorRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	result, err := r.db.ExecContext(ctx, "DELETE FROM doctors WHERE id = ?", id)
 	if err != nil {
@@ -216,22 +401,47 @@ func (r *doctorRepository) CreateSchedule(ctx context.Context, schedule *domain.
 	query := `
 		INSERT INTO doctor_schedules (
 			id, doctor_id, day, start_time, end_time,
-			created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, NOW(), NOW())`
+			slot_duration, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())`
 
 	schedule.ID = uuid.New()
 
 	_, err := r.db.ExecContext(ctx, query,
 		schedule.ID, schedule.DoctorID, schedule.Day,
-		schedule.StartTime, schedule.EndTime,
+		schedule.StartTime, schedule.EndTime, schedule.SlotDuration,
 	)
 
 	return err
 }
 
+func (r *doctorRepository) GetScheduleByID(ctx context.Context, id uuid.UUID) (*domain.DoctorSchedule, error) {
+	query := `
+		SELECT id, doctor_id, day, start_time, end_time, slot_duration
+		FROM doctor_schedules
+		WHERE id = ?`
+
+	schedule := &domain.DoctorSchedule{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&schedule.ID,
+		&schedule.DoctorID,
+		&schedule.Day,
+		&schedule.StartTime,
+		&schedule.EndTime,
+		&schedule.SlotDuration,
+	)
+	if err == sql.ErrNoRows {
+		return nil, constant.ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
 func (r *doctorRepository) GetSchedulesByDoctorID(ctx context.Context, doctorID uuid.UUID) ([]domain.DoctorSchedule, error) {
 	query := `
-		SELECT id, doctor_id, day, start_time, end_time
+		SELECT id, doctor_id, day, start_time, end_time, slot_duration
 		FROM doctor_schedules
 		WHERE doctor_id = ?
 		ORDER BY CASE day
@@ -259,6 +469,7 @@ func (r *doctorRepository) GetSchedulesByDoctorID(ctx context.Context, doctorID
 			&schedule.Day,
 			&schedule.StartTime,
 			&schedule.EndTime,
+			&schedule.SlotDuration,
 		)
 		if err != nil {
 			return nil, err
@@ -274,15 +485,27 @@ func (r *doctorRepository) GetSchedulesByDoctorID(ctx context.Context, doctorID
 	return schedules, nil
 }
 
-func (r *doctorRepository) UpdateSchedule(ctx context.Context, schedule *domain.DoctorSchedule) error {
+// UpdateSchedule writes a schedule once the guard accepts its upcoming appointments
+func (r *doctorRepository) UpdateSchedule(ctx context.Context, schedule *domain.DoctorSchedule, guard domain.ScheduleGuard) error {
 	query := `
 		UPDATE doctor_schedules SET
-			day = ?, start_time = ?, end_time = ?, updated_at = NOW()
+			day = ?, start_time = ?, end_time = ?, slot_duration = ?,
+			updated_at = NOW()
 		WHERE id = ?`
 
-	result, err := r.db.ExecContext(ctx, query,
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := guardSchedule(ctx, tx, schedule.ID, guard); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, query,
 		schedule.Day, schedule.StartTime, schedule.EndTime,
-		schedule.ID,
+		schedule.SlotDuration, schedule.ID,
 	)
 	if err != nil {
 		return err
@@ -297,11 +520,22 @@ func (r *doctorRepository) UpdateSchedule(ctx context.Context, schedule *domain.
 		return fmt.Errorf("schedule not found")
 	}
 
-	return nil
+	return tx.Commit()
 }
 
-func (r *doctorRepository) DeleteSchedule(ctx context.Context, id uuid.UUID) error {
-	result, err := r.db.ExecContext(ctx, "DELETE FROM doctor_schedules WHERE id = ?", id)
+// DeleteSchedule deletes a schedule once the guard accepts its upcoming appointments
+func (r *doctorRepository) DeleteSchedule(ctx context.Context, id uuid.UUID, guard domain.ScheduleGuard) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := guardSchedule(ctx, tx, id, guard); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM doctor_schedules WHERE id = ?", id)
 	if err != nil {
 		return err
 	}
@@ -315,11 +549,42 @@ func (r *doctorRepository) DeleteSchedule(ctx context.Context, id uuid.UUID) err
 		return fmt.Errorf("schedule not found")
 	}
 
-	return nil
+	return tx.Commit()
+}
+
+// guardSchedule locks a schedule row the way booking an appointment does, so no appointment is
+// booked on the schedule until the transaction ends, and runs the guard on the schedule as stored
+// and what it holds
+func guardSchedule(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID, guard domain.ScheduleGuard) error {
+	current := &domain.DoctorSchedule{}
+	err := tx.QueryRowContext(ctx, `
+		SELECT id, doctor_id, day, start_time, end_time, slot_duration
+		FROM doctor_schedules WHERE id = ? FOR UPDATE`, scheduleID,
+	).Scan(&current.ID, &current.DoctorID, &current.Day, &current.StartTime, &current.EndTime, &current.SlotDuration)
+	if err == sql.ErrNoRows {
+		return constant.ErrScheduleNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	appointments, err := upcomingAppointments(ctx, tx, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	reschedules, err := reschedulesBySchedule(ctx, tx, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	return guard(current, appointments, reschedules)
 }
 
 // Reschedule operations
-func (r *doctorRepository) CreateReschedule(ctx context.Context, reschedule *domain.DoctorReschedule) error {
+
+// CreateReschedule writes a reschedule once the guard accepts the upcoming appointments of its schedule
+func (r *doctorRepository) CreateReschedule(ctx context.Context, reschedule *domain.DoctorReschedule, guard domain.ScheduleGuard) error {
 	query := `
 		INSERT INTO doctor_reschedules (
 			id, doctor_schedule_id, date, start_time, end_time,
@@ -328,26 +593,76 @@ func (r *doctorRepository) CreateReschedule(ctx context.Context, reschedule *dom
 
 	reschedule.ID = uuid.New()
 
-	_, err := r.db.ExecContext(ctx, query,
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := guardSchedule(ctx, tx, reschedule.DoctorScheduleID, guard); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query,
 		reschedule.ID, reschedule.DoctorScheduleID, reschedule.Date,
 		reschedule.StartTime, reschedule.EndTime, reschedule.Status,
 		reschedule.Description,
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return tx.Commit()
+}
+
+func (r *doctorRepository) GetRescheduleByID(ctx context.Context, id uuid.UUID) (*domain.DoctorReschedule, error) {
+	query := `
+		SELECT 
+			dr.id, dr.doctor_schedule_id, dr.date, dr.start_time,
+			dr.end_time, dr.status, dr.description,
+			ds.id, ds.doctor_id, ds.day, ds.start_time, ds.end_time, ds.slot_duration
+		FROM doctor_reschedules dr
+		INNER JOIN doctor_schedules ds ON dr.doctor_schedule_id = ds.id
+		WHERE dr.id = ?`
+
+	reschedule := &domain.DoctorReschedule{}
+	schedule := &domain.DoctorSchedule{}
+	var description sql.NullString
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&reschedule.ID, &reschedule.DoctorScheduleID, &reschedule.Date,
+		&reschedule.StartTime, &reschedule.EndTime, &reschedule.Status,
+		&description,
+		&schedule.ID, &schedule.DoctorID, &schedule.Day,
+		&schedule.StartTime, &schedule.EndTime, &schedule.SlotDuration,
+	)
+	if err == sql.ErrNoRows {
+		return nil, constant.ErrRescheduleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	reschedule.Description = description.String
+	reschedule.Schedule = schedule
+	return reschedule, nil
 }
 
 func (r *doctorRepository) GetReschedulesByScheduleID(ctx context.Context, scheduleID uuid.UUID) ([]domain.DoctorReschedule, error) {
+	return reschedulesBySchedule(ctx, r.db, scheduleID)
+}
+
+func reschedulesBySchedule(ctx context.Context, q queryer, scheduleID uuid.UUID) ([]domain.DoctorReschedule, error) {
 	query := `
 		SELECT 
 			dr.id, dr.doctor_schedule_id, dr.date, dr.start_time,
 			dr.end_time, dr.status, dr.description,
-			ds.id, ds.doctor_id, ds.day, ds.start_time, ds.end_time
+			ds.id, ds.doctor_id, ds.day, ds.start_time, ds.end_time, ds.slot_duration
 		FROM doctor_reschedules dr
 		LEFT JOIN doctor_schedules ds ON dr.doctor_schedule_id = ds.id
 		WHERE dr.doctor_schedule_id = ?`
 
-	rows, err := r.db.QueryContext(ctx, query, scheduleID)
+	rows, err := q.QueryContext(ctx, query, scheduleID)
 	if err != nil {
 		return nil, err
 	}
@@ -364,7 +679,7 @@ func (r *doctorRepository) GetReschedulesByScheduleID(ctx context.Context, sched
 			&reschedule.StartTime, &reschedule.EndTime, &reschedule.Status,
 			&reschedule.Description,
 			&schedule.ID, &schedule.DoctorID, &schedule.Day,
-			&schedule.StartTime, &schedule.EndTime,
+			&schedule.StartTime, &schedule.EndTime, &schedule.SlotDuration,
 		)
 		if err != nil {
 			return nil, err
@@ -381,14 +696,25 @@ func (r *doctorRepository) GetReschedulesByScheduleID(ctx context.Context, sched
 	return reschedules, nil
 }
 
-func (r *doctorRepository) UpdateReschedule(ctx context.Context, reschedule *domain.DoctorReschedule) error {
+// UpdateReschedule writes a reschedule once the guard accepts the upcoming appointments of its schedule
+func (r *doctorRepository) UpdateReschedule(ctx context.Context, reschedule *domain.DoctorReschedule, guard domain.ScheduleGuard) error {
 	query := `
 		UPDATE doctor_reschedules SET
 			date = ?, start_time = ?, end_time = ?,
 			status = ?, description = ?, updated_at = NOW()
 		WHERE id = ?`
 
-	result, err := r.db.ExecContext(ctx, query,
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := guardSchedule(ctx, tx, reschedule.DoctorScheduleID, guard); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, query,
 		reschedule.Date, reschedule.StartTime, reschedule.EndTime,
 		reschedule.Status, reschedule.Description, reschedule.ID,
 	)
@@ -405,11 +731,22 @@ func (r *doctorRepository) UpdateReschedule(ctx context.Context, reschedule *dom
 		return fmt.Errorf("reschedule not found")
 	}
 
-	return nil
+	return tx.Commit()
 }
 
-func (r *doctorRepository) DeleteReschedule(ctx context.Context, id uuid.UUID) error {
-	result, err := r.db.ExecContext(ctx, "DELETE FROM doctor_reschedules WHERE id = ?", id)
+// DeleteReschedule deletes a reschedule once the guard accepts the upcoming appointments of its schedule
+func (r *doctorRepository) DeleteReschedule(ctx context.Context, reschedule *domain.DoctorReschedule, guard domain.ScheduleGuard) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := guardSchedule(ctx, tx, reschedule.DoctorScheduleID, guard); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM doctor_reschedules WHERE id = ?", reschedule.ID)
 	if err != nil {
 		return err
 	}
@@ -423,5 +760,237 @@ func (r *doctorRepository) DeleteReschedule(ctx context.Context, id uuid.UUID) e
 		return fmt.Errorf("reschedule not found")
 	}
 
-	return nil
+	return tx.Commit()
+}
+
+func (r *doctorRepository) GetReschedulesByDoctorID(ctx context.Context, doctorID uuid.UUID, from, to time.Time) ([]domain.DoctorReschedule, error) {
+	query := `
+		SELECT 
+			dr.id, dr.doctor_schedule_id, dr.date, dr.start_time,
+			dr.end_time, dr.status, dr.description
+		FROM doctor_reschedules dr
+		INNER JOIN doctor_schedules ds ON dr.doctor_schedule_id = ds.id
+		WHERE ds.doctor_id = ? AND dr.date BETWEEN ? AND ?
+		ORDER BY dr.date ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, doctorID,
+		from.Format(constant.DateFormat), to.Format(constant.DateFormat))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reschedules []domain.DoctorReschedule
+	for rows.Next() {
+		var reschedule domain.DoctorReschedule
+		var description sql.NullString
+
+		err := rows.Scan(
+			&reschedule.ID, &reschedule.DoctorScheduleID, &reschedule.Date,
+			&reschedule.StartTime, &reschedule.EndTime, &reschedule.Status,
+			&description,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		reschedule.Description = description.String
+		reschedules = append(reschedules, reschedule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reschedules, nil
+}
+
+// Availability operations
+func (r *doctorRepository) GetBookedSlots(ctx context.Context, doctorID uuid.UUID, from, to time.Time) ([]domain.BookedSlot, error) {
+	query := `
+		SELECT a.appointment_date, a.appointment_time, ds.slot_duration
+		FROM appointments a
+		INNER JOIN doctor_schedules ds ON ds.id = a.doctor_schedule_id
+		WHERE a.doctor_id = ? AND a.status = 'scheduled'
+		AND a.appointment_date BETWEEN ? AND ?`
+
+	rows, err := r.db.QueryContext(ctx, query, doctorID,
+		from.Format(constant.DateFormat), to.Format(constant.DateFormat))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var slots []domain.BookedSlot
+	for rows.Next() {
+		var slot domain.BookedSlot
+		if err := rows.Scan(&slot.Date, &slot.Time, &slot.Duration); err != nil {
+			return nil, err
+		}
+		slots = append(slots, slot)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return slots, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanDoctor scans a row selected with doctorColumns
+func scanDoctor(row rowScanner) (*domain.Doctor, error) {
+	doctor := &domain.Doctor{}
+	service := &domain.Service{}
+	var userID uuid.NullUUID
+	var photoPath, description, serviceDescription sql.NullString
+	var languages, education, certifications []byte
+	var consultationFee sql.NullInt64
+
+	err := row.Scan(
+		&doctor.ID, &userID, &doctor.Name, &doctor.Slug, &photoPath, &doctor.ServiceID, &description,
+		&doctor.Specialization, &doctor.Degree, &doctor.Experience,
+		&languages, &education, &certifications, &consultationFee,
+		&service.ID, &service.Name, &service.Slug, &serviceDescription,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	doctor.UserID = nullableUUID(userID)
+	doctor.PhotoPath = photoPath.String
+	doctor.Description = description.String
+	service.Description = serviceDescription.String
+	doctor.Service = service
+
+	if doctor.Languages, err = unmarshalList[string](languages); err != nil {
+		return nil, err
+	}
+	if doctor.Education, err = unmarshalList[domain.Education](education); err != nil {
+		return nil, err
+	}
+	if doctor.Certifications, err = unmarshalList[domain.Certification](certifications); err != nil {
+		return nil, err
+	}
+
+	if consultationFee.Valid {
+		doctor.ConsultationFee = &consultationFee.Int64
+	}
+
+	return doctor, nil
+}
+
+// doctorProfile holds the JSON encoded profile lists of a doctor
+type doctorProfile struct {
+	languages      []byte
+	education      []byte
+	certifications []byte
+}
+
+func marshalProfile(doctor *domain.Doctor) (*doctorProfile, error) {
+	profile := &doctorProfile{}
+	var err error
+
+	if profile.languages, err = json.Marshal(orEmpty(doctor.Languages)); err != nil {
+		return nil, err
+	}
+	if profile.education, err = json.Marshal(orEmpty(doctor.Education)); err != nil {
+		return nil, err
+	}
+	if profile.certifications, err = json.Marshal(orEmpty(doctor.Certifications)); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// unmarshalList decodes a JSON array column, a NULL column decodes into an empty list
+func unmarshalList[T any](value []byte) ([]T, error) {
+	values := []T{}
+	if len(value) == 0 {
+		return values, nil
+	}
+	if err := json.Unmarshal(value, &values); err != nil {
+		return nil, err
+	}
+	return orEmpty(values), nil
+}
+
+// orEmpty stores a missing list as an empty JSON array instead of null
+func orEmpty[T any](values []T) []T {
+	if values == nil {
+		return []T{}
+	}
+	return values
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func nullInt64(value *int64) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *value, Valid: true}
+}
+
+// upcomingAppointments gets the scheduled appointments booked on a schedule from today on
+func upcomingAppointments(ctx context.Context, q queryer, scheduleID uuid.UUID) ([]domain.ScheduledAppointment, error) {
+	query := `
+		SELECT id, appointment_date, appointment_time
+		FROM appointments
+		WHERE doctor_schedule_id = ? AND status = 'scheduled'
+		AND appointment_date >= CURDATE()
+		ORDER BY appointment_date ASC, appointment_time ASC`
+
+	rows, err := q.QueryContext(ctx, query, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	appointments := []domain.ScheduledAppointment{}
+	for rows.Next() {
+		var appointment domain.ScheduledAppointment
+		if err := rows.Scan(&appointment.ID, &appointment.Date, &appointment.Time); err != nil {
+			return nil, err
+		}
+		appointments = append(appointments, appointment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return appointments, nil
+}
+
+func uuidOrNull(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func nullableUUID(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	return &id.UUID
+}
+
+// mapDuplicateError converts a violation of the unique slug or doctor account index into its domain error
+func mapDuplicateError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+		if strings.Contains(mysqlErr.Message, slugUniqueKey) {
+			return constant.ErrDoctorSlugTaken
+		}
+		return constant.ErrDoctorAccountTaken
+	}
+	return err
 }
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/domain"
+)
+
+// testMySQLDSNEnv points the repository tests to a migrated local MySQL database,
+// e.g. root:secret@tcp(127.0.0.1:3306)/hospital_cms_test?parseTime=true
+const testMySQLDSNEnv = "APEXA_TEST_MYSQL_DSN"
+
+type scheduleFixture struct {
+	userID     uuid.UUID
+	doctorID   uuid.UUID
+	scheduleID uuid.UUID
+}
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv(testMySQLDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set, skipping MySQL repository test", testMySQLDSNEnv)
+	}
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if err := db.Ping(); err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// seedScheduleFixture creates a doctor holding four 30 minute slots on Monday from 09:00 to 11:00
+func seedScheduleFixture(t *testing.T, db *sql.DB) scheduleFixture {
+	t.Helper()
+
+	ctx := context.Background()
+	fixture := scheduleFixture{
+		userID:     uuid.New(),
+		doctorID:   uuid.New(),
+		scheduleID: uuid.New(),
+	}
+	serviceID := uuid.New()
+
+	statements := []struct {
+		query string
+		args  []interface{}
+	}{
+		{
+			query: "INSERT INTO users (id, email, password, name, status) VALUES (?, ?, 'secret', 'Availability Test', 'active')",
+			args:  []interface{}{fixture.userID, fixture.userID.String() + "@example.com"},
+		},
+		{
+			query: "INSERT INTO services (id, name, slug) VALUES (?, 'Availability Test', ?)",
+			args:  []interface{}{serviceID, serviceID.String()},
+		},
+		{
+			query: "INSERT INTO doctors (id, name, slug, service_id, specialization, degree, experience) VALUES (?, 'Availability Test', ?, ?, 'General', 'MD', '1 year')",
+			args:  []interface{}{fixture.doctorID, fixture.doctorID.String(), serviceID},
+		},
+		{
+			query: "INSERT INTO doctor_schedules (id, doctor_id, day, start_time, end_time, slot_duration) VALUES (?, ?, 'Monday', '09:00:00', '11:00:00', 30)",
+			args:  []interface{}{fixture.scheduleID, fixture.doctorID},
+		},
+	}
+	for _, stmt := range statements {
+		if _, err := db.ExecContext(ctx, stmt.query, stmt.args...); err != nil {
+			t.Fatalf("failed to seed fixture: %v", err)
+		}
+	}
+
+	t.Cleanup(func() {
+		// Doctors, schedules, reschedules and appointments are removed through ON DELETE CASCADE
+		db.ExecContext(ctx, "DELETE FROM services WHERE id = ?", serviceID)
+		db.ExecContext(ctx, "DELETE FROM users WHERE id = ?", fixture.userID)
+	})
+
+	return fixture
+}
+
+func TestStartedUntil(t *testing.T) {
+	date := time.Date(2030, 1, 7, 0, 0, 0, 0, time.Local)
+
+	if got := startedUntil(date, time.Date(2030, 1, 6, 23, 59, 0, 0, time.Local)); got != -1 {
+		t.Errorf("startedUntil() the day before = %d, want -1", got)
+	}
+	if got := startedUntil(date, time.Date(2030, 1, 7, 10, 15, 30, 0, time.Local)); got != 10*3600+15*60+30 {
+		t.Errorf("startedUntil() the same day = %d, want %d", got, 10*3600+15*60+30)
+	}
+}
+
+func TestDoctorFilterClause_AvailableOn(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	// A Monday, the day of the fixture schedule
+	date := time.Date(2030, 1, 7, 0, 0, 0, 0, time.Local)
+	dayBefore := date.AddDate(0, 0, -1).Add(12 * time.Hour)
+
+	tests := []struct {
+		name       string
+		reschedule string
+		booked     []string
+		now        time.Time
+		want       bool
+	}{
+		{
+			name:   "Free slots left",
+			booked: []string{"09:00", "09:30", "10:00"},
+			now:    dayBefore,
+			want:   true,
+		},
+		{
+			name:   "Every slot booked",
+			booked: []string{"09:00", "09:30", "10:00", "10:30"},
+			now:    dayBefore,
+			want:   false,
+		},
+		{
+			name:   "A booking off the slot grid takes both slots it covers",
+			booked: []string{"09:00", "09:45", "10:30"},
+			now:    dayBefore,
+			want:   false,
+		},
+		{
+			name:   "Free slot after bookings off the slot grid",
+			booked: []string{"09:15", "10:00"},
+			now:    dayBefore,
+			want:   true,
+		},
+		{
+			name:   "Today only the slots still to start are left",
+			booked: []string{"10:30"},
+			now:    date.Add(10*time.Hour + 15*time.Minute),
+			want:   false,
+		},
+		{
+			name:   "A slot starting now has started",
+			booked: []string{},
+			now:    date.Add(10*time.Hour + 30*time.Minute),
+			want:   false,
+		},
+		{
+			name:       "Bookings outside a changed window do not count",
+			reschedule: "INSERT INTO doctor_reschedules (id, doctor_schedule_id, date, start_time, end_time, status) VALUES (?, ?, ?, '13:00:00', '14:00:00', 'changed')",
+			booked:     []string{"09:00", "09:30", "13:00"},
+			now:        dayBefore,
+			want:       true,
+		},
+		{
+			name:       "Cancelled day",
+			reschedule: "INSERT INTO doctor_reschedules (id, doctor_schedule_id, date, start_time, end_time, status) VALUES (?, ?, ?, '09:00:00', '11:00:00', 'cancelled')",
+			now:        dayBefore,
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := seedScheduleFixture(t, db)
+
+			if tt.reschedule != "" {
+				if _, err := db.ExecContext(ctx, tt.reschedule, uuid.New(), fixture.scheduleID, date.Format(constant.DateFormat)); err != nil {
+					t.Fatalf("failed to seed reschedule: %v", err)
+				}
+			}
+			for _, slot := range tt.booked {
+				_, err := db.ExecContext(ctx,
+					"INSERT INTO appointments (id, user_id, doctor_id, doctor_schedule_id, appointment_date, appointment_time, status, reason) VALUES (?, ?, ?, ?, ?, ?, 'scheduled', 'Availability test')",
+					uuid.New(), fixture.userID, fixture.doctorID, fixture.scheduleID, date.Format(constant.DateFormat), slot)
+				if err != nil {
+					t.Fatalf("failed to seed appointment: %v", err)
+				}
+			}
+
+			where, args := doctorFilterClause(&domain.DoctorFilter{AvailableOn: date.Format(constant.DateFormat)}, tt.now)
+			query := "SELECT COUNT(*) FROM doctors d WHERE d.id = ? AND " + strings.Join(where, " AND ")
+
+			var count int
+			if err := db.QueryRowContext(ctx, query, append([]interface{}{fixture.doctorID}, args...)...).Scan(&count); err != nil {
+				t.Fatalf("available_on query failed: %v", err)
+			}
+			if got := count == 1; got != tt.want {
+				t.Errorf("doctor listed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoctorRepository_DeleteScheduleGuard(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	fixture := seedScheduleFixture(t, db)
+	repo := NewDoctorRepository(db)
+
+	date := time.Now().AddDate(0, 0, 7).Format(constant.DateFormat)
+	_, err := db.ExecContext(ctx,
+		"INSERT INTO appointments (id, user_id, doctor_id, doctor_schedule_id, appointment_date, appointment_time, status, reason) VALUES (?, ?, ?, ?, ?, '09:00', 'scheduled', 'Guard test')",
+		uuid.New(), fixture.userID, fixture.doctorID, fixture.scheduleID, date)
+	if err != nil {
+		t.Fatalf("failed to seed appointment: %v", err)
+	}
+
+	var seen []domain.ScheduledAppointment
+	var locked *domain.DoctorSchedule
+	refuse := func(current *domain.DoctorSchedule, appointments []domain.ScheduledAppointment, _ []domain.DoctorReschedule) error {
+		locked, seen = current, appointments
+		return &domain.AppointmentsAffectedError{Appointments: appointments}
+	}
+	if err := repo.DeleteSchedule(ctx, fixture.scheduleID, refuse); !errors.Is(err, constant.ErrAppointmentsAffected) {
+		t.Fatalf("DeleteSchedule() error = %v, want %v", err, constant.ErrAppointmentsAffected)
+	}
+	if len(seen) != 1 {
+		t.Errorf("guard saw %d appointments, want 1", len(seen))
+	}
+	if locked == nil || locked.ID != fixture.scheduleID || locked.SlotDuration != 30 {
+		t.Errorf("guard saw schedule %+v, want the stored schedule", locked)
+	}
+	if _, err := repo.GetScheduleByID(ctx, fixture.scheduleID); err != nil {
+		t.Errorf("GetScheduleByID() after a refused delete error = %v, want the schedule kept", err)
+	}
+
+	if err := repo.DeleteSchedule(ctx, uuid.New(), refuse); !errors.Is(err, constant.ErrScheduleNotFound) {
+		t.Errorf("DeleteSchedule() unknown schedule error = %v, want %v", err, constant.ErrScheduleNotFound)
+	}
+}
@@ -1,36 +1,62 @@
 package router
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
 	"github.com/gomajido/hospital-cms-golang/internal/middleware"
 	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/handler"
 )
 
+// publicRateLimit throttles anonymous reads of the doctors and their availability
+var publicRateLimit = middleware.RateLimitPolicy{Name: "doctors:public", Limit: 120, Window: time.Minute}
+
 // RegisterDoctorRoutes registers all doctor routes
-func RegisterDoctorRoutes(router fiber.Router, h *handler.DoctorHandler, authMiddleware *middleware.AuthMiddleware) {
+func RegisterDoctorRoutes(router fiber.Router, h *handler.DoctorHandler, authMiddleware *middleware.AuthMiddleware, rateLimiter *middleware.RateLimiter) {
 	doctors := router.Group("/doctors")
 
 	// Public routes
-	doctors.Get("", h.List)
-	doctors.Get("/:id", h.GetByID)
+	limitPublic := rateLimiter.Limit(publicRateLimit)
+	doctors.Get("", limitPublic, h.List)
+	doctors.Get("/slug/:slug", limitPublic, h.GetBySlug)
+	doctors.Get("/:id", limitPublic, h.GetByID)
+	doctors.Get("/:id/availability", limitPublic, h.GetAvailability)
 
-	// Protected routes for admins only
+	// Protected routes
 	doctors.Use(authMiddleware.Protected())
-	doctors.Use(authMiddleware.HasAbility("admin"))
-	doctors.Post("", h.Create)
-	doctors.Put("/:id", h.Update)
-	doctors.Delete("/:id", h.Delete)
+	writeDoctor := authMiddleware.HasAbility(constant.PERMISSION_DOCTOR_WRITE)
+	doctors.Post("", writeDoctor, h.Create)
+	doctors.Put("/:id", writeDoctor, h.Update)
+	doctors.Delete("/:id", writeDoctor, h.Delete)
+	doctors.Put("/:id/photo", writeDoctor, h.UploadPhoto)
+	doctors.Delete("/:id/photo", writeDoctor, h.DeletePhoto)
 
 	// Schedule routes
-	doctors.Post("/:id/schedules", h.CreateSchedule)
-	doctors.Get("/:id/schedules", h.GetSchedules)
-	doctors.Put("/schedules/:id", h.UpdateSchedule)
-	doctors.Delete("/schedules/:id", h.DeleteSchedule)
+	manageSchedule := authMiddleware.HasAbility(constant.PERMISSION_DOCTOR_SCHEDULE_MANAGE)
+	doctors.Post("/:id/schedules", manageSchedule, h.CreateSchedule)
+	doctors.Get("/:id/schedules", manageSchedule, h.GetSchedules)
+	doctors.Put("/schedules/:id", manageSchedule, h.UpdateSchedule)
+	doctors.Delete("/schedules/:id", manageSchedule, h.DeleteSchedule)
 
 	// Reschedule routes
-	doctors.Post("/schedules/:id/reschedules", h.CreateReschedule)
-	doctors.Get("/schedules/:id/reschedules", h.GetReschedules)
-	doctors.Put("/reschedules/:id", h.UpdateReschedule)
-	doctors.Delete("/reschedules/:id", h.DeleteReschedule)
+	doctors.Post("/schedules/:id/reschedules", manageSchedule, h.CreateReschedule)
+	doctors.Get("/schedules/:id/reschedules", manageSchedule, h.GetReschedules)
+	doctors.Put("/reschedules/:id", manageSchedule, h.UpdateReschedule)
+	doctors.Delete("/reschedules/:id", manageSchedule, h.DeleteReschedule)
+
+	// Doctor portal, the doctor linked to the current user manages their own schedules
+	me := router.Group("/doctor/me")
+	protected := authMiddleware.Protected()
+	asDoctor := authMiddleware.HasAbility(constant.PERMISSION_DOCTOR_PORTAL)
+	me.Get("", protected, asDoctor, h.GetMyProfile)
+	me.Get("/schedules", protected, asDoctor, h.GetMySchedules)
+	me.Post("/schedules", protected, asDoctor, h.CreateMySchedule)
+	me.Put("/schedules/:id", protected, asDoctor, h.UpdateMySchedule)
+	me.Delete("/schedules/:id", protected, asDoctor, h.DeleteMySchedule)
+	me.Get("/schedules/:id/reschedules", protected, asDoctor, h.GetMyReschedules)
+	me.Post("/schedules/:id/reschedules", protected, asDoctor, h.CreateMyReschedule)
+	me.Put("/reschedules/:id", protected, asDoctor, h.UpdateMyReschedule)
+	me.Delete("/reschedules/:id", protected, asDoctor, h.DeleteMyReschedule)
 }
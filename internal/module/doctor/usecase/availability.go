@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/domain"
+	"github.com/google/uuid"
+)
+
+func (u *doctorUsecase) GetAvailability(ctx context.Context, doctorID uuid.UUID, from, to time.Time) ([]domain.DailyAvailability, error) {
+	doctor, err := u.doctorRepo.GetByID(ctx, doctorID)
+	if err != nil {
+		return nil, err
+	}
+
+	reschedules, err := u.doctorRepo.GetReschedulesByDoctorID(ctx, doctorID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	booked, err := u.doctorRepo.GetBookedSlots(ctx, doctorID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildAvailability(doctor.Schedules, reschedules, booked, from, to, time.Now()), nil
+}
+
+func (u *doctorUsecase) IsSlotAvailable(ctx context.Context, doctorID, scheduleID uuid.UUID, date time.Time, startTime string) (bool, error) {
+	days, err := u.GetAvailability(ctx, doctorID, date, date)
+	if err != nil {
+		return false, err
+	}
+
+	startTime = normalizeSlotTime(startTime)
+	for _, day := range days {
+		for _, slot := range day.Slots {
+			if slot.ScheduleID == scheduleID && slot.StartTime == startTime {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// buildAvailability expands the weekly schedules into free slots for every date between from and to.
+// Cancelled reschedules remove the schedule for that date, changed reschedules replace its time window.
+// Slots overlapping a booked appointment or starting before now are left out.
+func buildAvailability(schedules []domain.DoctorSchedule, reschedules []domain.DoctorReschedule, booked []domain.BookedSlot, from, to, now time.Time) []domain.DailyAvailability {
+	overrides := make(map[string]domain.DoctorReschedule)
+	for _, reschedule := range reschedules {
+		overrides[overrideKey(reschedule.DoctorScheduleID, reschedule.Date)] = reschedule
+	}
+
+	// Bookings are matched by interval rather than start time, so appointments booked on another
+	// grid (e.g. before the slot duration changed) still block every slot they overlap
+	taken := make(map[string][]domain.TimeSlot)
+	for _, slot := range booked {
+		dateStr := slot.Date.Format(constant.DateFormat)
+		taken[dateStr] = append(taken[dateStr], bookedInterval(slot))
+	}
+
+	var days []domain.DailyAvailability
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		dateStr := date.Format(constant.DateFormat)
+		day := domain.DailyAvailability{
+			Date:  dateStr,
+			Day:   date.Weekday().String(),
+			Slots: []domain.TimeSlot{},
+		}
+
+		for _, schedule := range schedules {
+			if schedule.Day != day.Day {
+				continue
+			}
+
+			startTime, endTime := schedule.StartTime, schedule.EndTime
+			if override, ok := overrides[overrideKey(schedule.ID, date)]; ok {
+				if override.Status == constant.RescheduleStatusCancelled {
+					continue
+				}
+				startTime, endTime = override.StartTime, override.EndTime
+			}
+
+			for _, slot := range expandSlots(schedule.ID, date, startTime, endTime, schedule.SlotDuration) {
+				if isTaken(taken[dateStr], slot) {
+					continue
+				}
+				if !slotStart(date, slot.StartTime).After(now) {
+					continue
+				}
+				day.Slots = append(day.Slots, slot)
+			}
+		}
+
+		sort.Slice(day.Slots, func(i, j int) bool {
+			return day.Slots[i].StartTime < day.Slots[j].StartTime
+		})
+		days = append(days, day)
+	}
+
+	return days
+}
+
+// expandSlots splits a time window into consecutive slots, dropping a trailing slot that would not fit
+func expandSlots(scheduleID uuid.UUID, date time.Time, startTime, endTime string, slotDuration int) []domain.TimeSlot {
+	if slotDuration <= 0 {
+		slotDuration = constant.DefaultSlotDuration
+	}
+
+	start, err := time.Parse(constant.ClockTimeFormat, startTime)
+	if err != nil {
+		return nil
+	}
+	end, err := time.Parse(constant.ClockTimeFormat, endTime)
+	if err != nil {
+		return nil
+	}
+
+	duration := time.Duration(slotDuration) * time.Minute
+
+	var slots []domain.TimeSlot
+	for slot := start; !slot.Add(duration).After(end); slot = slot.Add(duration) {
+		slots = append(slots, domain.TimeSlot{
+			ScheduleID: scheduleID,
+			StartTime:  slot.Format(constant.SlotTimeFormat),
+			EndTime:    slot.Add(duration).Format(constant.SlotTimeFormat),
+		})
+	}
+
+	return slots
+}
+
+// bookedInterval is the time window an appointment occupies, from its start for one slot of its schedule
+func bookedInterval(slot domain.BookedSlot) domain.TimeSlot {
+	duration := slot.Duration
+	if duration <= 0 {
+		duration = constant.DefaultSlotDuration
+	}
+
+	startTime := normalizeSlotTime(slot.Time)
+	start, err := time.Parse(constant.SlotTimeFormat, startTime)
+	if err != nil {
+		return domain.TimeSlot{StartTime: startTime, EndTime: startTime}
+	}
+
+	end := start.Add(time.Duration(duration) * time.Minute)
+	endTime := end.Format(constant.SlotTimeFormat)
+	if end.Day() != start.Day() {
+		// Running past midnight, the booking holds the rest of the day
+		endTime = "24:00"
+	}
+
+	return domain.TimeSlot{StartTime: startTime, EndTime: endTime}
+}
+
+// isTaken reports whether a slot overlaps any of the booked intervals of its date
+func isTaken(booked []domain.TimeSlot, slot domain.TimeSlot) bool {
+	for _, interval := range booked {
+		if overlaps(slot.StartTime, slot.EndTime, interval.StartTime, interval.EndTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeSlotTime converts a HH:MM:SS database time into the HH:MM slot format
+func normalizeSlotTime(value string) string {
+	if parts := strings.Split(value, ":"); len(parts) >= 2 {
+		return parts[0] + ":" + parts[1]
+	}
+	return value
+}
+
+func slotStart(date time.Time, slotTime string) time.Time {
+	clock, _ := time.Parse(constant.SlotTimeFormat, slotTime)
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, time.Local)
+}
+
+func overrideKey(scheduleID uuid.UUID, date time.Time) string {
+	return scheduleID.String() + " " + date.Format(constant.DateFormat)
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/domain"
+	"github.com/google/uuid"
+)
+
+func slotTimes(slots []domain.TimeSlot) []string {
+	times := []string{}
+	for _, slot := range slots {
+		times = append(times, slot.StartTime)
+	}
+	return times
+}
+
+func TestBuildAvailability(t *testing.T) {
+	mondayID := uuid.New()
+	schedules := []domain.DoctorSchedule{
+		{ID: mondayID, Day: "Monday", StartTime: "09:00:00", EndTime: "11:00:00", SlotDuration: 30},
+	}
+	// 2030-01-07 is a Monday
+	monday := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2029, 12, 31, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		reschedules []domain.DoctorReschedule
+		booked      []domain.BookedSlot
+		now         time.Time
+		want        []string
+	}{
+		{
+			name: "Regular schedule",
+			now:  now,
+			want: []string{"09:00", "09:30", "10:00", "10:30"},
+		},
+		{
+			name: "Booked slots are excluded",
+			booked: []domain.BookedSlot{
+				{Date: monday, Time: "09:30:00", Duration: 30},
+				{Date: monday, Time: "10:30:00", Duration: 30},
+			},
+			now:  now,
+			want: []string{"09:00", "10:00"},
+		},
+		{
+			name: "Bookings off the slot grid exclude every slot they overlap",
+			booked: []domain.BookedSlot{
+				// Booked when the schedule still used 45 minute slots
+				{Date: monday, Time: "09:00:00", Duration: 45},
+				{Date: monday, Time: "10:15:00", Duration: 15},
+			},
+			now:  now,
+			want: []string{"10:30"},
+		},
+		{
+			name: "Booking ending on a slot boundary keeps the next slot",
+			booked: []domain.BookedSlot{
+				{Date: monday, Time: "09:00:00", Duration: 60},
+				{Date: monday, Time: "10:30:00", Duration: 10},
+			},
+			now:  now,
+			want: []string{"10:00"},
+		},
+		{
+			name: "Cancelled reschedule removes the day",
+			reschedules: []domain.DoctorReschedule{
+				{DoctorScheduleID: mondayID, Date: monday, StartTime: "09:00:00", EndTime: "11:00:00", Status: constant.RescheduleStatusCancelled},
+			},
+			now:  now,
+			want: []string{},
+		},
+		{
+			name: "Changed reschedule replaces the time window",
+			reschedules: []domain.DoctorReschedule{
+				{DoctorScheduleID: mondayID, Date: monday, StartTime: "13:00:00", EndTime: "14:15:00", Status: constant.RescheduleStatusChanged},
+			},
+			now:  now,
+			want: []string{"13:00", "13:30"},
+		},
+		{
+			name: "Past slots are excluded",
+			now:  time.Date(2030, 1, 7, 10, 0, 0, 0, time.Local),
+			want: []string{"10:30"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days := buildAvailability(schedules, tt.reschedules, tt.booked, monday, monday, tt.now)
+			if len(days) != 1 {
+				t.Fatalf("buildAvailability() returned %d days, want 1", len(days))
+			}
+			if got := slotTimes(days[0].Slots); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("buildAvailability() slots = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildAvailabilityRange(t *testing.T) {
+	schedules := []domain.DoctorSchedule{
+		{ID: uuid.New(), Day: "Monday", StartTime: "09:00:00", EndTime: "10:00:00", SlotDuration: 60},
+		{ID: uuid.New(), Day: "Wednesday", StartTime: "14:00:00", EndTime: "15:00:00"},
+	}
+	from := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2030, 1, 9, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2029, 12, 31, 0, 0, 0, 0, time.Local)
+
+	days := buildAvailability(schedules, nil, nil, from, to, now)
+
+	want := map[string][]string{
+		"2030-01-07": {"09:00"},
+		"2030-01-08": {},
+		"2030-01-09": {"14:00", "14:30"},
+	}
+	if len(days) != len(want) {
+		t.Fatalf("buildAvailability() returned %d days, want %d", len(days), len(want))
+	}
+	for _, day := range days {
+		if got := slotTimes(day.Slots); !reflect.DeepEqual(got, want[day.Date]) {
+			t.Errorf("buildAvailability() %s slots = %v, want %v", day.Date, got, want[day.Date])
+		}
+	}
+}
+
+func TestExpandSlots(t *testing.T) {
+	tests := []struct {
+		name         string
+		startTime    string
+		endTime      string
+		slotDuration int
+		want         []string
+	}{
+		{name: "Trailing partial slot is dropped", startTime: "08:00:00", endTime: "09:50:00", slotDuration: 45, want: []string{"08:00", "08:45"}},
+		{name: "Default duration", startTime: "08:00:00", endTime: "09:00:00", slotDuration: 0, want: []string{"08:00", "08:30"}},
+		{name: "Invalid time", startTime: "8am", endTime: "09:00:00", slotDuration: 30, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slotTimes(expandSlots(uuid.New(), time.Now(), tt.startTime, tt.endTime, tt.slotDuration))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandSlots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
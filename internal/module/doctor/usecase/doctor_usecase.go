@@ -2,39 +2,95 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/gosimple/slug"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/constant"
 	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/domain"
+	"github.com/gomajido/hospital-cms-golang/pkg/storage"
 	"github.com/google/uuid"
 )
 
 type doctorUsecase struct {
 	doctorRepo domain.DoctorRepository
+	storage    storage.IStorageProviderRepository
 }
 
-func NewDoctorUsecase(dr domain.DoctorRepository) domain.DoctorUsecase {
+func NewDoctorUsecase(dr domain.DoctorRepository, storage storage.IStorageProviderRepository) domain.DoctorUsecase {
 	return &doctorUsecase{
 		doctorRepo: dr,
+		storage:    storage,
 	}
 }
 
 func (u *doctorUsecase) GetByID(ctx context.Context, id uuid.UUID) (*domain.Doctor, error) {
-	return u.doctorRepo.GetByID(ctx, id)
+	doctor, err := u.doctorRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	u.withPhotoURL(ctx, doctor)
+	return doctor, nil
 }
 
-func (u *doctorUsecase) List(ctx context.Context, page, limit int) ([]domain.Doctor, int64, error) {
-	return u.doctorRepo.List(ctx, page, limit)
+// GetByUserID gets the doctor linked to a user account
+func (u *doctorUsecase) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.Doctor, error) {
+	doctor, err := u.doctorRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, constant.ErrDoctorNotFound) {
+		return nil, constant.ErrDoctorAccountNotLinked
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	u.withPhotoURL(ctx, doctor)
+	return doctor, nil
+}
+
+// GetBySlug gets the doctor shown on a public profile page
+func (u *doctorUsecase) GetBySlug(ctx context.Context, slug string) (*domain.Doctor, error) {
+	doctor, err := u.doctorRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	u.withPhotoURL(ctx, doctor)
+	return doctor, nil
+}
+
+func (u *doctorUsecase) List(ctx context.Context, filter *domain.DoctorFilter, page, limit int) ([]domain.Doctor, int64, error) {
+	doctors, total, err := u.doctorRepo.List(ctx, filter, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range doctors {
+		u.withPhotoURL(ctx, &doctors[i])
+	}
+	return doctors, total, nil
 }
 
 func (u *doctorUsecase) Create(ctx context.Context, req domain.CreateDoctorRequest) (*domain.Doctor, error) {
 	doctor := &domain.Doctor{
-		ID:             uuid.New(),
-		Name:           req.Name,
-		ServiceID:      req.ServiceID,
-		Description:    req.Description,
-		Specialization: req.Specialization,
-		Degree:         req.Degree,
-		Experience:     req.Experience,
+		ID:              uuid.New(),
+		UserID:          req.UserID,
+		Name:            req.Name,
+		Slug:            req.Slug,
+		ServiceID:       req.ServiceID,
+		Description:     req.Description,
+		Specialization:  req.Specialization,
+		Degree:          req.Degree,
+		Experience:      req.Experience,
+		Languages:       trimLanguages(req.Languages),
+		Education:       trimEducation(req.Education),
+		Certifications:  trimCertifications(req.Certifications),
+		ConsultationFee: req.ConsultationFee,
+	}
+	if doctor.Slug == "" {
+		doctor.Slug = slug.Make(req.Name)
 	}
 
 	if err := u.doctorRepo.Create(ctx, doctor); err != nil {
@@ -50,17 +106,34 @@ func (u *doctorUsecase) Update(ctx context.Context, id uuid.UUID, req domain.Upd
 		return nil, err
 	}
 
+	// Keep the linked account unless another one is given or it is unlinked, so clients
+	// sending no user_id do not lock the doctor out of the portal
+	switch {
+	case req.UnlinkUser:
+		doctor.UserID = nil
+	case req.UserID != nil:
+		doctor.UserID = req.UserID
+	}
 	doctor.Name = req.Name
 	doctor.ServiceID = req.ServiceID
 	doctor.Description = req.Description
 	doctor.Specialization = req.Specialization
 	doctor.Degree = req.Degree
 	doctor.Experience = req.Experience
+	doctor.Languages = trimLanguages(req.Languages)
+	doctor.Education = trimEducation(req.Education)
+	doctor.Certifications = trimCertifications(req.Certifications)
+	doctor.ConsultationFee = req.ConsultationFee
+	// Keep the current slug unless a new one is given, so published profile links stay valid
+	if req.Slug != "" {
+		doctor.Slug = req.Slug
+	}
 
 	if err := u.doctorRepo.Update(ctx, doctor); err != nil {
 		return nil, err
 	}
 
+	u.withPhotoURL(ctx, doctor)
 	return doctor, nil
 }
 
@@ -70,12 +143,22 @@ func (u *doctorUsecase) Delete(ctx context.Context, id uuid.UUID) error {
 
 // Schedule operations
 func (u *doctorUsecase) CreateSchedule(ctx context.Context, doctorID uuid.UUID, req domain.CreateScheduleRequest) (*domain.DoctorSchedule, error) {
+	doctor, err := u.doctorRepo.GetByID(ctx, doctorID)
+	if err != nil {
+		return nil, err
+	}
+
 	schedule := &domain.DoctorSchedule{
-		ID:        uuid.New(),
-		DoctorID:  doctorID,
-		Day:       req.Day,
-		StartTime: req.StartTime,
-		EndTime:   req.EndTime,
+		ID:           uuid.New(),
+		DoctorID:     doctorID,
+		Day:          req.Day,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		SlotDuration: slotDurationOrDefault(req.SlotDuration),
+	}
+
+	if err := checkScheduleOverlap(doctor.Schedules, schedule); err != nil {
+		return nil, err
 	}
 
 	if err := u.doctorRepo.CreateSchedule(ctx, schedule); err != nil {
@@ -89,38 +172,98 @@ func (u *doctorUsecase) GetSchedulesByDoctorID(ctx context.Context, doctorID uui
 	return u.doctorRepo.GetSchedulesByDoctorID(ctx, doctorID)
 }
 
+// UpdateSchedule changes a schedule, refusing an overlap with the doctor's other schedules
+// or a change leaving booked appointments outside the schedule
 func (u *doctorUsecase) UpdateSchedule(ctx context.Context, id uuid.UUID, req domain.UpdateScheduleRequest) (*domain.DoctorSchedule, error) {
+	current, err := u.doctorRepo.GetScheduleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
 	schedule := &domain.DoctorSchedule{
-		ID:        id,
-		Day:       req.Day,
-		StartTime: req.StartTime,
-		EndTime:   req.EndTime,
+		ID:           id,
+		DoctorID:     current.DoctorID,
+		Day:          req.Day,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		SlotDuration: slotDurationOrDefault(req.SlotDuration),
 	}
 
-	if err := u.doctorRepo.UpdateSchedule(ctx, schedule); err != nil {
+	schedules, err := u.doctorRepo.GetSchedulesByDoctorID(ctx, current.DoctorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScheduleOverlap(schedules, schedule); err != nil {
+		return nil, err
+	}
+
+	// The appointments are checked while the schedule is locked against new bookings
+	if err := u.doctorRepo.UpdateSchedule(ctx, schedule, scheduleChangeGuard(schedule)); err != nil {
 		return nil, fmt.Errorf("failed to update schedule: %w", err)
 	}
 
 	return schedule, nil
 }
 
+// DeleteSchedule deletes a schedule without upcoming appointments, deleting the schedule would delete them too
 func (u *doctorUsecase) DeleteSchedule(ctx context.Context, id uuid.UUID) error {
-	return u.doctorRepo.DeleteSchedule(ctx, id)
+	return u.doctorRepo.DeleteSchedule(ctx, id, noUpcomingAppointments)
+}
+
+// CheckScheduleOwner makes sure a schedule belongs to the given doctor, schedules of other
+// doctors are reported as not found
+func (u *doctorUsecase) CheckScheduleOwner(ctx context.Context, doctorID, scheduleID uuid.UUID) error {
+	schedule, err := u.doctorRepo.GetScheduleByID(ctx, scheduleID)
+	if err != nil {
+		return err
+	}
+	if schedule.DoctorID != doctorID {
+		return constant.ErrScheduleNotFound
+	}
+	return nil
+}
+
+// CheckRescheduleOwner makes sure a reschedule belongs to a schedule of the given doctor
+func (u *doctorUsecase) CheckRescheduleOwner(ctx context.Context, doctorID, rescheduleID uuid.UUID) error {
+	reschedule, err := u.doctorRepo.GetRescheduleByID(ctx, rescheduleID)
+	if err != nil {
+		return err
+	}
+	if reschedule.Schedule.DoctorID != doctorID {
+		return constant.ErrRescheduleNotFound
+	}
+	return nil
+}
+
+func slotDurationOrDefault(slotDuration int) int {
+	if slotDuration <= 0 {
+		return constant.DefaultSlotDuration
+	}
+	return slotDuration
 }
 
 // Reschedule operations
 func (u *doctorUsecase) CreateReschedule(ctx context.Context, scheduleID uuid.UUID, req domain.CreateRescheduleRequest) (*domain.DoctorReschedule, error) {
+	schedule, err := u.doctorRepo.GetScheduleByID(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
 	reschedule := &domain.DoctorReschedule{
 		ID:               uuid.New(),
 		DoctorScheduleID: scheduleID,
 		Date:             req.Date,
 		StartTime:        req.StartTime,
 		EndTime:          req.EndTime,
-		Status:           req.Status,
+		Status:           strings.ToLower(req.Status),
 		Description:      req.Description,
 	}
 
-	if err := u.doctorRepo.CreateReschedule(ctx, reschedule); err != nil {
+	if err := u.checkReschedule(ctx, schedule, reschedule); err != nil {
+		return nil, err
+	}
+
+	if err := u.doctorRepo.CreateReschedule(ctx, reschedule, rescheduleChangeGuard(rescheduleWindow(reschedule))); err != nil {
 		return nil, err
 	}
 
@@ -131,23 +274,74 @@ func (u *doctorUsecase) GetReschedulesByScheduleID(ctx context.Context, schedule
 	return u.doctorRepo.GetReschedulesByScheduleID(ctx, scheduleID)
 }
 
+// UpdateReschedule changes a reschedule. Appointments on a date the reschedule is moved away
+// from fall back to the regular hours of the schedule.
 func (u *doctorUsecase) UpdateReschedule(ctx context.Context, id uuid.UUID, req domain.UpdateRescheduleRequest) (*domain.DoctorReschedule, error) {
+	current, err := u.doctorRepo.GetRescheduleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
 	reschedule := &domain.DoctorReschedule{
-		ID:          id,
-		Date:        req.Date,
-		StartTime:   req.StartTime,
-		EndTime:     req.EndTime,
-		Status:      req.Status,
-		Description: req.Description,
+		ID:               id,
+		DoctorScheduleID: current.DoctorScheduleID,
+		Date:             req.Date,
+		StartTime:        req.StartTime,
+		EndTime:          req.EndTime,
+		Status:           strings.ToLower(req.Status),
+		Description:      req.Description,
 	}
 
-	if err := u.doctorRepo.UpdateReschedule(ctx, reschedule); err != nil {
+	if err := u.checkReschedule(ctx, current.Schedule, reschedule); err != nil {
+		return nil, err
+	}
+	windows := []dateWindow{rescheduleWindow(reschedule)}
+	if !sameDate(current.Date, reschedule.Date) {
+		windows = append(windows, regularWindow(current.Date))
+	}
+	if err := u.doctorRepo.UpdateReschedule(ctx, reschedule, rescheduleChangeGuard(windows...)); err != nil {
 		return nil, fmt.Errorf("failed to update reschedule: %w", err)
 	}
 
 	return reschedule, nil
 }
 
+// DeleteReschedule deletes a reschedule, the appointments on its date fall back to the regular hours of the schedule
 func (u *doctorUsecase) DeleteReschedule(ctx context.Context, id uuid.UUID) error {
-	return u.doctorRepo.DeleteReschedule(ctx, id)
+	current, err := u.doctorRepo.GetRescheduleByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return u.doctorRepo.DeleteReschedule(ctx, current, rescheduleChangeGuard(regularWindow(current.Date)))
+}
+
+func trimLanguages(languages []string) []string {
+	trimmed := []string{}
+	for _, language := range languages {
+		if language = strings.TrimSpace(language); language != "" {
+			trimmed = append(trimmed, language)
+		}
+	}
+	return trimmed
+}
+
+func trimEducation(education []domain.Education) []domain.Education {
+	trimmed := []domain.Education{}
+	for _, entry := range education {
+		entry.Degree = strings.TrimSpace(entry.Degree)
+		entry.Institution = strings.TrimSpace(entry.Institution)
+		trimmed = append(trimmed, entry)
+	}
+	return trimmed
+}
+
+func trimCertifications(certifications []domain.Certification) []domain.Certification {
+	trimmed := []domain.Certification{}
+	for _, entry := range certifications {
+		entry.Name = strings.TrimSpace(entry.Name)
+		entry.Issuer = strings.TrimSpace(entry.Issuer)
+		trimmed = append(trimmed, entry)
+	}
+	return trimmed
 }
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/domain/mocks"
+	storageMocks "github.com/gomajido/hospital-cms-golang/pkg/storage/mocks"
+)
+
+// pngHeader is enough of a PNG file for its content type to be detected
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestDoctorUsecase_Create(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      domain.CreateDoctorRequest
+		wantSlug string
+	}{
+		{
+			name:     "Slug generated from the name",
+			req:      domain.CreateDoctorRequest{Name: "Dr. John Smith", Languages: []string{" English ", " "}},
+			wantSlug: "dr-john-smith",
+		},
+		{
+			name:     "Custom slug",
+			req:      domain.CreateDoctorRequest{Name: "Dr. John Smith", Slug: "john-smith-cardiology", Languages: []string{"English"}},
+			wantSlug: "john-smith-cardiology",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockDoctorRepository(ctrl)
+			repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+			doctor, err := NewDoctorUsecase(repo, storageMocks.NewMockIStorageProviderRepository(ctrl)).Create(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("Create() unexpected error = %v", err)
+			}
+			if doctor.Slug != tt.wantSlug {
+				t.Errorf("Create() slug = %s, want %s", doctor.Slug, tt.wantSlug)
+			}
+			if len(doctor.Languages) != 1 || doctor.Languages[0] != "English" {
+				t.Errorf("Create() languages = %q, want [English]", doctor.Languages)
+			}
+		})
+	}
+}
+
+func TestDoctorUsecase_Update_LinkedUser(t *testing.T) {
+	linked := uuid.New()
+	other := uuid.New()
+
+	tests := []struct {
+		name string
+		req  domain.UpdateDoctorRequest
+		want *uuid.UUID
+	}{
+		{
+			name: "Account kept when user_id is omitted",
+			want: &linked,
+		},
+		{
+			name: "Account replaced",
+			req:  domain.UpdateDoctorRequest{UserID: &other},
+			want: &other,
+		},
+		{
+			name: "Account unlinked",
+			req:  domain.UpdateDoctorRequest{UnlinkUser: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			id := uuid.New()
+			userID := linked
+			repo := mocks.NewMockDoctorRepository(ctrl)
+			repo.EXPECT().GetByID(gomock.Any(), id).Return(&domain.Doctor{ID: id, UserID: &userID, Slug: "dr-john-smith"}, nil)
+			repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+			tt.req.Name = "Dr. John Smith"
+			doctor, err := NewDoctorUsecase(repo, storageMocks.NewMockIStorageProviderRepository(ctrl)).Update(context.Background(), id, tt.req)
+			if err != nil {
+				t.Fatalf("Update() unexpected error = %v", err)
+			}
+			if (doctor.UserID == nil) != (tt.want == nil) || (tt.want != nil && *doctor.UserID != *tt.want) {
+				t.Errorf("Update() user = %v, want %v", doctor.UserID, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoctorUsecase_UploadPhoto(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	id := uuid.New()
+	oldPath := "doctors/" + id.String() + "/old.jpg"
+	url := "https://storage.example.com/photo.png"
+
+	repo := mocks.NewMockDoctorRepository(ctrl)
+	storage := storageMocks.NewMockIStorageProviderRepository(ctrl)
+	uc := NewDoctorUsecase(repo, storage)
+
+	if _, err := uc.UploadPhoto(context.Background(), id, strings.NewReader("not an image")); !errors.Is(err, constant.ErrInvalidPhoto) {
+		t.Errorf("UploadPhoto() text error = %v, want %v", err, constant.ErrInvalidPhoto)
+	}
+
+	tooLarge := bytes.NewReader(append(pngHeader, make([]byte, constant.MaxPhotoSize)...))
+	if _, err := uc.UploadPhoto(context.Background(), id, tooLarge); !errors.Is(err, constant.ErrPhotoTooLarge) {
+		t.Errorf("UploadPhoto() large error = %v, want %v", err, constant.ErrPhotoTooLarge)
+	}
+
+	var newPath string
+	repo.EXPECT().GetByID(gomock.Any(), id).Return(&domain.Doctor{ID: id, PhotoPath: oldPath}, nil)
+	storage.EXPECT().Put(gomock.Any(), gomock.Any(), pngHeader).DoAndReturn(
+		func(_ context.Context, path string, _ interface{}, _ ...interface{}) error {
+			newPath = path
+			return nil
+		})
+	repo.EXPECT().UpdatePhoto(gomock.Any(), id, gomock.Any()).Return(nil)
+	storage.EXPECT().Delete(gomock.Any(), oldPath).Return(nil)
+	storage.EXPECT().GenerateURL(gomock.Any(), gomock.Any(), constant.PhotoURLExpiry).Return(&url, nil)
+
+	doctor, err := uc.UploadPhoto(context.Background(), id, bytes.NewReader(pngHeader))
+	if err != nil {
+		t.Fatalf("UploadPhoto() unexpected error = %v", err)
+	}
+	if !strings.HasPrefix(newPath, "doctors/"+id.String()+"/") || !strings.HasSuffix(newPath, ".png") {
+		t.Errorf("UploadPhoto() stored at %s, want a png under the doctor directory", newPath)
+	}
+	if doctor.PhotoPath != newPath || doctor.PhotoURL != url {
+		t.Errorf("UploadPhoto() = %+v, want the new photo linked", doctor)
+	}
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/domain"
+	"github.com/gomajido/hospital-cms-golang/pkg/app_log"
+	"github.com/google/uuid"
+)
+
+// photoExtensions maps the accepted photo content types to the extension they are stored with
+var photoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// UploadPhoto stores a new profile photo and replaces the previous one.
+// The content type is detected from the data rather than trusted from the client.
+func (u *doctorUsecase) UploadPhoto(ctx context.Context, id uuid.UUID, photo io.Reader) (*domain.Doctor, error) {
+	data, err := io.ReadAll(io.LimitReader(photo, constant.MaxPhotoSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > constant.MaxPhotoSize {
+		return nil, constant.ErrPhotoTooLarge
+	}
+
+	extension, ok := photoExtensions[http.DetectContentType(data)]
+	if !ok {
+		return nil, constant.ErrInvalidPhoto
+	}
+
+	doctor, err := u.doctorRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// A new object per upload, so cached links to the previous photo never show the new one
+	path := fmt.Sprintf("%s/%s/%s%s", constant.PhotoDirectory, id, uuid.New(), extension)
+	if err := u.storage.Put(ctx, path, data); err != nil {
+		return nil, fmt.Errorf("failed to store photo: %w", err)
+	}
+
+	if err := u.doctorRepo.UpdatePhoto(ctx, id, path); err != nil {
+		u.deletePhoto(ctx, path)
+		return nil, err
+	}
+
+	if doctor.PhotoPath != "" {
+		u.deletePhoto(ctx, doctor.PhotoPath)
+	}
+
+	doctor.PhotoPath = path
+	u.withPhotoURL(ctx, doctor)
+	return doctor, nil
+}
+
+// DeletePhoto removes the profile photo of a doctor
+func (u *doctorUsecase) DeletePhoto(ctx context.Context, id uuid.UUID) (*domain.Doctor, error) {
+	doctor, err := u.doctorRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if doctor.PhotoPath == "" {
+		return doctor, nil
+	}
+
+	if err := u.doctorRepo.UpdatePhoto(ctx, id, ""); err != nil {
+		return nil, err
+	}
+
+	u.deletePhoto(ctx, doctor.PhotoPath)
+	doctor.PhotoPath = ""
+	return doctor, nil
+}
+
+// withPhotoURL links the profile photo through a temporary URL of the storage provider.
+// A failure only leaves the photo out, the rest of the profile is still returned.
+func (u *doctorUsecase) withPhotoURL(ctx context.Context, doctor *domain.Doctor) {
+	if doctor.PhotoPath == "" {
+		return
+	}
+
+	url, err := u.storage.GenerateURL(ctx, doctor.PhotoPath, constant.PhotoURLExpiry)
+	if err != nil {
+		app_log.Errorf("Failed to generate photo URL for doctor %s: %v", doctor.ID, err)
+		return
+	}
+	doctor.PhotoURL = *url
+}
+
+// deletePhoto removes a photo object, a leftover object is only logged
+func (u *doctorUsecase) deletePhoto(ctx context.Context, path string) {
+	if err := u.storage.Delete(ctx, path); err != nil {
+		app_log.Errorf("Failed to delete doctor photo %s: %v", path, err)
+	}
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/domain"
+	"github.com/google/uuid"
+)
+
+// checkScheduleOverlap makes sure a schedule does not overlap another weekly schedule held on the same day
+func checkScheduleOverlap(schedules []domain.DoctorSchedule, schedule *domain.DoctorSchedule) error {
+	for _, other := range schedules {
+		if other.ID == schedule.ID || other.Day != schedule.Day {
+			continue
+		}
+		if overlaps(schedule.StartTime, schedule.EndTime, other.StartTime, other.EndTime) {
+			return constant.ErrScheduleOverlap
+		}
+	}
+	return nil
+}
+
+// checkReschedule makes sure a reschedule falls on the day of its schedule, is the only one of the
+// schedule on that date and, when it changes the hours, does not overlap the doctor's other schedules
+// held that date
+func (u *doctorUsecase) checkReschedule(ctx context.Context, schedule *domain.DoctorSchedule, reschedule *domain.DoctorReschedule) error {
+	if reschedule.Date.Weekday().String() != schedule.Day {
+		return constant.ErrRescheduleDayMismatch
+	}
+
+	reschedules, err := u.doctorRepo.GetReschedulesByDoctorID(ctx, schedule.DoctorID, reschedule.Date, reschedule.Date)
+	if err != nil {
+		return err
+	}
+
+	overrides := make(map[uuid.UUID]domain.DoctorReschedule)
+	for _, other := range reschedules {
+		if other.ID == reschedule.ID {
+			continue
+		}
+		if other.DoctorScheduleID == schedule.ID {
+			return constant.ErrRescheduleExists
+		}
+		overrides[other.DoctorScheduleID] = other
+	}
+
+	if reschedule.Status == constant.RescheduleStatusCancelled {
+		return nil
+	}
+
+	schedules, err := u.doctorRepo.GetSchedulesByDoctorID(ctx, schedule.DoctorID)
+	if err != nil {
+		return err
+	}
+
+	for _, other := range schedules {
+		if other.ID == schedule.ID || other.Day != schedule.Day {
+			continue
+		}
+
+		startTime, endTime := other.StartTime, other.EndTime
+		if override, ok := overrides[other.ID]; ok {
+			if override.Status == constant.RescheduleStatusCancelled {
+				continue
+			}
+			startTime, endTime = override.StartTime, override.EndTime
+		}
+
+		if overlaps(reschedule.StartTime, reschedule.EndTime, startTime, endTime) {
+			return constant.ErrScheduleOverlap
+		}
+	}
+
+	return nil
+}
+
+// scheduleChangeGuard lists the upcoming appointments a new day, new hours or a new slot duration
+// of a schedule would leave out. Booked appointments keep the slot duration they were booked with,
+// they must still end by the end of the hours and start on the new slot grid. Dates with a
+// reschedule keep the hours of the reschedule, only their day and slot grid are checked.
+func scheduleChangeGuard(schedule *domain.DoctorSchedule) domain.ScheduleGuard {
+	return func(current *domain.DoctorSchedule, appointments []domain.ScheduledAppointment, reschedules []domain.DoctorReschedule) error {
+		return checkScheduleChange(current, schedule, appointments, reschedules)
+	}
+}
+
+func checkScheduleChange(current, schedule *domain.DoctorSchedule, appointments []domain.ScheduledAppointment, reschedules []domain.DoctorReschedule) error {
+	overrides := make(map[string]domain.DoctorReschedule)
+	for _, reschedule := range reschedules {
+		overrides[reschedule.Date.Format(constant.DateFormat)] = reschedule
+	}
+
+	var affected []domain.ScheduledAppointment
+	for _, appointment := range appointments {
+		if appointment.Date.Weekday().String() != schedule.Day {
+			affected = append(affected, appointment)
+			continue
+		}
+
+		startTime, endTime := schedule.StartTime, schedule.EndTime
+		if override, ok := overrides[appointment.Date.Format(constant.DateFormat)]; ok {
+			if override.Status == constant.RescheduleStatusCancelled {
+				continue
+			}
+			startTime, endTime = override.StartTime, override.EndTime
+		}
+
+		if !fits(appointment.Time, startTime, endTime, current.SlotDuration, schedule.SlotDuration) {
+			affected = append(affected, appointment)
+		}
+	}
+
+	return affectedError(affected)
+}
+
+// rescheduleChangeGuard lists the upcoming appointments left out when the hours of a schedule on
+// the given dates change. Each window applies to the appointments booked on its date, which must
+// still fit in it and start on the slot grid it is split into.
+func rescheduleChangeGuard(windows ...dateWindow) domain.ScheduleGuard {
+	return func(current *domain.DoctorSchedule, appointments []domain.ScheduledAppointment, _ []domain.DoctorReschedule) error {
+		return checkRescheduleChange(current, appointments, windows)
+	}
+}
+
+func checkRescheduleChange(current *domain.DoctorSchedule, appointments []domain.ScheduledAppointment, windows []dateWindow) error {
+	var affected []domain.ScheduledAppointment
+	for _, appointment := range appointments {
+		for _, window := range windows {
+			if !sameDate(appointment.Date, window.date) {
+				continue
+			}
+
+			startTime, endTime := window.startTime, window.endTime
+			if window.regular {
+				startTime, endTime = current.StartTime, current.EndTime
+			}
+			if window.cancelled || !fits(appointment.Time, startTime, endTime, current.SlotDuration, current.SlotDuration) {
+				affected = append(affected, appointment)
+			}
+			break
+		}
+	}
+
+	return affectedError(affected)
+}
+
+// dateWindow is the time window a schedule is held in on a single date
+type dateWindow struct {
+	date      time.Time
+	startTime string
+	endTime   string
+	cancelled bool
+	regular   bool // Held in the regular hours of the schedule
+}
+
+func rescheduleWindow(reschedule *domain.DoctorReschedule) dateWindow {
+	return dateWindow{
+		date:      reschedule.Date,
+		startTime: reschedule.StartTime,
+		endTime:   reschedule.EndTime,
+		cancelled: reschedule.Status == constant.RescheduleStatusCancelled,
+	}
+}
+
+// regularWindow is a date falling back to the regular hours of the schedule
+func regularWindow(date time.Time) dateWindow {
+	return dateWindow{
+		date:    date,
+		regular: true,
+	}
+}
+
+// noUpcomingAppointments lists every upcoming appointment of a schedule, deleting the schedule would delete them too
+func noUpcomingAppointments(_ *domain.DoctorSchedule, appointments []domain.ScheduledAppointment, _ []domain.DoctorReschedule) error {
+	return affectedError(appointments)
+}
+
+func affectedError(affected []domain.ScheduledAppointment) error {
+	if len(affected) == 0 {
+		return nil
+	}
+	return &domain.AppointmentsAffectedError{Appointments: affected}
+}
+
+// overlaps reports whether two time windows share any time, windows touching at their ends do not overlap
+func overlaps(startA, endA, startB, endB string) bool {
+	return normalizeSlotTime(startA) < normalizeSlotTime(endB) && normalizeSlotTime(startB) < normalizeSlotTime(endA)
+}
+
+// fits reports whether an appointment booked for bookedDuration minutes lies inside a time window
+// and starts on the grid of slotDuration minute slots the window is split into
+func fits(appointmentTime, startTime, endTime string, bookedDuration, slotDuration int) bool {
+	at, okAt := clockMinutes(appointmentTime)
+	start, okStart := clockMinutes(startTime)
+	end, okEnd := clockMinutes(endTime)
+	if !okAt || !okStart || !okEnd {
+		return false
+	}
+
+	bookedDuration = slotDurationOrDefault(bookedDuration)
+	slotDuration = slotDurationOrDefault(slotDuration)
+	return start <= at && at+bookedDuration <= end && (at-start)%slotDuration == 0
+}
+
+// clockMinutes reads a HH:MM or HH:MM:SS time as minutes since midnight
+func clockMinutes(value string) (int, bool) {
+	clock, err := time.Parse(constant.SlotTimeFormat, normalizeSlotTime(value))
+	if err != nil {
+		return 0, false
+	}
+	return clock.Hour()*60 + clock.Minute(), true
+}
+
+func sameDate(a, b time.Time) bool {
+	return a.Format(constant.DateFormat) == b.Format(constant.DateFormat)
+}
@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/module/doctor/domain/mocks"
+	storageMocks "github.com/gomajido/hospital-cms-golang/pkg/storage/mocks"
+)
+
+func TestCheckScheduleOverlap(t *testing.T) {
+	morning := domain.DoctorSchedule{ID: uuid.New(), Day: "Monday", StartTime: "08:00:00", EndTime: "12:00:00"}
+
+	tests := []struct {
+		name     string
+		schedule domain.DoctorSchedule
+		wantErr  error
+	}{
+		{
+			name:     "Overlapping block",
+			schedule: domain.DoctorSchedule{ID: uuid.New(), Day: "Monday", StartTime: "11:00:00", EndTime: "14:00:00"},
+			wantErr:  constant.ErrScheduleOverlap,
+		},
+		{
+			name:     "Block starting when the other ends",
+			schedule: domain.DoctorSchedule{ID: uuid.New(), Day: "Monday", StartTime: "12:00:00", EndTime: "14:00:00"},
+		},
+		{
+			name:     "Same hours on another day",
+			schedule: domain.DoctorSchedule{ID: uuid.New(), Day: "Tuesday", StartTime: "08:00:00", EndTime: "12:00:00"},
+		},
+		{
+			name:     "Schedule updated in place",
+			schedule: domain.DoctorSchedule{ID: morning.ID, Day: "Monday", StartTime: "09:00:00", EndTime: "12:00:00"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkScheduleOverlap([]domain.DoctorSchedule{morning}, &tt.schedule)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkScheduleOverlap() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// guardedWrite stands in for a schedule write of the repository, running the guard on the locked
+// schedule and what it holds and writing only when the guard accepts it
+func guardedWrite(current *domain.DoctorSchedule, appointments []domain.ScheduledAppointment, reschedules []domain.DoctorReschedule) func(context.Context, interface{}, domain.ScheduleGuard) error {
+	return func(_ context.Context, _ interface{}, guard domain.ScheduleGuard) error {
+		return guard(current, appointments, reschedules)
+	}
+}
+
+func TestDoctorUsecase_UpdateSchedule_AffectedAppointments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	current := &domain.DoctorSchedule{ID: uuid.New(), DoctorID: uuid.New(), Day: "Monday", StartTime: "08:00:00", EndTime: "12:00:00"}
+	monday := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	kept := domain.ScheduledAppointment{ID: uuid.New(), Date: monday, Time: "10:00:00"}
+	outside := domain.ScheduledAppointment{ID: uuid.New(), Date: monday, Time: "08:30:00"}
+	rescheduled := domain.ScheduledAppointment{ID: uuid.New(), Date: monday.AddDate(0, 0, 7), Time: "08:00:00"}
+
+	repo := mocks.NewMockDoctorRepository(ctrl)
+	repo.EXPECT().GetScheduleByID(gomock.Any(), current.ID).Return(current, nil)
+	repo.EXPECT().GetSchedulesByDoctorID(gomock.Any(), current.DoctorID).Return([]domain.DoctorSchedule{*current}, nil)
+	repo.EXPECT().UpdateSchedule(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		guardedWrite(current, []domain.ScheduledAppointment{kept, outside, rescheduled}, []domain.DoctorReschedule{
+			{ID: uuid.New(), DoctorScheduleID: current.ID, Date: rescheduled.Date, StartTime: "07:00:00", EndTime: "09:00:00", Status: constant.RescheduleStatusChanged},
+		}),
+	)
+
+	uc := NewDoctorUsecase(repo, storageMocks.NewMockIStorageProviderRepository(ctrl))
+	_, err := uc.UpdateSchedule(context.Background(), current.ID, domain.UpdateScheduleRequest{
+		Day: "Monday", StartTime: "09:00:00", EndTime: "12:00:00",
+	})
+
+	var affected *domain.AppointmentsAffectedError
+	if !errors.As(err, &affected) || !errors.Is(err, constant.ErrAppointmentsAffected) {
+		t.Fatalf("UpdateSchedule() error = %v, want the affected appointments", err)
+	}
+	if len(affected.Appointments) != 1 || affected.Appointments[0].ID != outside.ID {
+		t.Errorf("UpdateSchedule() affected = %+v, want only the appointment before the new start", affected.Appointments)
+	}
+}
+
+func TestCheckScheduleChange(t *testing.T) {
+	current := &domain.DoctorSchedule{ID: uuid.New(), Day: "Monday", StartTime: "09:00:00", EndTime: "12:00:00", SlotDuration: 30}
+	monday := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule domain.DoctorSchedule
+		time     string
+		want     bool
+	}{
+		{
+			name:     "Booking ending with the new hours",
+			schedule: domain.DoctorSchedule{Day: "Monday", StartTime: "09:00:00", EndTime: "12:00:00", SlotDuration: 30},
+			time:     "11:30:00",
+		},
+		{
+			name:     "Booking starting inside but ending after the new hours",
+			schedule: domain.DoctorSchedule{Day: "Monday", StartTime: "09:00:00", EndTime: "11:45:00", SlotDuration: 30},
+			time:     "11:30:00",
+			want:     true,
+		},
+		{
+			name:     "Booking off the grid of a new slot duration",
+			schedule: domain.DoctorSchedule{Day: "Monday", StartTime: "09:00:00", EndTime: "12:00:00", SlotDuration: 20},
+			time:     "09:30:00",
+			want:     true,
+		},
+		{
+			name:     "Booking on the grid of a new slot duration",
+			schedule: domain.DoctorSchedule{Day: "Monday", StartTime: "09:00:00", EndTime: "12:00:00", SlotDuration: 15},
+			time:     "09:30:00",
+		},
+		{
+			name:     "Booking off the grid of a new start time",
+			schedule: domain.DoctorSchedule{Day: "Monday", StartTime: "09:15:00", EndTime: "12:00:00", SlotDuration: 30},
+			time:     "10:00:00",
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appointment := domain.ScheduledAppointment{ID: uuid.New(), Date: monday, Time: tt.time}
+			err := checkScheduleChange(current, &tt.schedule, []domain.ScheduledAppointment{appointment}, nil)
+			if got := errors.Is(err, constant.ErrAppointmentsAffected); got != tt.want {
+				t.Errorf("checkScheduleChange() error = %v, want affected %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoctorUsecase_CreateReschedule(t *testing.T) {
+	schedule := &domain.DoctorSchedule{ID: uuid.New(), DoctorID: uuid.New(), Day: "Monday", StartTime: "08:00:00", EndTime: "12:00:00"}
+	afternoon := domain.DoctorSchedule{ID: uuid.New(), DoctorID: schedule.DoctorID, Day: "Monday", StartTime: "13:00:00", EndTime: "17:00:00"}
+	monday := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	booked := domain.ScheduledAppointment{ID: uuid.New(), Date: monday, Time: "09:00:00"}
+
+	tests := []struct {
+		name    string
+		req     domain.CreateRescheduleRequest
+		setup   func(repo *mocks.MockDoctorRepository)
+		wantErr error
+	}{
+		{
+			name:    "Date on another weekday",
+			req:     domain.CreateRescheduleRequest{Date: monday.AddDate(0, 0, 1), StartTime: "09:00:00", EndTime: "12:00:00", Status: constant.RescheduleStatusChanged},
+			wantErr: constant.ErrRescheduleDayMismatch,
+		},
+		{
+			name: "Schedule already rescheduled that date",
+			req:  domain.CreateRescheduleRequest{Date: monday, StartTime: "09:00:00", EndTime: "12:00:00", Status: constant.RescheduleStatusChanged},
+			setup: func(repo *mocks.MockDoctorRepository) {
+				repo.EXPECT().GetReschedulesByDoctorID(gomock.Any(), schedule.DoctorID, monday, monday).Return([]domain.DoctorReschedule{
+					{ID: uuid.New(), DoctorScheduleID: schedule.ID, Date: monday, Status: constant.RescheduleStatusCancelled},
+				}, nil)
+			},
+			wantErr: constant.ErrRescheduleExists,
+		},
+		{
+			name: "Changed hours overlapping the afternoon schedule",
+			req:  domain.CreateRescheduleRequest{Date: monday, StartTime: "10:00:00", EndTime: "14:00:00", Status: constant.RescheduleStatusChanged},
+			setup: func(repo *mocks.MockDoctorRepository) {
+				repo.EXPECT().GetReschedulesByDoctorID(gomock.Any(), schedule.DoctorID, monday, monday).Return(nil, nil)
+				repo.EXPECT().GetSchedulesByDoctorID(gomock.Any(), schedule.DoctorID).Return([]domain.DoctorSchedule{*schedule, afternoon}, nil)
+			},
+			wantErr: constant.ErrScheduleOverlap,
+		},
+		{
+			name: "Cancelling a date with booked appointments",
+			req:  domain.CreateRescheduleRequest{Date: monday, StartTime: "08:00:00", EndTime: "12:00:00", Status: "Cancelled"},
+			setup: func(repo *mocks.MockDoctorRepository) {
+				repo.EXPECT().GetReschedulesByDoctorID(gomock.Any(), schedule.DoctorID, monday, monday).Return(nil, nil)
+				repo.EXPECT().CreateReschedule(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					guardedWrite(schedule, []domain.ScheduledAppointment{booked}, nil),
+				)
+			},
+			wantErr: constant.ErrAppointmentsAffected,
+		},
+		{
+			name: "Changed hours keeping the booked appointments",
+			req:  domain.CreateRescheduleRequest{Date: monday, StartTime: "09:00:00", EndTime: "11:00:00", Status: constant.RescheduleStatusChanged},
+			setup: func(repo *mocks.MockDoctorRepository) {
+				repo.EXPECT().GetReschedulesByDoctorID(gomock.Any(), schedule.DoctorID, monday, monday).Return(nil, nil)
+				repo.EXPECT().GetSchedulesByDoctorID(gomock.Any(), schedule.DoctorID).Return([]domain.DoctorSchedule{*schedule, afternoon}, nil)
+				repo.EXPECT().CreateReschedule(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					guardedWrite(schedule, []domain.ScheduledAppointment{booked}, nil),
+				)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockDoctorRepository(ctrl)
+			repo.EXPECT().GetScheduleByID(gomock.Any(), schedule.ID).Return(schedule, nil)
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+
+			uc := NewDoctorUsecase(repo, storageMocks.NewMockIStorageProviderRepository(ctrl))
+			_, err := uc.CreateReschedule(context.Background(), schedule.ID, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateReschedule() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDoctorUsecase_DeleteSchedule(t *testing.T) {
+	scheduleID := uuid.New()
+	booked := domain.ScheduledAppointment{ID: uuid.New(), Date: time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC), Time: "09:00:00"}
+
+	tests := []struct {
+		name         string
+		appointments []domain.ScheduledAppointment
+		wantErr      error
+	}{
+		{
+			name: "Schedule without upcoming appointments",
+		},
+		{
+			name:         "Schedule with upcoming appointments",
+			appointments: []domain.ScheduledAppointment{booked},
+			wantErr:      constant.ErrAppointmentsAffected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockDoctorRepository(ctrl)
+			repo.EXPECT().DeleteSchedule(gomock.Any(), scheduleID, gomock.Any()).DoAndReturn(guardedWrite(&domain.DoctorSchedule{ID: scheduleID}, tt.appointments, nil))
+
+			uc := NewDoctorUsecase(repo, storageMocks.NewMockIStorageProviderRepository(ctrl))
+			if err := uc.DeleteSchedule(context.Background(), scheduleID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("DeleteSchedule() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
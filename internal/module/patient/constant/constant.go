@@ -0,0 +1,24 @@
+package constant
+
+import "errors"
+
+const (
+	GenderMale   = "male"
+	GenderFemale = "female"
+
+	DateFormat = "2006-01-02"
+
+	MaxDependents = 10
+)
+
+// BloodTypes are the accepted ABO groups with their Rh factor
+var BloodTypes = []string{"A+", "A-", "B+", "B-", "AB+", "AB-", "O+", "O-"}
+
+// Common errors for patient module
+var (
+	ErrPatientNotFound      = errors.New("patient not found")
+	ErrPatientProfileExists = errors.New("patient profile already exists")
+	ErrNationalIDTaken      = errors.New("national id is already registered")
+	ErrTooManyDependents    = errors.New("maximum number of dependents reached")
+	ErrDependentHasBookings = errors.New("dependent has upcoming appointments, cancel them first")
+)
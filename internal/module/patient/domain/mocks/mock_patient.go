@@ -0,0 +1,344 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/module/patient/domain/patient.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/gomajido/hospital-cms-golang/internal/module/patient/domain"
+	uuid "github.com/google/uuid"
+)
+
+// MockPatientRepository is a mock of PatientRepository interface.
+type MockPatientRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPatientRepositoryMockRecorder
+}
+
+// MockPatientRepositoryMockRecorder is the mock recorder for MockPatientRepository.
+type MockPatientRepositoryMockRecorder struct {
+	mock *MockPatientRepository
+}
+
+// NewMockPatientRepository creates a new mock instance.
+func NewMockPatientRepository(ctrl *gomock.Controller) *MockPatientRepository {
+	mock := &MockPatientRepository{ctrl: ctrl}
+	mock.recorder = &MockPatientRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPatientRepository) EXPECT() *MockPatientRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPatientRepository) Create(ctx context.Context, patient *domain.Patient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, patient)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPatientRepositoryMockRecorder) Create(ctx, patient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPatientRepository)(nil).Create), ctx, patient)
+}
+
+// CreateFromUser mocks base method.
+func (m *MockPatientRepository) CreateFromUser(ctx context.Context, userID uuid.UUID) (*domain.Patient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFromUser", ctx, userID)
+	ret0, _ := ret[0].(*domain.Patient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFromUser indicates an expected call of CreateFromUser.
+func (mr *MockPatientRepositoryMockRecorder) CreateFromUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFromUser", reflect.TypeOf((*MockPatientRepository)(nil).CreateFromUser), ctx, userID)
+}
+
+// GetByID mocks base method.
+func (m *MockPatientRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Patient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Patient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPatientRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPatientRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByManager mocks base method.
+func (m *MockPatientRepository) GetByManager(ctx context.Context, managerID uuid.UUID) ([]domain.Patient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByManager", ctx, managerID)
+	ret0, _ := ret[0].([]domain.Patient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByManager indicates an expected call of GetByManager.
+func (mr *MockPatientRepositoryMockRecorder) GetByManager(ctx, managerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByManager", reflect.TypeOf((*MockPatientRepository)(nil).GetByManager), ctx, managerID)
+}
+
+// GetByUserID mocks base method.
+func (m *MockPatientRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.Patient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID)
+	ret0, _ := ret[0].(*domain.Patient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockPatientRepositoryMockRecorder) GetByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockPatientRepository)(nil).GetByUserID), ctx, userID)
+}
+
+// List mocks base method.
+func (m *MockPatientRepository) List(ctx context.Context, search string, page, limit int) ([]domain.Patient, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, page, limit)
+	ret0, _ := ret[0].([]domain.Patient)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockPatientRepositoryMockRecorder) List(ctx, search, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPatientRepository)(nil).List), ctx, search, page, limit)
+}
+
+// RemoveManager mocks base method.
+func (m *MockPatientRepository) RemoveManager(ctx context.Context, id, managerID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveManager", ctx, id, managerID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveManager indicates an expected call of RemoveManager.
+func (mr *MockPatientRepositoryMockRecorder) RemoveManager(ctx, id, managerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveManager", reflect.TypeOf((*MockPatientRepository)(nil).RemoveManager), ctx, id, managerID)
+}
+
+// Update mocks base method.
+func (m *MockPatientRepository) Update(ctx context.Context, patient *domain.Patient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, patient)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPatientRepositoryMockRecorder) Update(ctx, patient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPatientRepository)(nil).Update), ctx, patient)
+}
+
+// MockPatientUsecase is a mock of PatientUsecase interface.
+type MockPatientUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPatientUsecaseMockRecorder
+}
+
+// MockPatientUsecaseMockRecorder is the mock recorder for MockPatientUsecase.
+type MockPatientUsecaseMockRecorder struct {
+	mock *MockPatientUsecase
+}
+
+// NewMockPatientUsecase creates a new mock instance.
+func NewMockPatientUsecase(ctrl *gomock.Controller) *MockPatientUsecase {
+	mock := &MockPatientUsecase{ctrl: ctrl}
+	mock.recorder = &MockPatientUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPatientUsecase) EXPECT() *MockPatientUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AddDependent mocks base method.
+func (m *MockPatientUsecase) AddDependent(ctx context.Context, req domain.PatientRequest) (*domain.Patient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDependent", ctx, req)
+	ret0, _ := ret[0].(*domain.Patient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDependent indicates an expected call of AddDependent.
+func (mr *MockPatientUsecaseMockRecorder) AddDependent(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDependent", reflect.TypeOf((*MockPatientUsecase)(nil).AddDependent), ctx, req)
+}
+
+// CreateProfile mocks base method.
+func (m *MockPatientUsecase) CreateProfile(ctx context.Context, req domain.PatientRequest) (*domain.Patient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProfile", ctx, req)
+	ret0, _ := ret[0].(*domain.Patient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProfile indicates an expected call of CreateProfile.
+func (mr *MockPatientUsecaseMockRecorder) CreateProfile(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProfile", reflect.TypeOf((*MockPatientUsecase)(nil).CreateProfile), ctx, req)
+}
+
+// GetAuthorized mocks base method.
+func (m *MockPatientUsecase) GetAuthorized(ctx context.Context, userID, patientID uuid.UUID) (*domain.Patient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorized", ctx, userID, patientID)
+	ret0, _ := ret[0].(*domain.Patient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorized indicates an expected call of GetAuthorized.
+func (mr *MockPatientUsecaseMockRecorder) GetAuthorized(ctx, userID, patientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorized", reflect.TypeOf((*MockPatientUsecase)(nil).GetAuthorized), ctx, userID, patientID)
+}
+
+// GetByID mocks base method.
+func (m *MockPatientUsecase) GetByID(ctx context.Context, id uuid.UUID) (*domain.Patient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Patient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPatientUsecaseMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPatientUsecase)(nil).GetByID), ctx, id)
+}
+
+// GetOrCreateProfile mocks base method.
+func (m *MockPatientUsecase) GetOrCreateProfile(ctx context.Context, userID uuid.UUID) (*domain.Patient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrCreateProfile", ctx, userID)
+	ret0, _ := ret[0].(*domain.Patient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrCreateProfile indicates an expected call of GetOrCreateProfile.
+func (mr *MockPatientUsecaseMockRecorder) GetOrCreateProfile(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreateProfile", reflect.TypeOf((*MockPatientUsecase)(nil).GetOrCreateProfile), ctx, userID)
+}
+
+// GetProfile mocks base method.
+func (m *MockPatientUsecase) GetProfile(ctx context.Context, userID uuid.UUID) (*domain.Patient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", ctx, userID)
+	ret0, _ := ret[0].(*domain.Patient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockPatientUsecaseMockRecorder) GetProfile(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockPatientUsecase)(nil).GetProfile), ctx, userID)
+}
+
+// List mocks base method.
+func (m *MockPatientUsecase) List(ctx context.Context, search string, page, limit int) ([]domain.Patient, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, page, limit)
+	ret0, _ := ret[0].([]domain.Patient)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockPatientUsecaseMockRecorder) List(ctx, search, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPatientUsecase)(nil).List), ctx, search, page, limit)
+}
+
+// ListDependents mocks base method.
+func (m *MockPatientUsecase) ListDependents(ctx context.Context, userID uuid.UUID) ([]domain.Patient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDependents", ctx, userID)
+	ret0, _ := ret[0].([]domain.Patient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDependents indicates an expected call of ListDependents.
+func (mr *MockPatientUsecaseMockRecorder) ListDependents(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDependents", reflect.TypeOf((*MockPatientUsecase)(nil).ListDependents), ctx, userID)
+}
+
+// RemoveDependent mocks base method.
+func (m *MockPatientUsecase) RemoveDependent(ctx context.Context, userID, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDependent", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDependent indicates an expected call of RemoveDependent.
+func (mr *MockPatientUsecaseMockRecorder) RemoveDependent(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDependent", reflect.TypeOf((*MockPatientUsecase)(nil).RemoveDependent), ctx, userID, id)
+}
+
+// UpdateDependent mocks base method.
+func (m *MockPatientUsecase) UpdateDependent(ctx context.Context, id uuid.UUID, req domain.PatientRequest) (*domain.Patient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDependent", ctx, id, req)
+	ret0, _ := ret[0].(*domain.Patient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDependent indicates an expected call of UpdateDependent.
+func (mr *MockPatientUsecaseMockRecorder) UpdateDependent(ctx, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDependent", reflect.TypeOf((*MockPatientUsecase)(nil).UpdateDependent), ctx, id, req)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockPatientUsecase) UpdateProfile(ctx context.Context, req domain.PatientRequest) (*domain.Patient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, req)
+	ret0, _ := ret[0].(*domain.Patient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockPatientUsecaseMockRecorder) UpdateProfile(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockPatientUsecase)(nil).UpdateProfile), ctx, req)
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Contact represents a person reachable on behalf of a patient
+type Contact struct {
+	Name         string `json:"name"`
+	Phone        string `json:"phone"`
+	Relationship string `json:"relationship"`
+}
+
+// Patient represents the medical demographics of a person appointments are booked for
+type Patient struct {
+	ID               uuid.UUID  `json:"id"`
+	UserID           *uuid.UUID `json:"user_id,omitempty"`
+	ManagedBy        *uuid.UUID `json:"managed_by,omitempty"` // Set for dependents without their own login
+	Name             string     `json:"name"`
+	DateOfBirth      *time.Time `json:"date_of_birth"` // Unknown until a profile created on a first booking is completed
+	Gender           string     `json:"gender"`
+	NationalID       string     `json:"national_id,omitempty"`
+	Phone            string     `json:"phone,omitempty"`
+	Address          string     `json:"address,omitempty"`
+	BloodType        string     `json:"blood_type,omitempty"`
+	Allergies        []string   `json:"allergies"`
+	EmergencyContact *Contact   `json:"emergency_contact,omitempty"`
+	Guardian         *Contact   `json:"guardian,omitempty"` // Set for minors
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// PatientRepository defines the interface for patient data operations
+type PatientRepository interface {
+	Create(ctx context.Context, patient *Patient) error
+	CreateFromUser(ctx context.Context, userID uuid.UUID) (*Patient, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Patient, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*Patient, error)
+	GetByManager(ctx context.Context, managerID uuid.UUID) ([]Patient, error)
+	List(ctx context.Context, search string, page, limit int) ([]Patient, int64, error)
+	Update(ctx context.Context, patient *Patient) error
+	RemoveManager(ctx context.Context, id, managerID uuid.UUID) (bool, error)
+}
+
+// PatientUsecase defines the interface for patient business logic
+type PatientUsecase interface {
+	CreateProfile(ctx context.Context, req PatientRequest) (*Patient, error)
+	GetProfile(ctx context.Context, userID uuid.UUID) (*Patient, error)
+	GetOrCreateProfile(ctx context.Context, userID uuid.UUID) (*Patient, error)
+	UpdateProfile(ctx context.Context, req PatientRequest) (*Patient, error)
+	AddDependent(ctx context.Context, req PatientRequest) (*Patient, error)
+	ListDependents(ctx context.Context, userID uuid.UUID) ([]Patient, error)
+	UpdateDependent(ctx context.Context, id uuid.UUID, req PatientRequest) (*Patient, error)
+	RemoveDependent(ctx context.Context, userID, id uuid.UUID) error
+	GetAuthorized(ctx context.Context, userID, patientID uuid.UUID) (*Patient, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Patient, error)
+	List(ctx context.Context, search string, page, limit int) ([]Patient, int64, error)
+}
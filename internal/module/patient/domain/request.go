@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// PatientRequest represents the request to create or replace the patient profile of a user,
+// or of one of their dependents
+type PatientRequest struct {
+	UserID           uuid.UUID `json:"-"` // Set by the handler from the authenticated user
+	Name             string    `json:"name"`
+	DateOfBirth      string    `json:"date_of_birth"`
+	Gender           string    `json:"gender"`
+	NationalID       string    `json:"national_id"`
+	Phone            string    `json:"phone"`
+	Address          string    `json:"address"`
+	BloodType        string    `json:"blood_type"`
+	Allergies        []string  `json:"allergies"`
+	EmergencyContact *Contact  `json:"emergency_contact"`
+	Guardian         *Contact  `json:"guardian"`
+}
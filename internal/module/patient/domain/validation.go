@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/validator"
+	patientConstant "github.com/gomajido/hospital-cms-golang/internal/module/patient/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/response"
+)
+
+const (
+	// Field names for validation messages
+	NAME_FIELD                    = "name"
+	DATE_OF_BIRTH_FIELD           = "date_of_birth"
+	GENDER_FIELD                  = "gender"
+	NATIONAL_ID_FIELD             = "national_id"
+	BLOOD_TYPE_FIELD              = "blood_type"
+	ALLERGIES_FIELD               = "allergies"
+	EMERGENCY_CONTACT_NAME_FIELD  = "emergency_contact.name"
+	EMERGENCY_CONTACT_PHONE_FIELD = "emergency_contact.phone"
+	GUARDIAN_NAME_FIELD           = "guardian.name"
+	GUARDIAN_PHONE_FIELD          = "guardian.phone"
+	GUARDIAN_RELATIONSHIP_FIELD   = "guardian.relationship"
+
+	MAX_NATIONAL_ID_LENGTH = 50
+	MAX_ALLERGY_LENGTH     = 100
+)
+
+// IsMinor reports whether a patient born on the given YYYY-MM-DD date needs a guardian,
+// using the same age rule as the older_than validation tag
+func IsMinor(dateOfBirth string) bool {
+	return validator.Validate.Var(dateOfBirth, validator.RULE_OLDER_THAN) != nil
+}
+
+// Validate validates PatientRequest
+func (r *PatientRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	if strings.TrimSpace(r.Name) == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        NAME_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, NAME_FIELD),
+		})
+	}
+
+	validBirthDate := false
+	if r.DateOfBirth == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        DATE_OF_BIRTH_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, DATE_OF_BIRTH_FIELD),
+		})
+	} else if dateOfBirth, err := time.Parse(patientConstant.DateFormat, r.DateOfBirth); err != nil {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        DATE_OF_BIRTH_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_FORMAT, DATE_OF_BIRTH_FIELD, "YYYY-MM-DD"),
+		})
+	} else if dateOfBirth.After(time.Now()) {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        DATE_OF_BIRTH_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MAX_VALUE, DATE_OF_BIRTH_FIELD, "today"),
+		})
+	} else {
+		validBirthDate = true
+	}
+
+	if r.Gender != patientConstant.GenderMale && r.Gender != patientConstant.GenderFemale {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        GENDER_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_VALUE, GENDER_FIELD, patientConstant.GenderMale+", "+patientConstant.GenderFemale),
+		})
+	}
+
+	if len(r.NationalID) > MAX_NATIONAL_ID_LENGTH {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        NATIONAL_ID_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MAX_LENGTH, NATIONAL_ID_FIELD, MAX_NATIONAL_ID_LENGTH),
+		})
+	}
+
+	if r.BloodType != constant.EMPTY_STRING && !isValidBloodType(r.BloodType) {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        BLOOD_TYPE_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_VALUE, BLOOD_TYPE_FIELD, strings.Join(patientConstant.BloodTypes, ", ")),
+		})
+	}
+
+	for _, allergy := range r.Allergies {
+		if strings.TrimSpace(allergy) == constant.EMPTY_STRING {
+			errorInfo = append(errorInfo, response.ErrorInfo{
+				Field:        ALLERGIES_FIELD,
+				ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, ALLERGIES_FIELD+" entry"),
+			})
+			break
+		}
+		if len(allergy) > MAX_ALLERGY_LENGTH {
+			errorInfo = append(errorInfo, response.ErrorInfo{
+				Field:        ALLERGIES_FIELD,
+				ErrorMessage: fmt.Sprintf(constant.VALIDATION_MAX_LENGTH, ALLERGIES_FIELD+" entry", MAX_ALLERGY_LENGTH),
+			})
+			break
+		}
+	}
+
+	if r.EmergencyContact != nil {
+		if r.EmergencyContact.Name == constant.EMPTY_STRING {
+			errorInfo = append(errorInfo, response.ErrorInfo{
+				Field:        EMERGENCY_CONTACT_NAME_FIELD,
+				ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, EMERGENCY_CONTACT_NAME_FIELD),
+			})
+		}
+		if r.EmergencyContact.Phone == constant.EMPTY_STRING {
+			errorInfo = append(errorInfo, response.ErrorInfo{
+				Field:        EMERGENCY_CONTACT_PHONE_FIELD,
+				ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, EMERGENCY_CONTACT_PHONE_FIELD),
+			})
+		}
+	}
+
+	// Minors can only be registered together with a guardian
+	if validBirthDate && IsMinor(r.DateOfBirth) {
+		guardian := r.Guardian
+		if guardian == nil {
+			guardian = &Contact{}
+		}
+		if guardian.Name == constant.EMPTY_STRING {
+			errorInfo = append(errorInfo, response.ErrorInfo{
+				Field:        GUARDIAN_NAME_FIELD,
+				ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, GUARDIAN_NAME_FIELD),
+			})
+		}
+		if guardian.Phone == constant.EMPTY_STRING {
+			errorInfo = append(errorInfo, response.ErrorInfo{
+				Field:        GUARDIAN_PHONE_FIELD,
+				ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, GUARDIAN_PHONE_FIELD),
+			})
+		}
+		if guardian.Relationship == constant.EMPTY_STRING {
+			errorInfo = append(errorInfo, response.ErrorInfo{
+				Field:        GUARDIAN_RELATIONSHIP_FIELD,
+				ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, GUARDIAN_RELATIONSHIP_FIELD),
+			})
+		}
+	}
+
+	return errorInfo
+}
+
+func isValidBloodType(bloodType string) bool {
+	for _, valid := range patientConstant.BloodTypes {
+		if bloodType == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gomajido/hospital-cms-golang/internal/helper/identity"
+	"github.com/gomajido/hospital-cms-golang/internal/module/patient/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/patient/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/response"
+	"github.com/google/uuid"
+)
+
+type PatientHandler struct {
+	patientUsecase domain.PatientUsecase
+}
+
+func NewPatientHandler(pu domain.PatientUsecase) *PatientHandler {
+	return &PatientHandler{
+		patientUsecase: pu,
+	}
+}
+
+func (h *PatientHandler) CreateProfile(c *fiber.Ctx) error {
+	var req domain.PatientRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+	req.UserID = userIdentity.UserID
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	patient, err := h.patientUsecase.CreateProfile(c.Context(), req)
+	if err != nil {
+		if errors.Is(err, constant.ErrPatientProfileExists) || errors.Is(err, constant.ErrNationalIDTaken) {
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Ok.WithData(patient))
+}
+
+func (h *PatientHandler) GetProfile(c *fiber.Ctx) error {
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	patient, err := h.patientUsecase.GetProfile(c.Context(), userIdentity.UserID)
+	if err != nil {
+		if errors.Is(err, constant.ErrPatientNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(patient))
+}
+
+func (h *PatientHandler) UpdateProfile(c *fiber.Ctx) error {
+	var req domain.PatientRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+	req.UserID = userIdentity.UserID
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	patient, err := h.patientUsecase.UpdateProfile(c.Context(), req)
+	if err != nil {
+		if errors.Is(err, constant.ErrPatientNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		if errors.Is(err, constant.ErrNationalIDTaken) {
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(patient))
+}
+
+func (h *PatientHandler) AddDependent(c *fiber.Ctx) error {
+	var req domain.PatientRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+	req.UserID = userIdentity.UserID
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	patient, err := h.patientUsecase.AddDependent(c.Context(), req)
+	if err != nil {
+		if errors.Is(err, constant.ErrNationalIDTaken) {
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		if errors.Is(err, constant.ErrTooManyDependents) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Ok.WithData(patient))
+}
+
+func (h *PatientHandler) ListDependents(c *fiber.Ctx) error {
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	patients, err := h.patientUsecase.ListDependents(c.Context(), userIdentity.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(patients))
+}
+
+func (h *PatientHandler) UpdateDependent(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
+	}
+
+	var req domain.PatientRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+	req.UserID = userIdentity.UserID
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	patient, err := h.patientUsecase.UpdateDependent(c.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, constant.ErrPatientNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		if errors.Is(err, constant.ErrNationalIDTaken) {
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(patient))
+}
+
+func (h *PatientHandler) RemoveDependent(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
+	}
+
+	userIdentity, err := identity.FromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.ErrUnprocessableEntity.WithError(err))
+	}
+
+	if err := h.patientUsecase.RemoveDependent(c.Context(), userIdentity.UserID, id); err != nil {
+		if errors.Is(err, constant.ErrPatientNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		if errors.Is(err, constant.ErrDependentHasBookings) {
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
+
+func (h *PatientHandler) GetByID(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
+	}
+
+	patient, err := h.patientUsecase.GetByID(c.Context(), id)
+	if err != nil {
+		if errors.Is(err, constant.ErrPatientNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(patient))
+}
+
+func (h *PatientHandler) List(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	patients, total, err := h.patientUsecase.List(c.Context(), c.Query("search"), page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+
+	listResponse := response.ListResponse{
+		Meta: response.MetaResponse{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+		Data: patients,
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(listResponse))
+}
@@ -0,0 +1,335 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/patient/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/patient/domain"
+)
+
+// mysqlErrDuplicateEntry is returned by MySQL when a unique index is violated
+const mysqlErrDuplicateEntry = 1062
+
+const patientColumns = `
+	id, user_id, managed_by, name, date_of_birth, gender, national_id, phone, address,
+	blood_type, allergies,
+	emergency_contact_name, emergency_contact_phone, emergency_contact_relationship,
+	guardian_name, guardian_phone, guardian_relationship,
+	created_at, updated_at`
+
+type patientRepository struct {
+	db *sql.DB
+}
+
+func NewPatientRepository(db *sql.DB) domain.PatientRepository {
+	return &patientRepository{
+		db: db,
+	}
+}
+
+// Create creates a patient profile
+func (r *patientRepository) Create(ctx context.Context, patient *domain.Patient) error {
+	query := `INSERT INTO patients (` + patientColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	now := time.Now()
+	patient.CreatedAt = now
+	patient.UpdatedAt = now
+
+	allergies, err := json.Marshal(allergiesOrEmpty(patient.Allergies))
+	if err != nil {
+		return err
+	}
+	emergency := contactColumns(patient.EmergencyContact)
+	guardian := contactColumns(patient.Guardian)
+
+	_, err = r.db.ExecContext(ctx, query,
+		patient.ID, uuidOrNull(patient.UserID), uuidOrNull(patient.ManagedBy), patient.Name, patient.DateOfBirth,
+		nullString(patient.Gender), nullString(patient.NationalID), nullString(patient.Phone),
+		nullString(patient.Address), nullString(patient.BloodType), allergies,
+		emergency[0], emergency[1], emergency[2],
+		guardian[0], guardian[1], guardian[2],
+		patient.CreatedAt, patient.UpdatedAt,
+	)
+	return mapDuplicateError(err)
+}
+
+// CreateFromUser creates the patient profile of a user from their account name and phone.
+// The birth date and gender stay unknown until the user completes the profile.
+func (r *patientRepository) CreateFromUser(ctx context.Context, userID uuid.UUID) (*domain.Patient, error) {
+	query := `INSERT INTO patients (id, user_id, name, phone, created_at, updated_at)
+		SELECT ?, id, name, phone, NOW(), NOW()
+		FROM users
+		WHERE id = ? AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, uuid.New(), userID)
+	if err != nil {
+		return nil, mapDuplicateError(err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, constant.ErrPatientNotFound
+	}
+
+	return r.GetByUserID(ctx, userID)
+}
+
+// GetByID gets a patient profile by ID
+func (r *patientRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Patient, error) {
+	query := `SELECT ` + patientColumns + ` FROM patients WHERE id = ?`
+
+	return scanPatient(r.db.QueryRowContext(ctx, query, id))
+}
+
+// GetByUserID gets the patient profile of a user account
+func (r *patientRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.Patient, error) {
+	query := `SELECT ` + patientColumns + ` FROM patients WHERE user_id = ?`
+
+	return scanPatient(r.db.QueryRowContext(ctx, query, userID))
+}
+
+// GetByManager gets the dependent profiles managed by a user account
+func (r *patientRepository) GetByManager(ctx context.Context, managerID uuid.UUID) ([]domain.Patient, error) {
+	query := `SELECT ` + patientColumns + ` FROM patients WHERE managed_by = ? ORDER BY name ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, managerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	patients := []domain.Patient{}
+	for rows.Next() {
+		patient, err := scanPatient(rows)
+		if err != nil {
+			return nil, err
+		}
+		patients = append(patients, *patient)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return patients, nil
+}
+
+// List lists patient profiles, optionally matching the name or national ID
+func (r *patientRepository) List(ctx context.Context, search string, page, limit int) ([]domain.Patient, int64, error) {
+	where := ""
+	args := []interface{}{}
+	if search != "" {
+		where = " WHERE name LIKE ? OR national_id = ?"
+		args = append(args, "%"+search+"%", search)
+	}
+
+	var total int64
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM patients"+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	query := `SELECT ` + patientColumns + ` FROM patients` + where + `
+		ORDER BY name ASC
+		LIMIT ? OFFSET ?`
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	patients := []domain.Patient{}
+	for rows.Next() {
+		patient, err := scanPatient(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		patients = append(patients, *patient)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return patients, total, nil
+}
+
+// Update replaces the demographics of a patient profile
+func (r *patientRepository) Update(ctx context.Context, patient *domain.Patient) error {
+	query := `UPDATE patients SET
+		name = ?, date_of_birth = ?, gender = ?, national_id = ?, phone = ?, address = ?,
+		blood_type = ?, allergies = ?,
+		emergency_contact_name = ?, emergency_contact_phone = ?, emergency_contact_relationship = ?,
+		guardian_name = ?, guardian_phone = ?, guardian_relationship = ?,
+		updated_at = ?
+		WHERE id = ?`
+
+	patient.UpdatedAt = time.Now()
+
+	allergies, err := json.Marshal(allergiesOrEmpty(patient.Allergies))
+	if err != nil {
+		return err
+	}
+	emergency := contactColumns(patient.EmergencyContact)
+	guardian := contactColumns(patient.Guardian)
+
+	result, err := r.db.ExecContext(ctx, query,
+		patient.Name, patient.DateOfBirth, nullString(patient.Gender),
+		nullString(patient.NationalID), nullString(patient.Phone), nullString(patient.Address),
+		nullString(patient.BloodType), allergies,
+		emergency[0], emergency[1], emergency[2],
+		guardian[0], guardian[1], guardian[2],
+		patient.UpdatedAt, patient.ID,
+	)
+	if err != nil {
+		return mapDuplicateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return constant.ErrPatientNotFound
+	}
+
+	return nil
+}
+
+// RemoveManager detaches a dependent from the account managing it. The record is kept for the
+// medical history of its appointments. It reports false, leaving the dependent managed, while
+// the dependent has upcoming scheduled appointments.
+func (r *patientRepository) RemoveManager(ctx context.Context, id, managerID uuid.UUID) (bool, error) {
+	query := `UPDATE patients SET managed_by = NULL, updated_at = ?
+		WHERE id = ? AND managed_by = ?
+		AND NOT EXISTS (
+			SELECT 1 FROM appointments
+			WHERE patient_id = ? AND status = 'scheduled' AND appointment_date >= CURDATE()
+		)`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id, managerID, id)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPatient(row rowScanner) (*domain.Patient, error) {
+	patient := &domain.Patient{}
+	var userID, managedBy uuid.NullUUID
+	var dateOfBirth sql.NullTime
+	var gender, nationalID, phone, address, bloodType sql.NullString
+	var allergies []byte
+	var emergency, guardian [3]sql.NullString
+
+	err := row.Scan(
+		&patient.ID, &userID, &managedBy, &patient.Name, &dateOfBirth, &gender,
+		&nationalID, &phone, &address, &bloodType, &allergies,
+		&emergency[0], &emergency[1], &emergency[2],
+		&guardian[0], &guardian[1], &guardian[2],
+		&patient.CreatedAt, &patient.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, constant.ErrPatientNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if userID.Valid {
+		patient.UserID = &userID.UUID
+	}
+	if managedBy.Valid {
+		patient.ManagedBy = &managedBy.UUID
+	}
+	if dateOfBirth.Valid {
+		patient.DateOfBirth = &dateOfBirth.Time
+	}
+	patient.Gender = gender.String
+	patient.NationalID = nationalID.String
+	patient.Phone = phone.String
+	patient.Address = address.String
+	patient.BloodType = bloodType.String
+	patient.EmergencyContact = contactFromColumns(emergency)
+	patient.Guardian = contactFromColumns(guardian)
+
+	patient.Allergies = []string{}
+	if len(allergies) > 0 {
+		if err := json.Unmarshal(allergies, &patient.Allergies); err != nil {
+			return nil, err
+		}
+	}
+
+	return patient, nil
+}
+
+// contactColumns returns the name, phone and relationship columns of a contact
+func contactColumns(contact *domain.Contact) [3]sql.NullString {
+	if contact == nil {
+		return [3]sql.NullString{}
+	}
+	return [3]sql.NullString{nullString(contact.Name), nullString(contact.Phone), nullString(contact.Relationship)}
+}
+
+func contactFromColumns(columns [3]sql.NullString) *domain.Contact {
+	if !columns[0].Valid && !columns[1].Valid {
+		return nil
+	}
+	return &domain.Contact{Name: columns[0].String, Phone: columns[1].String, Relationship: columns[2].String}
+}
+
+func nullString(value string) sql.NullString {
+	value = strings.TrimSpace(value)
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func uuidOrNull(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func allergiesOrEmpty(allergies []string) []string {
+	if allergies == nil {
+		return []string{}
+	}
+	return allergies
+}
+
+// mapDuplicateError converts unique index violations into the matching patient error
+func mapDuplicateError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+		if strings.Contains(mysqlErr.Message, "uk_patients_national_id") {
+			return constant.ErrNationalIDTaken
+		}
+		return constant.ErrPatientProfileExists
+	}
+	return err
+}
@@ -0,0 +1,31 @@
+package router
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/middleware"
+	"github.com/gomajido/hospital-cms-golang/internal/module/patient/handler"
+)
+
+// RegisterPatientRoutes registers all patient routes
+func RegisterPatientRoutes(router fiber.Router, h *handler.PatientHandler, authMiddleware *middleware.AuthMiddleware) {
+	patients := router.Group("/patients")
+	patients.Use(authMiddleware.Protected())
+
+	// Own profile
+	patients.Post("/me", h.CreateProfile)
+	patients.Get("/me", h.GetProfile)
+	patients.Put("/me", h.UpdateProfile)
+
+	// Dependents managed by the current user
+	patients.Post("/me/dependents", h.AddDependent)
+	patients.Get("/me/dependents", h.ListDependents)
+	patients.Put("/me/dependents/:id", h.UpdateDependent)
+	patients.Delete("/me/dependents/:id", h.RemoveDependent)
+
+	// Staff access to any patient record
+	readAny := authMiddleware.HasAbility(constant.PERMISSION_PATIENT_READ_ANY)
+	patients.Get("", readAny, h.List)
+	patients.Get("/:id", readAny, h.GetByID)
+}
@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/patient/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/patient/domain"
+)
+
+type patientUsecase struct {
+	patientRepo domain.PatientRepository
+}
+
+// NewPatientUsecase creates a new instance of patientUsecase
+func NewPatientUsecase(pr domain.PatientRepository) domain.PatientUsecase {
+	return &patientUsecase{
+		patientRepo: pr,
+	}
+}
+
+// CreateProfile creates the patient profile of a user, a user has at most one
+func (u *patientUsecase) CreateProfile(ctx context.Context, req domain.PatientRequest) (*domain.Patient, error) {
+	_, err := u.patientRepo.GetByUserID(ctx, req.UserID)
+	if err == nil {
+		return nil, constant.ErrPatientProfileExists
+	}
+	if !errors.Is(err, constant.ErrPatientNotFound) {
+		return nil, err
+	}
+
+	userID := req.UserID
+	patient := &domain.Patient{
+		ID:     uuid.New(),
+		UserID: &userID,
+	}
+	if err := applyRequest(patient, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.patientRepo.Create(ctx, patient); err != nil {
+		return nil, err
+	}
+
+	return patient, nil
+}
+
+// GetProfile gets the patient profile of a user
+func (u *patientUsecase) GetProfile(ctx context.Context, userID uuid.UUID) (*domain.Patient, error) {
+	return u.patientRepo.GetByUserID(ctx, userID)
+}
+
+// GetOrCreateProfile gets the patient profile of a user, creating one from their account when they
+// have none yet, so users who booked before profiles existed can keep booking for themselves
+func (u *patientUsecase) GetOrCreateProfile(ctx context.Context, userID uuid.UUID) (*domain.Patient, error) {
+	patient, err := u.patientRepo.GetByUserID(ctx, userID)
+	if !errors.Is(err, constant.ErrPatientNotFound) {
+		return patient, err
+	}
+
+	patient, err = u.patientRepo.CreateFromUser(ctx, userID)
+	if errors.Is(err, constant.ErrPatientProfileExists) {
+		// Created by a concurrent request
+		return u.patientRepo.GetByUserID(ctx, userID)
+	}
+	return patient, err
+}
+
+// UpdateProfile replaces the demographics of the patient profile of a user
+func (u *patientUsecase) UpdateProfile(ctx context.Context, req domain.PatientRequest) (*domain.Patient, error) {
+	patient, err := u.patientRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyRequest(patient, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.patientRepo.Update(ctx, patient); err != nil {
+		return nil, err
+	}
+
+	return patient, nil
+}
+
+// AddDependent creates a profile without its own login, managed by the requesting user
+func (u *patientUsecase) AddDependent(ctx context.Context, req domain.PatientRequest) (*domain.Patient, error) {
+	dependents, err := u.patientRepo.GetByManager(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(dependents) >= constant.MaxDependents {
+		return nil, constant.ErrTooManyDependents
+	}
+
+	managerID := req.UserID
+	patient := &domain.Patient{
+		ID:        uuid.New(),
+		ManagedBy: &managerID,
+	}
+	if err := applyRequest(patient, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.patientRepo.Create(ctx, patient); err != nil {
+		return nil, err
+	}
+
+	return patient, nil
+}
+
+// ListDependents lists the profiles managed by a user
+func (u *patientUsecase) ListDependents(ctx context.Context, userID uuid.UUID) ([]domain.Patient, error) {
+	return u.patientRepo.GetByManager(ctx, userID)
+}
+
+// UpdateDependent replaces the demographics of a dependent of the requesting user
+func (u *patientUsecase) UpdateDependent(ctx context.Context, id uuid.UUID, req domain.PatientRequest) (*domain.Patient, error) {
+	patient, err := u.patientRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !isManagedBy(patient, req.UserID) {
+		return nil, constant.ErrPatientNotFound
+	}
+
+	if err := applyRequest(patient, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.patientRepo.Update(ctx, patient); err != nil {
+		return nil, err
+	}
+
+	return patient, nil
+}
+
+// RemoveDependent stops the requesting user from managing a dependent, which frees a place
+// for a new one. Dependents with upcoming appointments are kept until those are cancelled.
+func (u *patientUsecase) RemoveDependent(ctx context.Context, userID, id uuid.UUID) error {
+	patient, err := u.patientRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !isManagedBy(patient, userID) {
+		return constant.ErrPatientNotFound
+	}
+
+	removed, err := u.patientRepo.RemoveManager(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return constant.ErrDependentHasBookings
+	}
+
+	return nil
+}
+
+// GetAuthorized gets a patient a user may act for: their own profile or one of their dependents.
+// Any other patient is reported as not found so profiles of other families are not disclosed.
+func (u *patientUsecase) GetAuthorized(ctx context.Context, userID, patientID uuid.UUID) (*domain.Patient, error) {
+	patient, err := u.patientRepo.GetByID(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	ownProfile := patient.UserID != nil && *patient.UserID == userID
+	if !ownProfile && !isManagedBy(patient, userID) {
+		return nil, constant.ErrPatientNotFound
+	}
+
+	return patient, nil
+}
+
+func (u *patientUsecase) GetByID(ctx context.Context, id uuid.UUID) (*domain.Patient, error) {
+	return u.patientRepo.GetByID(ctx, id)
+}
+
+func (u *patientUsecase) List(ctx context.Context, search string, page, limit int) ([]domain.Patient, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	return u.patientRepo.List(ctx, strings.TrimSpace(search), page, limit)
+}
+
+func isManagedBy(patient *domain.Patient, userID uuid.UUID) bool {
+	return patient.ManagedBy != nil && *patient.ManagedBy == userID
+}
+
+// applyRequest copies a validated request onto a patient. The guardian is only kept for
+// minors, an adult patient decides on their own.
+func applyRequest(patient *domain.Patient, req domain.PatientRequest) error {
+	dateOfBirth, err := time.Parse(constant.DateFormat, req.DateOfBirth)
+	if err != nil {
+		return err
+	}
+
+	allergies := make([]string, 0, len(req.Allergies))
+	for _, allergy := range req.Allergies {
+		allergies = append(allergies, strings.TrimSpace(allergy))
+	}
+
+	patient.Name = strings.TrimSpace(req.Name)
+	patient.DateOfBirth = &dateOfBirth
+	patient.Gender = req.Gender
+	patient.NationalID = strings.TrimSpace(req.NationalID)
+	patient.Phone = req.Phone
+	patient.Address = req.Address
+	patient.BloodType = req.BloodType
+	patient.Allergies = allergies
+	patient.EmergencyContact = req.EmergencyContact
+	patient.Guardian = nil
+	if domain.IsMinor(req.DateOfBirth) {
+		patient.Guardian = req.Guardian
+	}
+
+	return nil
+}
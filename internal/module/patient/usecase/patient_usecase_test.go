@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/patient/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/patient/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/module/patient/domain/mocks"
+)
+
+func newPatientRequest(userID uuid.UUID, dateOfBirth string) domain.PatientRequest {
+	return domain.PatientRequest{
+		UserID:      userID,
+		Name:        " Siti Rahma ",
+		DateOfBirth: dateOfBirth,
+		Gender:      constant.GenderFemale,
+		BloodType:   "O+",
+		Allergies:   []string{" penicillin "},
+		Guardian:    &domain.Contact{Name: "Rahma", Phone: "0812", Relationship: "mother"},
+	}
+}
+
+func TestPatientUsecase_CreateProfile(t *testing.T) {
+	userID := uuid.New()
+	child := time.Now().AddDate(-8, 0, 0).Format(constant.DateFormat)
+
+	tests := []struct {
+		name         string
+		dateOfBirth  string
+		existing     error
+		wantGuardian bool
+		wantErr      error
+	}{
+		{
+			name:         "Minor keeps the guardian",
+			dateOfBirth:  child,
+			existing:     constant.ErrPatientNotFound,
+			wantGuardian: true,
+		},
+		{
+			name:        "Adult drops the guardian",
+			dateOfBirth: "1990-05-17",
+			existing:    constant.ErrPatientNotFound,
+		},
+		{
+			name:        "Profile already exists",
+			dateOfBirth: "1990-05-17",
+			wantErr:     constant.ErrPatientProfileExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockPatientRepository(ctrl)
+			if tt.existing != nil {
+				repo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, tt.existing)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			} else {
+				repo.EXPECT().GetByUserID(gomock.Any(), userID).Return(&domain.Patient{ID: uuid.New()}, nil)
+			}
+
+			patient, err := NewPatientUsecase(repo).CreateProfile(context.Background(), newPatientRequest(userID, tt.dateOfBirth))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("CreateProfile() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateProfile() unexpected error = %v", err)
+			}
+
+			if patient.UserID == nil || *patient.UserID != userID || patient.Name != "Siti Rahma" {
+				t.Errorf("CreateProfile() = %+v, want a trimmed profile of the user", patient)
+			}
+			if len(patient.Allergies) != 1 || patient.Allergies[0] != "penicillin" {
+				t.Errorf("CreateProfile() allergies = %v, want [penicillin]", patient.Allergies)
+			}
+			if (patient.Guardian != nil) != tt.wantGuardian {
+				t.Errorf("CreateProfile() guardian = %+v, want guardian kept %v", patient.Guardian, tt.wantGuardian)
+			}
+		})
+	}
+}
+
+func TestPatientUsecase_GetOrCreateProfile(t *testing.T) {
+	userID := uuid.New()
+	profile := &domain.Patient{ID: uuid.New(), UserID: &userID, Name: "Siti Rahma"}
+
+	tests := []struct {
+		name    string
+		mock    func(repo *mocks.MockPatientRepository)
+		wantErr error
+	}{
+		{
+			name: "Existing profile",
+			mock: func(repo *mocks.MockPatientRepository) {
+				repo.EXPECT().GetByUserID(gomock.Any(), userID).Return(profile, nil)
+			},
+		},
+		{
+			name: "First booking creates the profile from the account",
+			mock: func(repo *mocks.MockPatientRepository) {
+				repo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, constant.ErrPatientNotFound)
+				repo.EXPECT().CreateFromUser(gomock.Any(), userID).Return(profile, nil)
+			},
+		},
+		{
+			name: "Profile created by a concurrent booking",
+			mock: func(repo *mocks.MockPatientRepository) {
+				gomock.InOrder(
+					repo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, constant.ErrPatientNotFound),
+					repo.EXPECT().CreateFromUser(gomock.Any(), userID).Return(nil, constant.ErrPatientProfileExists),
+					repo.EXPECT().GetByUserID(gomock.Any(), userID).Return(profile, nil),
+				)
+			},
+		},
+		{
+			name: "Deleted account",
+			mock: func(repo *mocks.MockPatientRepository) {
+				repo.EXPECT().GetByUserID(gomock.Any(), userID).Return(nil, constant.ErrPatientNotFound)
+				repo.EXPECT().CreateFromUser(gomock.Any(), userID).Return(nil, constant.ErrPatientNotFound)
+			},
+			wantErr: constant.ErrPatientNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockPatientRepository(ctrl)
+			tt.mock(repo)
+
+			patient, err := NewPatientUsecase(repo).GetOrCreateProfile(context.Background(), userID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetOrCreateProfile() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetOrCreateProfile() unexpected error = %v", err)
+			}
+			if patient.ID != profile.ID {
+				t.Errorf("GetOrCreateProfile() = %+v, want the profile of the user", patient)
+			}
+		})
+	}
+}
+
+func TestPatientRequest_Validate_Guardian(t *testing.T) {
+	child := time.Now().AddDate(-8, 0, 0).Format(constant.DateFormat)
+
+	withoutGuardian := newPatientRequest(uuid.New(), child)
+	withoutGuardian.Guardian = nil
+	if errs := withoutGuardian.Validate(); len(errs) != 3 {
+		t.Errorf("Validate() minor without guardian = %+v, want the three guardian fields", errs)
+	}
+
+	adult := newPatientRequest(uuid.New(), "1990-05-17")
+	adult.Guardian = nil
+	if errs := adult.Validate(); len(errs) != 0 {
+		t.Errorf("Validate() adult = %+v, want no errors", errs)
+	}
+
+	future := newPatientRequest(uuid.New(), time.Now().AddDate(0, 0, 2).Format(constant.DateFormat))
+	if errs := future.Validate(); len(errs) != 1 || errs[0].Field != domain.DATE_OF_BIRTH_FIELD {
+		t.Errorf("Validate() future birth date = %+v, want a date_of_birth error", errs)
+	}
+}
+
+func TestPatientUsecase_GetAuthorized(t *testing.T) {
+	userID := uuid.New()
+	otherID := uuid.New()
+
+	tests := []struct {
+		name    string
+		patient *domain.Patient
+		wantErr error
+	}{
+		{
+			name:    "Own profile",
+			patient: &domain.Patient{ID: uuid.New(), UserID: &userID},
+		},
+		{
+			name:    "Dependent of the user",
+			patient: &domain.Patient{ID: uuid.New(), ManagedBy: &userID},
+		},
+		{
+			name:    "Dependent of another user",
+			patient: &domain.Patient{ID: uuid.New(), ManagedBy: &otherID},
+			wantErr: constant.ErrPatientNotFound,
+		},
+		{
+			name:    "Profile of another user",
+			patient: &domain.Patient{ID: uuid.New(), UserID: &otherID},
+			wantErr: constant.ErrPatientNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockPatientRepository(ctrl)
+			repo.EXPECT().GetByID(gomock.Any(), tt.patient.ID).Return(tt.patient, nil)
+
+			patient, err := NewPatientUsecase(repo).GetAuthorized(context.Background(), userID, tt.patient.ID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetAuthorized() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || patient != tt.patient {
+				t.Errorf("GetAuthorized() = %+v, %v, want the patient", patient, err)
+			}
+		})
+	}
+}
+
+func TestPatientUsecase_AddDependent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	repo := mocks.NewMockPatientRepository(ctrl)
+	repo.EXPECT().GetByManager(gomock.Any(), userID).Return([]domain.Patient{}, nil)
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	child := time.Now().AddDate(-8, 0, 0).Format(constant.DateFormat)
+	patient, err := NewPatientUsecase(repo).AddDependent(context.Background(), newPatientRequest(userID, child))
+	if err != nil {
+		t.Fatalf("AddDependent() unexpected error = %v", err)
+	}
+	if patient.UserID != nil || patient.ManagedBy == nil || *patient.ManagedBy != userID {
+		t.Errorf("AddDependent() = %+v, want a profile without login managed by the user", patient)
+	}
+
+	repo.EXPECT().GetByManager(gomock.Any(), userID).Return(make([]domain.Patient, constant.MaxDependents), nil)
+	if _, err := NewPatientUsecase(repo).AddDependent(context.Background(), newPatientRequest(userID, child)); !errors.Is(err, constant.ErrTooManyDependents) {
+		t.Errorf("AddDependent() error = %v, want %v", err, constant.ErrTooManyDependents)
+	}
+}
+
+func TestPatientUsecase_RemoveDependent(t *testing.T) {
+	userID := uuid.New()
+	dependent := &domain.Patient{ID: uuid.New(), ManagedBy: &userID}
+	otherManager := uuid.New()
+	otherFamily := &domain.Patient{ID: uuid.New(), ManagedBy: &otherManager}
+
+	tests := []struct {
+		name    string
+		patient *domain.Patient
+		removed bool
+		wantErr error
+	}{
+		{name: "Dependent of the user", patient: dependent, removed: true},
+		{name: "Dependent with upcoming appointments", patient: dependent, wantErr: constant.ErrDependentHasBookings},
+		{name: "Dependent of another family", patient: otherFamily, wantErr: constant.ErrPatientNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockPatientRepository(ctrl)
+			repo.EXPECT().GetByID(gomock.Any(), tt.patient.ID).Return(tt.patient, nil)
+			if tt.patient == dependent {
+				repo.EXPECT().RemoveManager(gomock.Any(), tt.patient.ID, userID).Return(tt.removed, nil)
+			}
+
+			err := NewPatientUsecase(repo).RemoveDependent(context.Background(), userID, tt.patient.ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RemoveDependent() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
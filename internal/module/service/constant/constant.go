@@ -0,0 +1,18 @@
+package constant
+
+import "errors"
+
+const (
+	MaxNameLength = 255
+	MaxIconLength = 255
+
+	// ReservedSlug is routed to the staff listing and cannot be used by a service
+	ReservedSlug = "all"
+)
+
+// Common errors for service module
+var (
+	ErrServiceNotFound   = errors.New("service not found")
+	ErrServiceSlugTaken  = errors.New("a service with this slug already exists")
+	ErrServiceHasDoctors = errors.New("service still has doctors, move them or deactivate the service instead")
+)
@@ -0,0 +1,251 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/module/service/domain/service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/gomajido/hospital-cms-golang/internal/module/service/domain"
+	uuid "github.com/google/uuid"
+)
+
+// MockServiceRepository is a mock of ServiceRepository interface.
+type MockServiceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceRepositoryMockRecorder
+}
+
+// MockServiceRepositoryMockRecorder is the mock recorder for MockServiceRepository.
+type MockServiceRepositoryMockRecorder struct {
+	mock *MockServiceRepository
+}
+
+// NewMockServiceRepository creates a new mock instance.
+func NewMockServiceRepository(ctrl *gomock.Controller) *MockServiceRepository {
+	mock := &MockServiceRepository{ctrl: ctrl}
+	mock.recorder = &MockServiceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceRepository) EXPECT() *MockServiceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockServiceRepository) Create(ctx context.Context, service *domain.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, service)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceRepositoryMockRecorder) Create(ctx, service interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockServiceRepository)(nil).Create), ctx, service)
+}
+
+// Delete mocks base method.
+func (m *MockServiceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockServiceRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockServiceRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockServiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockServiceRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockServiceRepository)(nil).GetByID), ctx, id)
+}
+
+// GetBySlug mocks base method.
+func (m *MockServiceRepository) GetBySlug(ctx context.Context, slug string) (*domain.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySlug", ctx, slug)
+	ret0, _ := ret[0].(*domain.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySlug indicates an expected call of GetBySlug.
+func (mr *MockServiceRepositoryMockRecorder) GetBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlug", reflect.TypeOf((*MockServiceRepository)(nil).GetBySlug), ctx, slug)
+}
+
+// GetDoctors mocks base method.
+func (m *MockServiceRepository) GetDoctors(ctx context.Context, serviceID uuid.UUID) ([]domain.Doctor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDoctors", ctx, serviceID)
+	ret0, _ := ret[0].([]domain.Doctor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDoctors indicates an expected call of GetDoctors.
+func (mr *MockServiceRepositoryMockRecorder) GetDoctors(ctx, serviceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDoctors", reflect.TypeOf((*MockServiceRepository)(nil).GetDoctors), ctx, serviceID)
+}
+
+// List mocks base method.
+func (m *MockServiceRepository) List(ctx context.Context, activeOnly bool) ([]domain.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, activeOnly)
+	ret0, _ := ret[0].([]domain.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceRepositoryMockRecorder) List(ctx, activeOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockServiceRepository)(nil).List), ctx, activeOnly)
+}
+
+// Update mocks base method.
+func (m *MockServiceRepository) Update(ctx context.Context, service *domain.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, service)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockServiceRepositoryMockRecorder) Update(ctx, service interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockServiceRepository)(nil).Update), ctx, service)
+}
+
+// MockServiceUsecase is a mock of ServiceUsecase interface.
+type MockServiceUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceUsecaseMockRecorder
+}
+
+// MockServiceUsecaseMockRecorder is the mock recorder for MockServiceUsecase.
+type MockServiceUsecaseMockRecorder struct {
+	mock *MockServiceUsecase
+}
+
+// NewMockServiceUsecase creates a new mock instance.
+func NewMockServiceUsecase(ctrl *gomock.Controller) *MockServiceUsecase {
+	mock := &MockServiceUsecase{ctrl: ctrl}
+	mock.recorder = &MockServiceUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceUsecase) EXPECT() *MockServiceUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockServiceUsecase) Create(ctx context.Context, req domain.ServiceRequest) (*domain.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(*domain.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceUsecaseMockRecorder) Create(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockServiceUsecase)(nil).Create), ctx, req)
+}
+
+// Delete mocks base method.
+func (m *MockServiceUsecase) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockServiceUsecaseMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockServiceUsecase)(nil).Delete), ctx, id)
+}
+
+// GetBySlug mocks base method.
+func (m *MockServiceUsecase) GetBySlug(ctx context.Context, slug string) (*domain.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySlug", ctx, slug)
+	ret0, _ := ret[0].(*domain.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySlug indicates an expected call of GetBySlug.
+func (mr *MockServiceUsecaseMockRecorder) GetBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlug", reflect.TypeOf((*MockServiceUsecase)(nil).GetBySlug), ctx, slug)
+}
+
+// GetDoctors mocks base method.
+func (m *MockServiceUsecase) GetDoctors(ctx context.Context, slug string) ([]domain.Doctor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDoctors", ctx, slug)
+	ret0, _ := ret[0].([]domain.Doctor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDoctors indicates an expected call of GetDoctors.
+func (mr *MockServiceUsecaseMockRecorder) GetDoctors(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDoctors", reflect.TypeOf((*MockServiceUsecase)(nil).GetDoctors), ctx, slug)
+}
+
+// List mocks base method.
+func (m *MockServiceUsecase) List(ctx context.Context, includeInactive bool) ([]domain.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, includeInactive)
+	ret0, _ := ret[0].([]domain.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceUsecaseMockRecorder) List(ctx, includeInactive interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockServiceUsecase)(nil).List), ctx, includeInactive)
+}
+
+// Update mocks base method.
+func (m *MockServiceUsecase) Update(ctx context.Context, id uuid.UUID, req domain.ServiceRequest) (*domain.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, req)
+	ret0, _ := ret[0].(*domain.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockServiceUsecaseMockRecorder) Update(ctx, id, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockServiceUsecase)(nil).Update), ctx, id, req)
+}
@@ -0,0 +1,11 @@
+package domain
+
+// ServiceRequest represents the request to create or update a service
+type ServiceRequest struct {
+	Name        string `json:"name"`
+	Slug        string `json:"slug"` // Generated from the name when empty
+	Icon        string `json:"icon"`
+	Description string `json:"description"`
+	SortOrder   int    `json:"sort_order"`
+	IsActive    *bool  `json:"is_active"` // Defaults to active
+}
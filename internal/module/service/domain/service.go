@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Service represents a medical service or department doctors belong to
+type Service struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Icon        string    `json:"icon,omitempty"`
+	Description string    `json:"description"`
+	SortOrder   int       `json:"sort_order"`
+	IsActive    bool      `json:"is_active"`
+	DoctorCount int       `json:"doctor_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Doctor represents minimal doctor information listed under a service
+type Doctor struct {
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	Slug           string    `json:"slug"` // Links to the public doctor profile
+	Specialization string    `json:"specialization"`
+	Degree         string    `json:"degree"`
+	Experience     string    `json:"experience"`
+	Description    string    `json:"description"`
+}
+
+// ServiceRepository defines the interface for service data operations
+type ServiceRepository interface {
+	Create(ctx context.Context, service *Service) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Service, error)
+	GetBySlug(ctx context.Context, slug string) (*Service, error)
+	List(ctx context.Context, activeOnly bool) ([]Service, error)
+	Update(ctx context.Context, service *Service) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetDoctors(ctx context.Context, serviceID uuid.UUID) ([]Doctor, error)
+}
+
+// ServiceUsecase defines the interface for service business logic
+type ServiceUsecase interface {
+	Create(ctx context.Context, req ServiceRequest) (*Service, error)
+	Update(ctx context.Context, id uuid.UUID, req ServiceRequest) (*Service, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, includeInactive bool) ([]Service, error)
+	GetBySlug(ctx context.Context, slug string) (*Service, error)
+	GetDoctors(ctx context.Context, slug string) ([]Doctor, error)
+}
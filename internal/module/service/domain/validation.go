@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gosimple/slug"
+
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	serviceConstant "github.com/gomajido/hospital-cms-golang/internal/module/service/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/response"
+)
+
+const (
+	// Field names for validation messages
+	NAME_FIELD       = "name"
+	SLUG_FIELD       = "slug"
+	ICON_FIELD       = "icon"
+	SORT_ORDER_FIELD = "sort_order"
+)
+
+// Validate validates ServiceRequest
+func (r *ServiceRequest) Validate() []response.ErrorInfo {
+	var errorInfo []response.ErrorInfo
+
+	name := strings.TrimSpace(r.Name)
+	if name == constant.EMPTY_STRING {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        NAME_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_REQUIRED, NAME_FIELD),
+		})
+	} else if len(name) > serviceConstant.MaxNameLength {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        NAME_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MAX_LENGTH, NAME_FIELD, serviceConstant.MaxNameLength),
+		})
+	}
+
+	if r.Slug != constant.EMPTY_STRING && !slug.IsSlug(r.Slug) {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        SLUG_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_INVALID_FORMAT, SLUG_FIELD, "lowercase-words-with-dashes"),
+		})
+	}
+
+	if len(r.Icon) > serviceConstant.MaxIconLength {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        ICON_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MAX_LENGTH, ICON_FIELD, serviceConstant.MaxIconLength),
+		})
+	}
+
+	if r.SortOrder < 0 {
+		errorInfo = append(errorInfo, response.ErrorInfo{
+			Field:        SORT_ORDER_FIELD,
+			ErrorMessage: fmt.Sprintf(constant.VALIDATION_MIN_VALUE, SORT_ORDER_FIELD, "0"),
+		})
+	}
+
+	return errorInfo
+}
+
+// ServiceSlug returns the requested slug, or one generated from the name
+func (r *ServiceRequest) ServiceSlug() string {
+	if r.Slug != constant.EMPTY_STRING {
+		return r.Slug
+	}
+	return slug.Make(r.Name)
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gomajido/hospital-cms-golang/internal/module/service/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/service/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/response"
+	"github.com/google/uuid"
+)
+
+type ServiceHandler struct {
+	serviceUsecase domain.ServiceUsecase
+}
+
+func NewServiceHandler(su domain.ServiceUsecase) *ServiceHandler {
+	return &ServiceHandler{
+		serviceUsecase: su,
+	}
+}
+
+func (h *ServiceHandler) List(c *fiber.Ctx) error {
+	services, err := h.serviceUsecase.List(c.Context(), false)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(services))
+}
+
+func (h *ServiceHandler) ListAll(c *fiber.Ctx) error {
+	services, err := h.serviceUsecase.List(c.Context(), true)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(services))
+}
+
+func (h *ServiceHandler) GetBySlug(c *fiber.Ctx) error {
+	service, err := h.serviceUsecase.GetBySlug(c.Context(), c.Params("slug"))
+	if err != nil {
+		if errors.Is(err, constant.ErrServiceNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(service))
+}
+
+func (h *ServiceHandler) GetDoctors(c *fiber.Ctx) error {
+	doctors, err := h.serviceUsecase.GetDoctors(c.Context(), c.Params("slug"))
+	if err != nil {
+		if errors.Is(err, constant.ErrServiceNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(doctors))
+}
+
+func (h *ServiceHandler) Create(c *fiber.Ctx) error {
+	var req domain.ServiceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	service, err := h.serviceUsecase.Create(c.Context(), req)
+	if err != nil {
+		if errors.Is(err, constant.ErrServiceSlugTaken) {
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Ok.WithData(service))
+}
+
+func (h *ServiceHandler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
+	}
+
+	var req domain.ServiceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrBadRequest)
+	}
+
+	if errors := req.Validate(); len(errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithErrorInfo(errors))
+	}
+
+	service, err := h.serviceUsecase.Update(c.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, constant.ErrServiceNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		if errors.Is(err, constant.ErrServiceSlugTaken) {
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok.WithData(service))
+}
+
+func (h *ServiceHandler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrInvalidParam.WithError(err))
+	}
+
+	if err := h.serviceUsecase.Delete(c.Context(), id); err != nil {
+		if errors.Is(err, constant.ErrServiceNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(response.ErrRecordNotFound.WithError(err))
+		}
+		if errors.Is(err, constant.ErrServiceHasDoctors) {
+			return c.Status(fiber.StatusConflict).JSON(response.ErrConflict.WithError(err))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrInternalServer.WithError(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Ok)
+}
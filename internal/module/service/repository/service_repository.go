@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/service/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/service/domain"
+)
+
+// mysqlErrDuplicateEntry is returned by MySQL when a unique index is violated
+const mysqlErrDuplicateEntry = 1062
+
+// serviceColumns selects a service together with the number of its doctors
+const serviceColumns = `
+	s.id, s.name, s.slug, s.icon, s.description, s.sort_order, s.is_active,
+	(SELECT COUNT(*) FROM doctors d WHERE d.service_id = s.id) AS doctor_count,
+	s.created_at, s.updated_at`
+
+type serviceRepository struct {
+	db *sql.DB
+}
+
+func NewServiceRepository(db *sql.DB) domain.ServiceRepository {
+	return &serviceRepository{
+		db: db,
+	}
+}
+
+// Create creates a service
+func (r *serviceRepository) Create(ctx context.Context, service *domain.Service) error {
+	query := `INSERT INTO services (
+			id, name, slug, icon, description, sort_order, is_active, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	now := time.Now()
+	service.CreatedAt = now
+	service.UpdatedAt = now
+
+	_, err := r.db.ExecContext(ctx, query,
+		service.ID, service.Name, service.Slug, nullString(service.Icon), service.Description,
+		service.SortOrder, service.IsActive, service.CreatedAt, service.UpdatedAt,
+	)
+	return mapDuplicateError(err)
+}
+
+// GetByID gets a service by ID
+func (r *serviceRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Service, error) {
+	query := `SELECT ` + serviceColumns + ` FROM services s WHERE s.id = ?`
+
+	return scanService(r.db.QueryRowContext(ctx, query, id))
+}
+
+// GetBySlug gets a service by slug
+func (r *serviceRepository) GetBySlug(ctx context.Context, slug string) (*domain.Service, error) {
+	query := `SELECT ` + serviceColumns + ` FROM services s WHERE s.slug = ?`
+
+	return scanService(r.db.QueryRowContext(ctx, query, slug))
+}
+
+// List lists services in display order
+func (r *serviceRepository) List(ctx context.Context, activeOnly bool) ([]domain.Service, error) {
+	query := `SELECT ` + serviceColumns + ` FROM services s`
+	if activeOnly {
+		query += ` WHERE s.is_active = 1`
+	}
+	query += ` ORDER BY s.sort_order ASC, s.name ASC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	services := []domain.Service{}
+	for rows.Next() {
+		service, err := scanService(rows)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, *service)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return services, nil
+}
+
+// Update updates a service
+func (r *serviceRepository) Update(ctx context.Context, service *domain.Service) error {
+	query := `UPDATE services SET
+		name = ?, slug = ?, icon = ?, description = ?, sort_order = ?, is_active = ?, updated_at = ?
+		WHERE id = ?`
+
+	service.UpdatedAt = time.Now()
+
+	result, err := r.db.ExecContext(ctx, query,
+		service.Name, service.Slug, nullString(service.Icon), service.Description,
+		service.SortOrder, service.IsActive, service.UpdatedAt, service.ID,
+	)
+	if err != nil {
+		return mapDuplicateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return constant.ErrServiceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes a service that has no doctors
+func (r *serviceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	// Doctors cascade with their service, so refuse while any are assigned
+	result, err := r.db.ExecContext(ctx,
+		"DELETE FROM services WHERE id = ? AND NOT EXISTS (SELECT 1 FROM doctors WHERE service_id = ?)", id, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		var exists bool
+		err := r.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM services WHERE id = ?)", id).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return constant.ErrServiceHasDoctors
+		}
+		return constant.ErrServiceNotFound
+	}
+
+	return nil
+}
+
+// GetDoctors gets the doctors of a service
+func (r *serviceRepository) GetDoctors(ctx context.Context, serviceID uuid.UUID) ([]domain.Doctor, error) {
+	query := `
+		SELECT id, name, slug, specialization, degree, experience, description
+		FROM doctors
+		WHERE service_id = ?
+		ORDER BY name ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	doctors := []domain.Doctor{}
+	for rows.Next() {
+		var doctor domain.Doctor
+		var description sql.NullString
+		err := rows.Scan(
+			&doctor.ID, &doctor.Name, &doctor.Slug, &doctor.Specialization,
+			&doctor.Degree, &doctor.Experience, &description,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		doctor.Description = description.String
+		doctors = append(doctors, doctor)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return doctors, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanService(row rowScanner) (*domain.Service, error) {
+	service := &domain.Service{}
+	var icon, description sql.NullString
+
+	err := row.Scan(
+		&service.ID, &service.Name, &service.Slug, &icon, &description,
+		&service.SortOrder, &service.IsActive, &service.DoctorCount,
+		&service.CreatedAt, &service.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, constant.ErrServiceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	service.Icon = icon.String
+	service.Description = description.String
+
+	return service, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// mapDuplicateError converts a violation of the unique slug index into ErrServiceSlugTaken
+func mapDuplicateError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+		return constant.ErrServiceSlugTaken
+	}
+	return err
+}
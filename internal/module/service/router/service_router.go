@@ -0,0 +1,35 @@
+package router
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/gomajido/hospital-cms-golang/internal/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/middleware"
+	"github.com/gomajido/hospital-cms-golang/internal/module/service/handler"
+)
+
+// publicRateLimit throttles anonymous reads of the services
+var publicRateLimit = middleware.RateLimitPolicy{Name: "services:public", Limit: 120, Window: time.Minute}
+
+// RegisterServiceRoutes registers all service routes
+func RegisterServiceRoutes(router fiber.Router, h *handler.ServiceHandler, authMiddleware *middleware.AuthMiddleware, rateLimiter *middleware.RateLimiter) {
+	services := router.Group("/services")
+	protected := authMiddleware.Protected()
+	writeService := authMiddleware.HasAbility(constant.PERMISSION_SERVICE_WRITE)
+
+	// Staff listing including inactive services, registered before the slug routes
+	services.Get("/all", protected, writeService, h.ListAll)
+
+	// Public routes
+	limitPublic := rateLimiter.Limit(publicRateLimit)
+	services.Get("", limitPublic, h.List)
+	services.Get("/:slug", limitPublic, h.GetBySlug)
+	services.Get("/:slug/doctors", limitPublic, h.GetDoctors)
+
+	// Protected routes for service writers only
+	services.Post("", protected, writeService, h.Create)
+	services.Put("/:id", protected, writeService, h.Update)
+	services.Delete("/:id", protected, writeService, h.Delete)
+}
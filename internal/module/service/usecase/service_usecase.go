@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/service/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/service/domain"
+)
+
+type serviceUsecase struct {
+	serviceRepo domain.ServiceRepository
+}
+
+// NewServiceUsecase creates a new instance of serviceUsecase
+func NewServiceUsecase(sr domain.ServiceRepository) domain.ServiceUsecase {
+	return &serviceUsecase{
+		serviceRepo: sr,
+	}
+}
+
+func (u *serviceUsecase) Create(ctx context.Context, req domain.ServiceRequest) (*domain.Service, error) {
+	service := &domain.Service{
+		ID: uuid.New(),
+	}
+	if err := applyRequest(service, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.serviceRepo.Create(ctx, service); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+func (u *serviceUsecase) Update(ctx context.Context, id uuid.UUID, req domain.ServiceRequest) (*domain.Service, error) {
+	service, err := u.serviceRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyRequest(service, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.serviceRepo.Update(ctx, service); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+// Delete deletes a service, services that still have doctors can only be deactivated
+func (u *serviceUsecase) Delete(ctx context.Context, id uuid.UUID) error {
+	return u.serviceRepo.Delete(ctx, id)
+}
+
+// List lists the services in display order, inactive services are only listed for staff
+func (u *serviceUsecase) List(ctx context.Context, includeInactive bool) ([]domain.Service, error) {
+	return u.serviceRepo.List(ctx, !includeInactive)
+}
+
+// GetBySlug gets an active service, inactive services are reported as not found
+func (u *serviceUsecase) GetBySlug(ctx context.Context, slug string) (*domain.Service, error) {
+	service, err := u.serviceRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if !service.IsActive {
+		return nil, constant.ErrServiceNotFound
+	}
+
+	return service, nil
+}
+
+// GetDoctors lists the doctors of an active service
+func (u *serviceUsecase) GetDoctors(ctx context.Context, slug string) ([]domain.Doctor, error) {
+	service, err := u.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.serviceRepo.GetDoctors(ctx, service.ID)
+}
+
+// applyRequest copies a validated request onto a service
+func applyRequest(service *domain.Service, req domain.ServiceRequest) error {
+	slug := req.ServiceSlug()
+	if slug == constant.ReservedSlug {
+		return constant.ErrServiceSlugTaken
+	}
+
+	service.Name = strings.TrimSpace(req.Name)
+	service.Slug = slug
+	service.Icon = strings.TrimSpace(req.Icon)
+	service.Description = req.Description
+	service.SortOrder = req.SortOrder
+	service.IsActive = req.IsActive == nil || *req.IsActive
+
+	return nil
+}
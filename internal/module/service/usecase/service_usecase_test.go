@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+
+	"github.com/gomajido/hospital-cms-golang/internal/module/service/constant"
+	"github.com/gomajido/hospital-cms-golang/internal/module/service/domain"
+	"github.com/gomajido/hospital-cms-golang/internal/module/service/domain/mocks"
+)
+
+func TestServiceUsecase_Create(t *testing.T) {
+	inactive := false
+
+	tests := []struct {
+		name       string
+		req        domain.ServiceRequest
+		wantSlug   string
+		wantActive bool
+		wantErr    error
+	}{
+		{
+			name:       "Slug generated from the name",
+			req:        domain.ServiceRequest{Name: " Obstetrics & Gynecology "},
+			wantSlug:   "obstetrics-and-gynecology",
+			wantActive: true,
+		},
+		{
+			name:     "Custom slug and inactive",
+			req:      domain.ServiceRequest{Name: "Ear, Nose and Throat", Slug: "ent", IsActive: &inactive},
+			wantSlug: "ent",
+		},
+		{
+			name:    "Reserved slug",
+			req:     domain.ServiceRequest{Name: "All"},
+			wantErr: constant.ErrServiceSlugTaken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockServiceRepository(ctrl)
+			if tt.wantErr == nil {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			service, err := NewServiceUsecase(repo).Create(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Create() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create() unexpected error = %v", err)
+			}
+			if service.Slug != tt.wantSlug || service.IsActive != tt.wantActive {
+				t.Errorf("Create() = %+v, want slug %s and active %v", service, tt.wantSlug, tt.wantActive)
+			}
+		})
+	}
+}
+
+func TestServiceUsecase_GetDoctors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	active := &domain.Service{ID: uuid.New(), Slug: "cardiology", IsActive: true}
+	doctors := []domain.Doctor{{ID: uuid.New(), Name: "Dr. Heart"}}
+
+	repo := mocks.NewMockServiceRepository(ctrl)
+	repo.EXPECT().GetBySlug(gomock.Any(), "cardiology").Return(active, nil)
+	repo.EXPECT().GetDoctors(gomock.Any(), active.ID).Return(doctors, nil)
+	repo.EXPECT().GetBySlug(gomock.Any(), "closed").Return(&domain.Service{ID: uuid.New(), Slug: "closed"}, nil)
+
+	uc := NewServiceUsecase(repo)
+	got, err := uc.GetDoctors(context.Background(), "cardiology")
+	if err != nil || len(got) != 1 || got[0].ID != doctors[0].ID {
+		t.Errorf("GetDoctors() = %+v, %v, want the doctors of the service", got, err)
+	}
+
+	if _, err := uc.GetDoctors(context.Background(), "closed"); !errors.Is(err, constant.ErrServiceNotFound) {
+		t.Errorf("GetDoctors() inactive service error = %v, want %v", err, constant.ErrServiceNotFound)
+	}
+}
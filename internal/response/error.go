@@ -47,6 +47,12 @@ var (
 		Code:     "4002",
 	}
 
+	ErrConflict = CustomResponse{
+		HttpCode: http.StatusConflict,
+		Message:  "conflict",
+		Code:     "4009",
+	}
+
 	StatusTooManyRequests = CustomResponse{
 		HttpCode: http.StatusTooManyRequests,
 		Message:  "StatusTooManyRequests",
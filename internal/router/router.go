@@ -1,16 +1,29 @@
 package router
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gomajido/hospital-cms-golang/config"
 	"github.com/gomajido/hospital-cms-golang/internal/dependency"
+	"github.com/gomajido/hospital-cms-golang/internal/middleware"
 	appointmentRouter "github.com/gomajido/hospital-cms-golang/internal/module/appointment/router"
 	articleRouter "github.com/gomajido/hospital-cms-golang/internal/module/article/router"
 	authRouter "github.com/gomajido/hospital-cms-golang/internal/module/auth/router"
 	doctorRouter "github.com/gomajido/hospital-cms-golang/internal/module/doctor/router"
+	patientRouter "github.com/gomajido/hospital-cms-golang/internal/module/patient/router"
+	serviceRouter "github.com/gomajido/hospital-cms-golang/internal/module/service/router"
 )
 
+// apiRateLimit applies to every API request on top of the policies set by each module router.
+// Requests with a valid token are identified first so they are counted per user rather than per IP.
+var apiRateLimit = middleware.RateLimitPolicy{Name: "api", Limit: 300, Window: time.Minute}
+
+// ipRateLimit runs before the token is looked up and bounds the token lookups a single address can
+// cause with made up tokens. It is above apiRateLimit so users sharing an address are held by their own limit first.
+var ipRateLimit = middleware.RateLimitPolicy{Name: "api_ip", Limit: 1200, Window: time.Minute}
+
 type Router struct {
 	ApplicationHandler *dependency.ApplicationHandler
 	HttpConfig         config.HttpConfig
@@ -24,6 +37,10 @@ func Run(r *Router) error {
 		ServerHeader:  "Apexa",
 		AppName:       "Apexa Core API",
 		BodyLimit:     100 * 1024 * 1024, // 10mb
+		// Client IPs are read from the proxy header only when the request comes from a trusted proxy
+		ProxyHeader:             r.HttpConfig.ProxyHeader,
+		EnableTrustedProxyCheck: len(r.HttpConfig.TrustedProxies) > 0,
+		TrustedProxies:          r.HttpConfig.TrustedProxies,
 	})
 	app.Use(recover.New())
 
@@ -37,19 +54,30 @@ func Run(r *Router) error {
 	})
 
 	// API routes
-	v1 := app.Group("/api/v1")
+	rateLimiter := r.ApplicationHandler.RateLimiter
+	v1 := app.Group("/api/v1",
+		rateLimiter.Limit(ipRateLimit), // No identity is set yet, so counted per IP
+		r.ApplicationHandler.AuthMiddleware.Identify(),
+		rateLimiter.Limit(apiRateLimit),
+	)
 
 	// Register auth routes
-	authRouter.RegisterAuthRoutes(v1, r.ApplicationHandler.AuthHandler, r.ApplicationHandler.AuthMiddleware)
+	authRouter.RegisterAuthRoutes(v1, r.ApplicationHandler.AuthHandler, r.ApplicationHandler.AuthMiddleware, rateLimiter)
 
 	// Register article routes
-	articleRouter.RegisterArticleRoutes(v1, r.ApplicationHandler.ArticleHandler, r.ApplicationHandler.AuthMiddleware)
+	articleRouter.RegisterArticleRoutes(v1, r.ApplicationHandler.ArticleHandler, r.ApplicationHandler.AuthMiddleware, rateLimiter)
+
+	// Register service routes
+	serviceRouter.RegisterServiceRoutes(v1, r.ApplicationHandler.ServiceHandler, r.ApplicationHandler.AuthMiddleware, rateLimiter)
 
 	// Register doctor routes
-	doctorRouter.RegisterDoctorRoutes(v1, r.ApplicationHandler.DoctorHandler, r.ApplicationHandler.AuthMiddleware)
+	doctorRouter.RegisterDoctorRoutes(v1, r.ApplicationHandler.DoctorHandler, r.ApplicationHandler.AuthMiddleware, rateLimiter)
 
 	// Register appointment routes
-	appointmentRouter.RegisterAppointmentRoutes(v1, r.ApplicationHandler.AppointmentHandler, r.ApplicationHandler.AuthMiddleware)
+	appointmentRouter.RegisterAppointmentRoutes(v1, r.ApplicationHandler.AppointmentHandler, r.ApplicationHandler.AuthMiddleware, rateLimiter)
+
+	// Register patient routes
+	patientRouter.RegisterPatientRoutes(v1, r.ApplicationHandler.PatientHandler, r.ApplicationHandler.AuthMiddleware)
 
 	err := app.Listen(r.HttpConfig.Address)
 	if err != nil {
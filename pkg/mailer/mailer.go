@@ -0,0 +1,15 @@
+package mailer
+
+import "context"
+
+// Message is a single email to deliver
+type Message struct {
+	To       []string
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+type IMailerProviderRepository interface {
+	Send(ctx context.Context, message *Message) error
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/mailer/mailer.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	mailer "github.com/gomajido/hospital-cms-golang/pkg/mailer"
+)
+
+// MockIMailerProviderRepository is a mock of IMailerProviderRepository interface.
+type MockIMailerProviderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMailerProviderRepositoryMockRecorder
+}
+
+// MockIMailerProviderRepositoryMockRecorder is the mock recorder for MockIMailerProviderRepository.
+type MockIMailerProviderRepositoryMockRecorder struct {
+	mock *MockIMailerProviderRepository
+}
+
+// NewMockIMailerProviderRepository creates a new mock instance.
+func NewMockIMailerProviderRepository(ctrl *gomock.Controller) *MockIMailerProviderRepository {
+	mock := &MockIMailerProviderRepository{ctrl: ctrl}
+	mock.recorder = &MockIMailerProviderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMailerProviderRepository) EXPECT() *MockIMailerProviderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockIMailerProviderRepository) Send(ctx context.Context, message *mailer.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockIMailerProviderRepositoryMockRecorder) Send(ctx, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockIMailerProviderRepository)(nil).Send), ctx, message)
+}
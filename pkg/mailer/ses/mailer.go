@@ -0,0 +1,58 @@
+package ses
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+	"github.com/gomajido/hospital-cms-golang/config"
+	"github.com/gomajido/hospital-cms-golang/pkg/mailer"
+)
+
+// SESMailer delivers emails through Amazon SES
+type SESMailer struct {
+	Cfg    *config.SESConfig
+	Client *sesv2.Client
+}
+
+func NewSESMailer(cfg *config.SESConfig, client *sesv2.Client) mailer.IMailerProviderRepository {
+	return &SESMailer{
+		Cfg:    cfg,
+		Client: client,
+	}
+}
+
+// Send delivers a message as a simple SES email
+func (m *SESMailer) Send(ctx context.Context, message *mailer.Message) error {
+	if len(message.To) == 0 {
+		return fmt.Errorf("[pkg/mailer/ses][Send]message has no recipient")
+	}
+
+	body := &types.Body{}
+	if message.TextBody != "" {
+		body.Text = &types.Content{Data: aws.String(message.TextBody), Charset: aws.String("UTF-8")}
+	}
+	if message.HTMLBody != "" {
+		body.Html = &types.Content{Data: aws.String(message.HTMLBody), Charset: aws.String("UTF-8")}
+	}
+
+	from := (&mail.Address{Name: m.Cfg.FromName, Address: m.Cfg.FromAddress}).String()
+	_, err := m.Client.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(from),
+		Destination:      &types.Destination{ToAddresses: message.To},
+		Content: &types.EmailContent{
+			Simple: &types.Message{
+				Subject: &types.Content{Data: aws.String(message.Subject), Charset: aws.String("UTF-8")},
+				Body:    body,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("[pkg/mailer/ses][Send]%w", err)
+	}
+
+	return nil
+}
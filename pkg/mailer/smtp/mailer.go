@@ -0,0 +1,159 @@
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/gomajido/hospital-cms-golang/config"
+	"github.com/gomajido/hospital-cms-golang/pkg/mailer"
+)
+
+// SMTPMailer delivers emails through an SMTP server
+type SMTPMailer struct {
+	Cfg  *config.SMTPConfig
+	Auth smtp.Auth
+}
+
+func NewSMTPMailer(cfg *config.SMTPConfig, auth *smtp.Auth) mailer.IMailerProviderRepository {
+	m := &SMTPMailer{
+		Cfg: cfg,
+	}
+	if auth != nil {
+		m.Auth = *auth
+	}
+	return m
+}
+
+// Send delivers a message. With UseTLS the connection is TLS from the start,
+// otherwise STARTTLS is used whenever the server offers it.
+func (m *SMTPMailer) Send(ctx context.Context, message *mailer.Message) error {
+	if len(message.To) == 0 {
+		return fmt.Errorf("[pkg/mailer/smtp][Send]message has no recipient")
+	}
+
+	body, err := buildMessage(m.from(), message)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(m.Cfg.SMTPServer, m.Cfg.SMTPPort)
+	tlsConfig := &tls.Config{
+		ServerName:         m.Cfg.SMTPServer,
+		InsecureSkipVerify: m.Cfg.InsecureSkipVerify,
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	var conn net.Conn
+	if m.Cfg.UseTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("[pkg/mailer/smtp][Send]dial %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.Cfg.SMTPServer)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("[pkg/mailer/smtp][Send]%w", err)
+	}
+	defer client.Close()
+
+	if !m.Cfg.UseTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("[pkg/mailer/smtp][Send]starttls: %w", err)
+			}
+		}
+	}
+
+	if m.Auth != nil {
+		if ok, _ := client.Extension("AUTH"); ok {
+			if err := client.Auth(m.Auth); err != nil {
+				return fmt.Errorf("[pkg/mailer/smtp][Send]auth: %w", err)
+			}
+		}
+	}
+
+	if err := client.Mail(m.Cfg.FromAddress); err != nil {
+		return fmt.Errorf("[pkg/mailer/smtp][Send]mail from: %w", err)
+	}
+	for _, to := range message.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("[pkg/mailer/smtp][Send]rcpt to %s: %w", to, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("[pkg/mailer/smtp][Send]data: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("[pkg/mailer/smtp][Send]write: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("[pkg/mailer/smtp][Send]data: %w", err)
+	}
+
+	return client.Quit()
+}
+
+func (m *SMTPMailer) from() string {
+	return (&mail.Address{Name: m.Cfg.FromName, Address: m.Cfg.FromAddress}).String()
+}
+
+// buildMessage renders the headers and a multipart/alternative body
+func buildMessage(from string, message *mailer.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	headers := []string{
+		"From: " + from,
+		"To: " + strings.Join(message.To, ", "),
+		"Subject: " + mime.QEncoding.Encode("utf-8", message.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=" + writer.Boundary(),
+	}
+	buf.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	parts := []struct {
+		contentType string
+		body        string
+	}{
+		{contentType: "text/plain; charset=utf-8", body: message.TextBody},
+		{contentType: "text/html; charset=utf-8", body: message.HTMLBody},
+	}
+	for _, p := range parts {
+		if p.body == "" {
+			continue
+		}
+		part, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {p.contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := part.Write([]byte(p.body)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,120 @@
+package smtp
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/gomajido/hospital-cms-golang/config"
+	"github.com/gomajido/hospital-cms-golang/pkg/mailer"
+)
+
+// fakeSMTPServer is a minimal local SMTP stand-in that records what it receives
+type fakeSMTPServer struct {
+	listener net.Listener
+	received chan string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	s := &fakeSMTPServer{listener: listener, received: make(chan string, 1)}
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTPServer) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	var transcript strings.Builder
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		transcript.WriteString(line)
+
+		switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL"), strings.HasPrefix(cmd, "RCPT"):
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				transcript.WriteString(line)
+			}
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 Bye")
+			s.received <- transcript.String()
+			return
+		default:
+			reply("500 unknown command")
+		}
+	}
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	host, port, _ := net.SplitHostPort(server.listener.Addr().String())
+
+	m := NewSMTPMailer(&config.SMTPConfig{
+		SMTPServer:  host,
+		SMTPPort:    port,
+		FromAddress: "no-reply@example.com",
+		FromName:    "Hospital",
+	}, nil)
+
+	err := m.Send(context.Background(), &mailer.Message{
+		To:       []string{"patient@example.com"},
+		Subject:  "Verify your email",
+		TextBody: "Open the link",
+		HTMLBody: "<p>Open the link</p>",
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	transcript := <-server.received
+	for _, want := range []string{
+		"MAIL FROM:<no-reply@example.com>",
+		"RCPT TO:<patient@example.com>",
+		"Subject: Verify your email",
+		"Content-Type: text/plain; charset=utf-8",
+		"<p>Open the link</p>",
+	} {
+		if !strings.Contains(transcript, want) {
+			t.Errorf("Send() transcript missing %q:\n%s", want, transcript)
+		}
+	}
+}
+
+func TestSMTPMailer_SendWithoutRecipient(t *testing.T) {
+	m := NewSMTPMailer(&config.SMTPConfig{SMTPServer: "127.0.0.1", SMTPPort: "1"}, nil)
+
+	if err := m.Send(context.Background(), &mailer.Message{Subject: "Hello"}); err == nil {
+		t.Error("Send() error = nil, want an error for a message without recipient")
+	}
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/oidc/oidc.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	oidc "github.com/gomajido/hospital-cms-golang/pkg/oidc"
+)
+
+// MockIOIDCProviderRepository is a mock of IOIDCProviderRepository interface.
+type MockIOIDCProviderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOIDCProviderRepositoryMockRecorder
+}
+
+// MockIOIDCProviderRepositoryMockRecorder is the mock recorder for MockIOIDCProviderRepository.
+type MockIOIDCProviderRepositoryMockRecorder struct {
+	mock *MockIOIDCProviderRepository
+}
+
+// NewMockIOIDCProviderRepository creates a new mock instance.
+func NewMockIOIDCProviderRepository(ctrl *gomock.Controller) *MockIOIDCProviderRepository {
+	mock := &MockIOIDCProviderRepository{ctrl: ctrl}
+	mock.recorder = &MockIOIDCProviderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOIDCProviderRepository) EXPECT() *MockIOIDCProviderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AuthCodeURL mocks base method.
+func (m *MockIOIDCProviderRepository) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthCodeURL", ctx, state, nonce, codeChallenge)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthCodeURL indicates an expected call of AuthCodeURL.
+func (mr *MockIOIDCProviderRepositoryMockRecorder) AuthCodeURL(ctx, state, nonce, codeChallenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthCodeURL", reflect.TypeOf((*MockIOIDCProviderRepository)(nil).AuthCodeURL), ctx, state, nonce, codeChallenge)
+}
+
+// Exchange mocks base method.
+func (m *MockIOIDCProviderRepository) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*oidc.Claims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", ctx, code, codeVerifier, nonce)
+	ret0, _ := ret[0].(*oidc.Claims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockIOIDCProviderRepositoryMockRecorder) Exchange(ctx, code, codeVerifier, nonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockIOIDCProviderRepository)(nil).Exchange), ctx, code, codeVerifier, nonce)
+}
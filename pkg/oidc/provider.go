@@ -0,0 +1,310 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gomajido/hospital-cms-golang/config"
+)
+
+// clockSkew is the leeway allowed on the time claims of an ID token
+const clockSkew = time.Minute
+
+var (
+	ErrInvalidIDToken = errors.New("invalid id token")
+	ErrNonceMismatch  = errors.New("id token nonce mismatch")
+)
+
+var defaultScopes = []string{"openid", "email", "profile"}
+
+// metadata is the part of the discovery document the code flow needs
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider talks to an OpenID Connect provider. The discovery document and the
+// signing keys are fetched on first use and cached, keys are refetched when an
+// unknown key ID shows up so provider key rotation is picked up.
+type Provider struct {
+	Cfg    *config.OIDCProviderConfig
+	Client *http.Client
+
+	mu       sync.Mutex
+	metadata *metadata
+	keys     map[string]*rsa.PublicKey
+}
+
+func NewProvider(cfg *config.OIDCProviderConfig, client *http.Client) IOIDCProviderRepository {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Provider{Cfg: cfg, Client: client}
+}
+
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	scopes := p.Cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultScopes
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.Cfg.ClientID},
+		"redirect_uri":          {p.Cfg.RedirectURL},
+		"scope":                 {strings.Join(scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return meta.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Claims, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.Cfg.RedirectURL},
+		"client_id":     {p.Cfg.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.Cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.Cfg.ClientID), url.QueryEscape(p.Cfg.ClientSecret))
+	}
+
+	var token struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := p.do(req, &token); err != nil {
+		return nil, fmt.Errorf("[pkg/oidc][Exchange]%w", err)
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("[pkg/oidc][Exchange]token response has no id_token")
+	}
+
+	claims, err := p.verify(ctx, meta, token.IDToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Nonce != nonce {
+		return nil, ErrNonceMismatch
+	}
+
+	return claims, nil
+}
+
+// verify checks the RS256 signature and the issuer, audience and expiry of an ID token
+func (p *Provider) verify(ctx context.Context, meta *metadata, rawToken string) (*Claims, error) {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidIDToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil || header.Alg != "RS256" {
+		return nil, ErrInvalidIDToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidIDToken
+	}
+
+	key, err := p.key(ctx, meta, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, ErrInvalidIDToken
+	}
+
+	var payload struct {
+		Claims
+		Issuer   string   `json:"iss"`
+		Audience audience `json:"aud"`
+		Expiry   int64    `json:"exp"`
+	}
+	if err := decodeSegment(parts[1], &payload); err != nil {
+		return nil, ErrInvalidIDToken
+	}
+
+	if payload.Issuer != meta.Issuer || !payload.Audience.contains(p.Cfg.ClientID) || payload.Subject == "" {
+		return nil, ErrInvalidIDToken
+	}
+	if time.Now().After(time.Unix(payload.Expiry, 0).Add(clockSkew)) {
+		return nil, ErrInvalidIDToken
+	}
+
+	return &payload.Claims, nil
+}
+
+// discover fetches the discovery document of the issuer once
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	issuer := strings.TrimRight(p.Cfg.Issuer, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &metadata{}
+	if err := p.do(req, meta); err != nil {
+		return nil, fmt.Errorf("[pkg/oidc][discover]%w", err)
+	}
+
+	if strings.TrimRight(meta.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("[pkg/oidc][discover]issuer mismatch: got %q, want %q", meta.Issuer, p.Cfg.Issuer)
+	}
+
+	p.metadata = meta
+	return meta, nil
+}
+
+// key returns the signing key with the given ID, refetching the key set when it is unknown
+func (p *Provider) key(ctx context.Context, meta *metadata, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, meta.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := p.do(req, &set); err != nil {
+		return nil, fmt.Errorf("[pkg/oidc][key]%w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+		e, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+		if errN != nil || errE != nil {
+			continue
+		}
+		keys[jwk.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	p.keys = keys
+
+	key, ok := keys[kid]
+	if !ok {
+		return nil, ErrInvalidIDToken
+	}
+	return key, nil
+}
+
+// do sends a request and decodes its JSON response
+func (p *Provider) do(req *http.Request, out interface{}) error {
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %s returned %d: %s", req.Method, req.URL.Path, resp.StatusCode, body)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func decodeSegment(segment string, out interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+// audience accepts the aud claim as a single string or as an array
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(b, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+func (a audience) contains(clientID string) bool {
+	for _, aud := range a {
+		if aud == clientID {
+			return true
+		}
+	}
+	return false
+}
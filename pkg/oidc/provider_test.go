@@ -0,0 +1,224 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gomajido/hospital-cms-golang/config"
+)
+
+// mockServer is a minimal OpenID Connect provider issuing RS256 ID tokens
+type mockServer struct {
+	*httptest.Server
+	t      *testing.T
+	key    *rsa.PrivateKey // Signs the ID tokens
+	jwk    *rsa.PublicKey  // Published in the key set
+	kid    string
+	claims map[string]interface{} // Claims of the next ID token, iss/aud/exp are filled in
+	codes  map[string]string      // Authorization code => PKCE challenge
+}
+
+func newMockServer(t *testing.T) *mockServer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	m := &mockServer{t: t, key: key, jwk: &key.PublicKey, kid: "key-1", codes: map[string]string{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 m.URL,
+			"authorization_endpoint": m.URL + "/authorize",
+			"token_endpoint":         m.URL + "/token",
+			"jwks_uri":               m.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"use": "sig",
+				"kid": m.kid,
+				"n":   base64.RawURLEncoding.EncodeToString(m.jwk.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(m.jwk.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if id, secret, ok := r.BasicAuth(); !ok || id != "client" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		challenge, ok := m.codes[r.PostForm.Get("code")]
+		if !ok || CodeChallenge(r.PostForm.Get("code_verifier")) != challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		delete(m.codes, r.PostForm.Get("code"))
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "access", "token_type": "Bearer", "id_token": m.idToken()})
+	})
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+
+	return m
+}
+
+// authorize plays the user consenting, returning the code sent back to the redirect URL
+func (m *mockServer) authorize(authURL string) string {
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		m.t.Fatalf("url.Parse() error = %v", err)
+	}
+	query := parsed.Query()
+	if query.Get("code_challenge_method") != "S256" || query.Get("client_id") != "client" {
+		m.t.Fatalf("authorization request = %v, want an S256 PKCE request of the client", query)
+	}
+
+	m.claims["nonce"] = query.Get("nonce")
+	m.codes["code"] = query.Get("code_challenge")
+	return "code"
+}
+
+func (m *mockServer) idToken() string {
+	claims := map[string]interface{}{"iss": m.URL, "aud": "client", "exp": time.Now().Add(time.Hour).Unix()}
+	for k, v := range m.claims {
+		claims[k] = v
+	}
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": m.kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, m.key, crypto.SHA256, digest[:])
+	if err != nil {
+		m.t.Fatalf("SignPKCS1v15() error = %v", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestProvider_CodeFlow(t *testing.T) {
+	tests := []struct {
+		name    string
+		claims  map[string]interface{}
+		tamper  func(m *mockServer, verifier, nonce *string)
+		wantErr bool
+	}{
+		{
+			name:   "Valid login",
+			claims: map[string]interface{}{"sub": "user-1", "email": "doctor@hospital.test", "email_verified": true, "name": "Doctor"},
+		},
+		{
+			name:   "Audience as an array",
+			claims: map[string]interface{}{"sub": "user-1", "aud": []string{"other", "client"}},
+		},
+		{
+			name:    "Wrong code verifier",
+			claims:  map[string]interface{}{"sub": "user-1"},
+			tamper:  func(m *mockServer, verifier, nonce *string) { *verifier += "x" },
+			wantErr: true,
+		},
+		{
+			name:    "Replayed nonce",
+			claims:  map[string]interface{}{"sub": "user-1"},
+			tamper:  func(m *mockServer, verifier, nonce *string) { *nonce = "another-nonce" },
+			wantErr: true,
+		},
+		{
+			name:    "Token for another client",
+			claims:  map[string]interface{}{"sub": "user-1", "aud": "other"},
+			wantErr: true,
+		},
+		{
+			name:    "Expired token",
+			claims:  map[string]interface{}{"sub": "user-1", "exp": time.Now().Add(-time.Hour).Unix()},
+			wantErr: true,
+		},
+		{
+			name:   "Token signed by an unknown key",
+			claims: map[string]interface{}{"sub": "user-1"},
+			tamper: func(m *mockServer, verifier, nonce *string) {
+				other, _ := rsa.GenerateKey(rand.Reader, 2048)
+				m.key = other // Signs with a key the JWKS does not publish
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockServer(t)
+			server.claims = tt.claims
+			provider := NewProvider(&config.OIDCProviderConfig{
+				Issuer:       server.URL,
+				ClientID:     "client",
+				ClientSecret: "secret",
+				RedirectURL:  "https://hospital.test/auth/callback",
+			}, server.Client())
+
+			verifier, err := GenerateCodeVerifier()
+			if err != nil {
+				t.Fatalf("GenerateCodeVerifier() error = %v", err)
+			}
+			nonce := "nonce"
+
+			authURL, err := provider.AuthCodeURL(context.Background(), "state", nonce, CodeChallenge(verifier))
+			if err != nil {
+				t.Fatalf("AuthCodeURL() error = %v", err)
+			}
+			if !strings.HasPrefix(authURL, server.URL+"/authorize?") {
+				t.Errorf("AuthCodeURL() = %v, want the authorization endpoint", authURL)
+			}
+			code := server.authorize(authURL)
+
+			if tt.tamper != nil {
+				tt.tamper(server, &verifier, &nonce)
+			}
+
+			claims, err := provider.Exchange(context.Background(), code, verifier, nonce)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Exchange() = %+v, want an error", claims)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Exchange() unexpected error = %v", err)
+			}
+			if claims.Subject != "user-1" {
+				t.Errorf("Exchange() subject = %v, want user-1", claims.Subject)
+			}
+			if email, _ := tt.claims["email"].(string); claims.Email != email || claims.EmailVerified != (email != "") {
+				t.Errorf("Exchange() = %+v, want the email claims of the ID token", claims)
+			}
+		})
+	}
+}
+
+func TestProvider_DiscoveryFailure(t *testing.T) {
+	server := newMockServer(t)
+	provider := NewProvider(&config.OIDCProviderConfig{Issuer: server.URL + "/tenant", ClientID: "client"}, server.Client())
+
+	// No discovery document is served for the configured issuer
+	if _, err := provider.AuthCodeURL(context.Background(), "state", "nonce", "challenge"); err == nil {
+		t.Error("AuthCodeURL() error = nil, want a discovery error")
+	}
+}